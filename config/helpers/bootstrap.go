@@ -24,6 +24,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math"
 	"strings"
 	"text/template"
 	"time"
@@ -57,6 +58,22 @@ type TransformFileFunc func(bs []byte) ([]byte, error)
 type Option struct {
 	// TransformFileFunc is a function that transforms a resource file before being applied to the cluster.
 	TransformFile TransformFileFunc
+	// Retry, if set, replaces the default of retrying all resources every second.
+	Retry *RetryPolicy
+}
+
+// RetryPolicy controls how Bootstrap retries after some resources failed to be created.
+type RetryPolicy struct {
+	// FailedOnly retries only the resources that failed before, instead of all of them.
+	FailedOnly bool
+	// Backoff is the delay between retries. Its steps are ignored, the retries only stop
+	// when the context is done.
+	Backoff wait.Backoff
+}
+
+// RetryOption sets the retry policy of the bootstrap process.
+func RetryOption(policy RetryPolicy) Option {
+	return Option{Retry: &policy}
 }
 
 // ReplaceOption allows to customize the bootstrap process.
@@ -83,23 +100,50 @@ func Bootstrap(ctx context.Context, discoveryClient discovery.DiscoveryInterface
 
 	// bootstrap non-crd resources
 	transformers := make([]TransformFileFunc, 0, len(opts))
+	retry := RetryPolicy{Backoff: wait.Backoff{Duration: time.Second}}
 	for _, opt := range opts {
-		transformers = append(transformers, opt.TransformFile)
+		if opt.TransformFile != nil {
+			transformers = append(transformers, opt.TransformFile)
+		}
+		if opt.Retry != nil {
+			retry = *opt.Retry
+		}
 	}
-	return wait.PollUntilContextCancel(ctx, time.Second, true, func(ctx context.Context) (bool, error) {
-		if err := CreateResourcesFromFS(ctx, dynamicClient, mapper, batteriesIncluded, fs, transformers...); err != nil {
-			klog.FromContext(ctx).WithValues("err", err).Info("failed to bootstrap resources, retrying")
-			// invalidate cache if resources not found
-			// xref: https://github.com/kcp-dev/kcp/issues/655
-			cache.Invalidate()
-			return false, nil
+
+	// created holds the documents created successfully, if only failed ones are retried.
+	var created sets.Set[string]
+	if retry.FailedOnly {
+		created = sets.New[string]()
+	}
+
+	backoff := retry.Backoff
+	backoff.Steps = math.MaxInt32
+	for {
+		err := createResourcesFromFS(ctx, dynamicClient, mapper, batteriesIncluded, fs, created, transformers...)
+		if err == nil {
+			return nil
+		}
+		klog.FromContext(ctx).WithValues("err", err).Info("failed to bootstrap resources, retrying")
+		// invalidate cache if resources not found
+		// xref: https://github.com/kcp-dev/kcp/issues/655
+		cache.Invalidate()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff.Step()):
 		}
-		return true, nil
-	})
+	}
 }
 
 // CreateResourcesFromFS creates all resources from a filesystem.
 func CreateResourcesFromFS(ctx context.Context, client dynamic.Interface, mapper meta.RESTMapper, batteriesIncluded sets.Set[string], fs embed.FS, transformers ...TransformFileFunc) error {
+	return createResourcesFromFS(ctx, client, mapper, batteriesIncluded, fs, nil, transformers...)
+}
+
+// createResourcesFromFS creates all resources from a filesystem. If created is not nil,
+// documents in it are skipped, and documents created successfully are added to it.
+func createResourcesFromFS(ctx context.Context, client dynamic.Interface, mapper meta.RESTMapper, batteriesIncluded sets.Set[string], fs embed.FS, created sets.Set[string], transformers ...TransformFileFunc) error {
 	files, err := fs.ReadDir(".")
 	if err != nil {
 		return err
@@ -110,7 +154,7 @@ func CreateResourcesFromFS(ctx context.Context, client dynamic.Interface, mapper
 		if f.IsDir() {
 			continue
 		}
-		if err := CreateResourceFromFS(ctx, client, mapper, batteriesIncluded, f.Name(), fs, transformers...); err != nil {
+		if err := createResourceFromFS(ctx, client, mapper, batteriesIncluded, f.Name(), fs, created, transformers...); err != nil {
 			errs = append(errs, err)
 		}
 	}
@@ -119,6 +163,10 @@ func CreateResourcesFromFS(ctx context.Context, client dynamic.Interface, mapper
 
 // CreateResourceFromFS creates given resource file.
 func CreateResourceFromFS(ctx context.Context, client dynamic.Interface, mapper meta.RESTMapper, batteriesIncluded sets.Set[string], filename string, fs embed.FS, transformers ...TransformFileFunc) error {
+	return createResourceFromFS(ctx, client, mapper, batteriesIncluded, filename, fs, nil, transformers...)
+}
+
+func createResourceFromFS(ctx context.Context, client dynamic.Interface, mapper meta.RESTMapper, batteriesIncluded sets.Set[string], filename string, fs embed.FS, created sets.Set[string], transformers ...TransformFileFunc) error {
 	raw, err := fs.ReadFile(filename)
 	if err != nil {
 		return fmt.Errorf("could not read %s: %w", filename, err)
@@ -140,6 +188,10 @@ func CreateResourceFromFS(ctx context.Context, client dynamic.Interface, mapper
 		if len(bytes.TrimSpace(doc)) == 0 {
 			continue
 		}
+		key := fmt.Sprintf("%s#%d", filename, i)
+		if created.Has(key) {
+			continue
+		}
 
 		for _, transformer := range transformers {
 			doc, err = transformer(doc)
@@ -148,8 +200,10 @@ func CreateResourceFromFS(ctx context.Context, client dynamic.Interface, mapper
 			}
 		}
 
-		if err := createResourceFromFS(ctx, client, mapper, doc, batteriesIncluded); err != nil {
+		if err := createResourceFromRaw(ctx, client, mapper, doc, batteriesIncluded); err != nil {
 			errs = append(errs, fmt.Errorf("failed to create resource %s doc %d: %w", filename, i, err))
+		} else if created != nil {
+			created.Insert(key)
 		}
 	}
 	return utilerrors.NewAggregate(errs)
@@ -158,7 +212,7 @@ func CreateResourceFromFS(ctx context.Context, client dynamic.Interface, mapper
 const annotationCreateOnlyKey = "bootstrap.kcp.io/create-only"
 const annotationBattery = "bootstrap.kcp.io/battery"
 
-func createResourceFromFS(ctx context.Context, client dynamic.Interface, mapper meta.RESTMapper, raw []byte, batteriesIncluded sets.Set[string]) error {
+func createResourceFromRaw(ctx context.Context, client dynamic.Interface, mapper meta.RESTMapper, raw []byte, batteriesIncluded sets.Set[string]) error {
 	logger := klog.FromContext(ctx)
 	type Input struct {
 		Batteries map[string]bool
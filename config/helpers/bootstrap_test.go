@@ -0,0 +1,95 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helpers
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+//go:embed testdata/*.yaml
+var testdata embed.FS
+
+func TestCreateResourceFromFSRetries(t *testing.T) {
+	configMaps := schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}, meta.RESTScopeNamespace)
+
+	tests := map[string]struct {
+		failedOnly bool
+
+		wantRetried []string
+	}{
+		"retry all": {
+			wantRetried: []string{"first", "second", "third"},
+		},
+		"retry failed only": {
+			failedOnly:  true,
+			wantRetried: []string{"second"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{configMaps: "ConfigMapList"})
+
+			var attempts []string
+			failing := sets.New[string]("second")
+			client.PrependReactor("create", "configmaps", func(action clienttesting.Action) (bool, runtime.Object, error) {
+				name := action.(clienttesting.CreateAction).GetObject().(*unstructured.Unstructured).GetName()
+				attempts = append(attempts, name)
+				if failing.Has(name) {
+					return true, nil, errors.New("boom")
+				}
+				return false, nil, nil
+			})
+
+			var created sets.Set[string]
+			if tc.failedOnly {
+				created = sets.New[string]()
+			}
+
+			err := createResourceFromFS(context.Background(), client, mapper, sets.New[string](), "testdata/configmaps.yaml", testdata, created)
+			require.Error(t, err)
+			require.Equal(t, []string{"first", "second", "third"}, attempts)
+
+			attempts = nil
+			failing = sets.New[string]()
+			err = createResourceFromFS(context.Background(), client, mapper, sets.New[string](), "testdata/configmaps.yaml", testdata, created)
+			require.NoError(t, err)
+			require.Equal(t, tc.wantRetried, attempts)
+
+			for _, name := range []string{"first", "second", "third"} {
+				_, err := client.Resource(configMaps).Namespace("default").Get(context.Background(), name, metav1.GetOptions{})
+				require.NoError(t, err, "expected %s to be created", name)
+			}
+		})
+	}
+}
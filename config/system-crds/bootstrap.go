@@ -39,17 +39,13 @@ import (
 //go:embed *.yaml
 var fs embed.FS
 
-// Bootstrap creates CRDs and the resources in this package by continuously retrying the list.
-// This is blocking, i.e. it only returns (with error) when the context is closed or with nil when
-// the bootstrapping is successfully completed.
-func Bootstrap(ctx context.Context, crdClient apiextensionsclient.Interface, discoveryClient discovery.DiscoveryInterface, dynamicClient dynamic.Interface, batteriesIncluded sets.Set[string]) error {
-	logger := klog.FromContext(ctx)
-	// This is the full list of CRDs that kcp owns and manages in the system:system-crds logical cluster. Our custom CRD
-	// lister currently has a hard-coded list of which system CRDs are made available to which workspaces. See
-	// pkg/server/apiextensions.go newSystemCRDProvider for the list. These CRDs should never be installed in any other
-	// logical cluster.
-	// TODO(sttts): get rid of this and enforce/support schema evolution while allowing wildcard informers to work
-	crds := []metav1.GroupResource{
+// CRDs returns the full list of CRDs that kcp owns and manages in the system:system-crds logical cluster. Our custom CRD
+// lister currently has a hard-coded list of which system CRDs are made available to which workspaces. See
+// pkg/server/apiextensions.go newSystemCRDProvider for the list. These CRDs should never be installed in any other
+// logical cluster.
+// TODO(sttts): get rid of this and enforce/support schema evolution while allowing wildcard informers to work
+func CRDs() []metav1.GroupResource {
+	return []metav1.GroupResource{
 		{Group: apis.GroupName, Resource: "apiexports"},
 		{Group: apis.GroupName, Resource: "apibindings"},
 		{Group: apis.GroupName, Resource: "apiresourceschemas"},
@@ -57,6 +53,14 @@ func Bootstrap(ctx context.Context, crdClient apiextensionsclient.Interface, dis
 		{Group: core.GroupName, Resource: "logicalclusters"},
 		{Group: apis.GroupName, Resource: "apiconversions"},
 	}
+}
+
+// Bootstrap creates CRDs and the resources in this package by continuously retrying the list.
+// This is blocking, i.e. it only returns (with error) when the context is closed or with nil when
+// the bootstrapping is successfully completed.
+func Bootstrap(ctx context.Context, crdClient apiextensionsclient.Interface, discoveryClient discovery.DiscoveryInterface, dynamicClient dynamic.Interface, batteriesIncluded sets.Set[string]) error {
+	logger := klog.FromContext(ctx)
+	crds := CRDs()
 
 	if err := wait.PollUntilContextCancel(ctx, time.Second, true, func(ctx context.Context) (bool, error) {
 		if err := configcrds.Create(ctx, crdClient.ApiextensionsV1().CustomResourceDefinitions(), crds...); err != nil {
@@ -45,6 +45,7 @@ import (
 	"github.com/kcp-dev/kcp/pkg/admission/apibindingfinalizer"
 	"github.com/kcp-dev/kcp/pkg/admission/apiexport"
 	"github.com/kcp-dev/kcp/pkg/admission/apiexportendpointslice"
+	"github.com/kcp-dev/kcp/pkg/admission/apiexportfinalizer"
 	"github.com/kcp-dev/kcp/pkg/admission/apiresourceschema"
 	"github.com/kcp-dev/kcp/pkg/admission/crdnooverlappinggvr"
 	"github.com/kcp-dev/kcp/pkg/admission/kubequota"
@@ -62,6 +63,7 @@ import (
 	kcpvalidatingadmissionpolicy "github.com/kcp-dev/kcp/pkg/admission/validatingadmissionpolicy"
 	kcpvalidatingwebhook "github.com/kcp-dev/kcp/pkg/admission/validatingwebhook"
 	"github.com/kcp-dev/kcp/pkg/admission/workspace"
+	"github.com/kcp-dev/kcp/pkg/admission/workspacearchive"
 	"github.com/kcp-dev/kcp/pkg/admission/workspacetype"
 	"github.com/kcp-dev/kcp/pkg/admission/workspacetypeexists"
 )
@@ -71,6 +73,7 @@ var AllOrderedPlugins = beforeWebhooks(kubeapiserveroptions.AllOrderedPlugins,
 	workspacenamespacelifecycle.PluginName,
 	apiresourceschema.PluginName,
 	workspace.PluginName,
+	workspacearchive.PluginName,
 	logicalclusterfinalizer.PluginName,
 	shard.PluginName,
 	workspacetype.PluginName,
@@ -79,6 +82,7 @@ var AllOrderedPlugins = beforeWebhooks(kubeapiserveroptions.AllOrderedPlugins,
 	apiexport.PluginName,
 	apibinding.PluginName,
 	apibindingfinalizer.PluginName,
+	apiexportfinalizer.PluginName,
 	apiexportendpointslice.PluginName,
 	kcpmutatingwebhook.PluginName,
 	kcpvalidatingadmissionpolicy.PluginName,
@@ -108,6 +112,7 @@ func beforeWebhooks(recommended []string, plugins ...string) []string {
 // The order of registration is irrelevant, see AllOrderedPlugins for execution order.
 func RegisterAllKcpAdmissionPlugins(plugins *admission.Plugins) {
 	workspace.Register(plugins)
+	workspacearchive.Register(plugins)
 	logicalclusterfinalizer.Register(plugins)
 	shard.Register(plugins)
 	workspacetype.Register(plugins)
@@ -117,6 +122,7 @@ func RegisterAllKcpAdmissionPlugins(plugins *admission.Plugins) {
 	apiexport.Register(plugins)
 	apibinding.Register(plugins)
 	apibindingfinalizer.Register(plugins)
+	apiexportfinalizer.Register(plugins)
 	apiexportendpointslice.Register(plugins)
 	workspacenamespacelifecycle.Register(plugins)
 	kcpmutatingwebhook.Register(plugins)
@@ -140,6 +146,7 @@ var defaultOnPluginsInKcp = sets.New[string](
 
 	// KCP
 	workspace.PluginName,
+	workspacearchive.PluginName,
 	logicalclusterfinalizer.PluginName,
 	shard.PluginName,
 	workspacetype.PluginName,
@@ -149,6 +156,7 @@ var defaultOnPluginsInKcp = sets.New[string](
 	apiexport.PluginName,
 	apibinding.PluginName,
 	apibindingfinalizer.PluginName,
+	apiexportfinalizer.PluginName,
 	apiexportendpointslice.PluginName,
 	kcpmutatingwebhook.PluginName,
 	kcpvalidatingadmissionpolicy.PluginName,
@@ -0,0 +1,133 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspacearchive
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apiserver/pkg/admission"
+	"k8s.io/apiserver/pkg/authentication/user"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+
+	kcpinitializers "github.com/kcp-dev/kcp/pkg/admission/initializers"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	kcpinformers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions"
+	corev1alpha1listers "github.com/kcp-dev/kcp/sdk/client/listers/core/v1alpha1"
+)
+
+const (
+	PluginName = "kcp.io/WorkspaceArchive"
+)
+
+// reviewResources are not persisted. Creating them only asks the server for an answer, hence
+// they stay allowed in archived workspaces.
+var reviewResources = sets.New[schema.GroupResource](
+	schema.GroupResource{Group: "authentication.k8s.io", Resource: "tokenreviews"},
+	schema.GroupResource{Group: "authentication.k8s.io", Resource: "selfsubjectreviews"},
+	schema.GroupResource{Group: "authorization.k8s.io", Resource: "subjectaccessreviews"},
+	schema.GroupResource{Group: "authorization.k8s.io", Resource: "localsubjectaccessreviews"},
+	schema.GroupResource{Group: "authorization.k8s.io", Resource: "selfsubjectaccessreviews"},
+	schema.GroupResource{Group: "authorization.k8s.io", Resource: "selfsubjectrulesreviews"},
+)
+
+func Register(plugins *admission.Plugins) {
+	plugins.Register(PluginName,
+		func(_ io.Reader) (admission.Interface, error) {
+			return &workspaceArchive{
+				Handler: admission.NewHandler(admission.Create, admission.Update, admission.Delete),
+			}, nil
+		})
+}
+
+// workspaceArchive rejects writes to the content of archived workspaces, i.e. of logical
+// clusters whose LogicalCluster carries the archived annotation. The LogicalCluster itself stays
+// writable such that the workspace can be unarchived, and once the LogicalCluster is being
+// deleted, writes are allowed again such that the content can be removed. Status updates, e.g.
+// of controllers reporting on existing objects, reviews and writes of system:masters are always
+// allowed.
+type workspaceArchive struct {
+	*admission.Handler
+
+	logicalClusterLister corev1alpha1listers.LogicalClusterClusterLister
+
+	getLogicalCluster func(clusterName logicalcluster.Name, name string) (*corev1alpha1.LogicalCluster, error)
+}
+
+// Ensure that the required admission interfaces are implemented.
+var _ = admission.ValidationInterface(&workspaceArchive{})
+var _ = admission.InitializationValidator(&workspaceArchive{})
+var _ = kcpinitializers.WantsKcpInformers(&workspaceArchive{})
+
+func (o *workspaceArchive) Validate(ctx context.Context, a admission.Attributes, _ admission.ObjectInterfaces) error {
+	if a.GetResource().GroupResource() == corev1alpha1.Resource("logicalclusters") {
+		return nil
+	}
+	if a.GetSubresource() == "status" || reviewResources.Has(a.GetResource().GroupResource()) {
+		return nil
+	}
+	if slices.Contains(a.GetUserInfo().GetGroups(), user.SystemPrivilegedGroup) {
+		return nil
+	}
+
+	clusterName, err := genericapirequest.ClusterNameFrom(ctx)
+	if err != nil {
+		return apierrors.NewInternalError(err)
+	}
+
+	logicalCluster, err := o.getLogicalCluster(clusterName, corev1alpha1.LogicalClusterName)
+	if apierrors.IsNotFound(err) {
+		return nil // not (yet) a workspace, e.g. system logical clusters
+	} else if err != nil {
+		return apierrors.NewInternalError(err)
+	}
+
+	if logicalCluster.Annotations[tenancyv1alpha1.ExperimentalWorkspaceArchivedAnnotationKey] != "true" {
+		return nil
+	}
+	if !logicalCluster.DeletionTimestamp.IsZero() {
+		return nil
+	}
+
+	return admission.NewForbidden(a, fmt.Errorf("workspace is archived and read-only"))
+}
+
+func (o *workspaceArchive) ValidateInitialization() error {
+	if o.logicalClusterLister == nil {
+		return fmt.Errorf(PluginName + " plugin needs a LogicalCluster lister")
+	}
+	return nil
+}
+
+func (o *workspaceArchive) SetKcpInformers(local, global kcpinformers.SharedInformerFactory) {
+	logicalClusterReady := local.Core().V1alpha1().LogicalClusters().Informer().HasSynced
+	o.SetReadyFunc(func() bool {
+		return logicalClusterReady()
+	})
+	o.logicalClusterLister = local.Core().V1alpha1().LogicalClusters().Lister()
+	o.getLogicalCluster = func(clusterName logicalcluster.Name, name string) (*corev1alpha1.LogicalCluster, error) {
+		return o.logicalClusterLister.Cluster(clusterName).Get(name)
+	}
+}
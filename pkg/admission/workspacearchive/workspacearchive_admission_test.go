@@ -0,0 +1,141 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspacearchive
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/admission"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/endpoints/request"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+)
+
+func TestValidate(t *testing.T) {
+	now := metav1.Now()
+	archived := &corev1alpha1.LogicalCluster{ObjectMeta: metav1.ObjectMeta{
+		Annotations: map[string]string{tenancyv1alpha1.ExperimentalWorkspaceArchivedAnnotationKey: "true"},
+	}}
+
+	tests := map[string]struct {
+		resource       schema.GroupVersionResource
+		subresource    string
+		groups         []string
+		logicalCluster *corev1alpha1.LogicalCluster
+		wantForbidden  bool
+	}{
+		"no logical cluster": {
+			resource: corev1.SchemeGroupVersion.WithResource("configmaps"),
+		},
+		"not archived": {
+			resource:       corev1.SchemeGroupVersion.WithResource("configmaps"),
+			logicalCluster: &corev1alpha1.LogicalCluster{},
+		},
+		"archived": {
+			resource: corev1.SchemeGroupVersion.WithResource("configmaps"),
+			logicalCluster: &corev1alpha1.LogicalCluster{ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{tenancyv1alpha1.ExperimentalWorkspaceArchivedAnnotationKey: "true"},
+			}},
+			wantForbidden: true,
+		},
+		"archived, but logical cluster is deleting": {
+			resource: corev1.SchemeGroupVersion.WithResource("configmaps"),
+			logicalCluster: &corev1alpha1.LogicalCluster{ObjectMeta: metav1.ObjectMeta{
+				Annotations:       map[string]string{tenancyv1alpha1.ExperimentalWorkspaceArchivedAnnotationKey: "true"},
+				DeletionTimestamp: &now,
+			}},
+		},
+		"archived, logical cluster itself": {
+			resource: corev1alpha1.SchemeGroupVersion.WithResource("logicalclusters"),
+			logicalCluster: &corev1alpha1.LogicalCluster{ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{tenancyv1alpha1.ExperimentalWorkspaceArchivedAnnotationKey: "true"},
+			}},
+		},
+		"archived, status": {
+			resource:       corev1.SchemeGroupVersion.WithResource("pods"),
+			subresource:    "status",
+			logicalCluster: archived,
+		},
+		"archived, other subresource": {
+			resource:       corev1.SchemeGroupVersion.WithResource("pods"),
+			subresource:    "binding",
+			logicalCluster: archived,
+			wantForbidden:  true,
+		},
+		"archived, token review": {
+			resource:       schema.GroupVersionResource{Group: "authentication.k8s.io", Version: "v1", Resource: "tokenreviews"},
+			logicalCluster: archived,
+		},
+		"archived, subject access review": {
+			resource:       schema.GroupVersionResource{Group: "authorization.k8s.io", Version: "v1", Resource: "subjectaccessreviews"},
+			logicalCluster: archived,
+		},
+		"archived, self subject access review": {
+			resource:       schema.GroupVersionResource{Group: "authorization.k8s.io", Version: "v1", Resource: "selfsubjectaccessreviews"},
+			logicalCluster: archived,
+		},
+		"archived, self subject rules review": {
+			resource:       schema.GroupVersionResource{Group: "authorization.k8s.io", Version: "v1", Resource: "selfsubjectrulesreviews"},
+			logicalCluster: archived,
+		},
+		"archived, system:masters": {
+			resource:       corev1.SchemeGroupVersion.WithResource("configmaps"),
+			groups:         []string{user.SystemPrivilegedGroup},
+			logicalCluster: archived,
+		},
+		"archived, other group": {
+			resource:       corev1.SchemeGroupVersion.WithResource("configmaps"),
+			groups:         []string{"system:authenticated"},
+			logicalCluster: archived,
+			wantForbidden:  true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			o := &workspaceArchive{
+				Handler: admission.NewHandler(admission.Create, admission.Update, admission.Delete),
+				getLogicalCluster: func(clusterName logicalcluster.Name, name string) (*corev1alpha1.LogicalCluster, error) {
+					if tc.logicalCluster == nil {
+						return nil, apierrors.NewNotFound(corev1alpha1.Resource("logicalclusters"), name)
+					}
+					return tc.logicalCluster, nil
+				},
+			}
+
+			ctx := request.WithCluster(context.Background(), request.Cluster{Name: "foo"})
+			a := admission.NewAttributesRecord(nil, nil, schema.GroupVersionKind{}, "default", "test", tc.resource, tc.subresource, admission.Create, &metav1.CreateOptions{}, false, &user.DefaultInfo{Name: "user", Groups: tc.groups})
+
+			err := o.Validate(ctx, a, nil)
+			if tc.wantForbidden {
+				require.True(t, apierrors.IsForbidden(err), "expected forbidden, got %v", err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -172,6 +172,7 @@ func (o *workspacetypeExists) Admit(ctx context.Context, a admission.Attributes,
 	}
 
 	addAdditionalWorkspaceLabels(wt, ws)
+	addAdditionalWorkspaceAnnotations(wt, ws)
 
 	return updateUnstructured(u, ws)
 }
@@ -397,6 +398,26 @@ func addAdditionalWorkspaceLabels(
 	}
 }
 
+// addAdditionalWorkspaceAnnotations adds annotations defined by the workspace
+// type to the workspace if they are not already present.
+func addAdditionalWorkspaceAnnotations(
+	wt *tenancyv1alpha1.WorkspaceType,
+	ws *tenancyv1alpha1.Workspace,
+) {
+	if len(wt.Spec.AdditionalWorkspaceAnnotations) > 0 {
+		if ws.Annotations == nil {
+			ws.Annotations = map[string]string{}
+		}
+		for key, value := range wt.Spec.AdditionalWorkspaceAnnotations {
+			if _, ok := ws.Annotations[key]; ok {
+				// Do not override existing annotations
+				continue
+			}
+			ws.Annotations[key] = value
+		}
+	}
+}
+
 // TODO: Move this out of admission to some shared location.
 type TransitiveTypeResolver interface {
 	Resolve(t *tenancyv1alpha1.WorkspaceType) ([]*tenancyv1alpha1.WorkspaceType, error)
@@ -41,6 +41,9 @@ import (
 
 type Config struct {
 	*embed.Config
+
+	// DefragInterval is the interval at which the backend is defragmented. Zero disables it.
+	DefragInterval time.Duration
 }
 
 func NewConfig(o options.CompletedOptions, enableWatchCache bool) (*Config, error) {
@@ -109,9 +112,20 @@ func NewConfig(o options.CompletedOptions, enableWatchCache bool) (*Config, erro
 		cfg.QuotaBackendBytes = o.QuotaBackendBytes
 	}
 
-	return &Config{
+	if o.AutoCompactionMode != "" {
+		cfg.AutoCompactionMode = o.AutoCompactionMode
+	}
+	if o.AutoCompactionRetention != "" {
+		cfg.AutoCompactionRetention = o.AutoCompactionRetention
+	}
+
+	c := &Config{
 		Config: cfg,
-	}, nil
+	}
+	if o.PeriodicDefrag {
+		c.DefragInterval = o.DefragInterval
+	}
+	return c, nil
 }
 
 type completedConfig struct {
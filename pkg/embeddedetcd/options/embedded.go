@@ -19,9 +19,12 @@ package options
 import (
 	"fmt"
 	"path/filepath"
+	"strconv"
+	"time"
 
 	"github.com/spf13/pflag"
 	etcdtypes "go.etcd.io/etcd/client/pkg/v3/types"
+	"go.etcd.io/etcd/server/v3/embed"
 
 	genericoptions "k8s.io/apiserver/pkg/server/options"
 )
@@ -36,13 +39,19 @@ type Options struct {
 	WalSizeBytes      int64
 	QuotaBackendBytes int64
 	ForceNewCluster   bool
+
+	AutoCompactionMode      string
+	AutoCompactionRetention string
+	PeriodicDefrag          bool
+	DefragInterval          time.Duration
 }
 
 func NewOptions(rootDir string) *Options {
 	return &Options{
-		Directory:  filepath.Join(rootDir, "etcd-server"),
-		PeerPort:   "2380",
-		ClientPort: "2379",
+		Directory:      filepath.Join(rootDir, "etcd-server"),
+		PeerPort:       "2380",
+		ClientPort:     "2379",
+		DefragInterval: 24 * time.Hour,
 	}
 }
 
@@ -54,6 +63,10 @@ func (e *Options) AddFlags(fs *pflag.FlagSet) {
 	fs.Int64Var(&e.WalSizeBytes, "embedded-etcd-wal-size-bytes", e.WalSizeBytes, "Size of embedded etcd WAL")
 	fs.Int64Var(&e.QuotaBackendBytes, "embedded-etcd-quota-backend-bytes", e.WalSizeBytes, "Alarm threshold for embedded etcd backend bytes")
 	fs.BoolVar(&e.ForceNewCluster, "embedded-etcd-force-new-cluster", e.ForceNewCluster, "Starts a new cluster from existing data restored from a different system")
+	fs.StringVar(&e.AutoCompactionMode, "embedded-etcd-auto-compaction-mode", e.AutoCompactionMode, "Interpret --embedded-etcd-auto-compaction-retention as 'periodic' (a duration like 1h) or 'revision' (a number of revisions). Defaults to etcd's default")
+	fs.StringVar(&e.AutoCompactionRetention, "embedded-etcd-auto-compaction-retention", e.AutoCompactionRetention, "Retention for embedded etcd auto compaction, a positive duration or number of revisions depending on --embedded-etcd-auto-compaction-mode. Empty disables auto compaction")
+	fs.BoolVar(&e.PeriodicDefrag, "embedded-etcd-periodic-defrag", e.PeriodicDefrag, "Defragment the embedded etcd backend every --embedded-etcd-defrag-interval")
+	fs.DurationVar(&e.DefragInterval, "embedded-etcd-defrag-interval", e.DefragInterval, "Interval at which the embedded etcd backend is defragmented if --embedded-etcd-periodic-defrag is set")
 }
 
 type completedOptions struct {
@@ -94,7 +107,31 @@ func (e *Options) Validate() []error {
 				errs = append(errs, fmt.Errorf("--embedded-etcd-listen-metrics-urls parse failure: %w", err))
 			}
 		}
+		switch e.AutoCompactionMode {
+		case "", embed.CompactorModePeriodic, embed.CompactorModeRevision:
+		default:
+			errs = append(errs, fmt.Errorf("--embedded-etcd-auto-compaction-mode must be either %q or %q", embed.CompactorModePeriodic, embed.CompactorModeRevision))
+		}
+		if e.AutoCompactionRetention != "" && !validRetention(e.AutoCompactionMode, e.AutoCompactionRetention) {
+			errs = append(errs, fmt.Errorf("--embedded-etcd-auto-compaction-retention must be a positive duration or number of revisions, got %q", e.AutoCompactionRetention))
+		}
+		if e.DefragInterval <= 0 {
+			errs = append(errs, fmt.Errorf("--embedded-etcd-defrag-interval must be positive"))
+		}
 	}
 
 	return errs
 }
+
+// validRetention returns whether etcd accepts the retention in the given auto compaction mode,
+// and whether it is positive. Periodic retentions are durations, or plain numbers of hours.
+func validRetention(mode, retention string) bool {
+	if n, err := strconv.ParseInt(retention, 10, 64); err == nil {
+		return n > 0
+	}
+	if mode == embed.CompactorModeRevision {
+		return false
+	}
+	d, err := time.ParseDuration(retention)
+	return err == nil && d > 0
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package options
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	tests := map[string]struct {
+		mutate  func(o *Options)
+		wantErr bool
+	}{
+		"defaults": {
+			mutate: func(o *Options) {},
+		},
+		"periodic defrag": {
+			mutate: func(o *Options) { o.PeriodicDefrag = true; o.DefragInterval = time.Hour },
+		},
+		"zero defrag interval": {
+			mutate:  func(o *Options) { o.DefragInterval = 0 },
+			wantErr: true,
+		},
+		"negative defrag interval": {
+			mutate:  func(o *Options) { o.DefragInterval = -time.Hour },
+			wantErr: true,
+		},
+		"periodic retention": {
+			mutate: func(o *Options) { o.AutoCompactionMode = "periodic"; o.AutoCompactionRetention = "30m" },
+		},
+		"periodic retention in hours": {
+			mutate: func(o *Options) { o.AutoCompactionRetention = "2" },
+		},
+		"revision retention": {
+			mutate: func(o *Options) { o.AutoCompactionMode = "revision"; o.AutoCompactionRetention = "1000" },
+		},
+		"zero retention": {
+			mutate:  func(o *Options) { o.AutoCompactionRetention = "0" },
+			wantErr: true,
+		},
+		"zero periodic retention": {
+			mutate:  func(o *Options) { o.AutoCompactionMode = "periodic"; o.AutoCompactionRetention = "0s" },
+			wantErr: true,
+		},
+		"negative revision retention": {
+			mutate:  func(o *Options) { o.AutoCompactionMode = "revision"; o.AutoCompactionRetention = "-5" },
+			wantErr: true,
+		},
+		"duration as revision retention": {
+			mutate:  func(o *Options) { o.AutoCompactionMode = "revision"; o.AutoCompactionRetention = "1h" },
+			wantErr: true,
+		},
+		"unknown compaction mode": {
+			mutate:  func(o *Options) { o.AutoCompactionMode = "sometimes" },
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			o := NewOptions(t.TempDir())
+			o.Enabled = true
+			tc.mutate(o)
+			errs := o.Validate()
+			if tc.wantErr {
+				require.NotEmpty(t, errs)
+			} else {
+				require.Empty(t, errs)
+			}
+		})
+	}
+}
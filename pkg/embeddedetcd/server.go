@@ -24,6 +24,7 @@ import (
 	"go.etcd.io/etcd/server/v3/embed"
 
 	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
 )
 
 type Server struct {
@@ -55,6 +56,9 @@ func (s *Server) Run(ctx context.Context) error {
 
 	select {
 	case <-e.Server.ReadyNotify():
+		if s.config.DefragInterval > 0 {
+			go defragPeriodically(ctx, clock.RealClock{}, s.config.DefragInterval, e.Server.Backend().Defrag)
+		}
 		return nil
 	case <-time.After(60 * time.Second):
 		e.Server.Stop() // trigger a shutdown
@@ -63,3 +67,27 @@ func (s *Server) Run(ctx context.Context) error {
 		return e
 	}
 }
+
+// defragPeriodically calls defrag every interval until ctx is done.
+func defragPeriodically(ctx context.Context, clk clock.WithTicker, interval time.Duration, defrag func() error) {
+	logger := klog.FromContext(ctx).WithValues("interval", interval)
+	logger.Info("Starting periodic embedded etcd defragmentation")
+
+	ticker := clk.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C():
+		}
+
+		start := clk.Now()
+		if err := defrag(); err != nil {
+			logger.Error(err, "failed to defragment embedded etcd")
+			continue
+		}
+		logger.V(2).Info("defragmented embedded etcd", "duration", clk.Since(start))
+	}
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package embeddedetcd
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+func TestDefragPeriodically(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clk := clocktesting.NewFakeClock(time.Now())
+	calls := make(chan struct{})
+	errs := []error{errors.New("failed"), nil, nil}
+	defrag := func() error {
+		calls <- struct{}{}
+		err := errs[0]
+		errs = errs[1:]
+		return err
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defragPeriodically(ctx, clk, time.Hour, defrag)
+	}()
+
+	require.Eventually(t, clk.HasWaiters, wait.ForeverTestTimeout, 10*time.Millisecond)
+
+	clk.Step(59 * time.Minute)
+	select {
+	case <-calls:
+		t.Fatal("defragmented before the interval passed")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// a failed defrag does not stop the schedule.
+	for i := 0; i < 3; i++ {
+		clk.Step(time.Hour)
+		select {
+		case <-calls:
+		case <-time.After(wait.ForeverTestTimeout):
+			t.Fatalf("defrag %d not called", i)
+		}
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(wait.ForeverTestTimeout):
+		t.Fatal("defragPeriodically did not return after the context was cancelled")
+	}
+}
@@ -22,8 +22,12 @@ import (
 	"github.com/kcp-dev/logicalcluster/v3"
 
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
 
 	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
 )
 
 // ClusterAndGroupResourceValue returns the index value for use with
@@ -107,3 +111,37 @@ func IndexAPIBindingByAPIExport(obj interface{}) ([]string, error) {
 
 	return []string{path.Join(apiBinding.Spec.Reference.Export.Name).String()}, nil
 }
+
+// ListAPIBindingsByAPIExport returns the APIBindings in the given indexer that reference the
+// given APIExport, either by its canonical path or by its logical cluster. The indexer must
+// have the APIBindingsByAPIExport index.
+func ListAPIBindingsByAPIExport(indexer cache.Indexer, export *apisv1alpha1.APIExport) ([]*apisv1alpha1.APIBinding, error) {
+	// binding keys by full path
+	keys := sets.New[string]()
+	if path := logicalcluster.NewPath(export.Annotations[core.LogicalClusterPathAnnotationKey]); !path.Empty() {
+		pathKeys, err := indexer.IndexKeys(APIBindingsByAPIExport, path.Join(export.Name).String())
+		if err != nil {
+			return nil, err
+		}
+		keys.Insert(pathKeys...)
+	}
+
+	clusterKeys, err := indexer.IndexKeys(APIBindingsByAPIExport, logicalcluster.From(export).Path().Join(export.Name).String())
+	if err != nil {
+		return nil, err
+	}
+	keys.Insert(clusterKeys...)
+
+	bindings := make([]*apisv1alpha1.APIBinding, 0, keys.Len())
+	for _, key := range sets.List[string](keys) {
+		binding, exists, err := indexer.GetByKey(key)
+		if err != nil {
+			utilruntime.HandleError(err)
+			continue
+		} else if !exists {
+			continue
+		}
+		bindings = append(bindings, binding.(*apisv1alpha1.APIBinding))
+	}
+	return bindings, nil
+}
@@ -931,6 +931,27 @@ func (s *crdGVRSource) Subscribe() <-chan struct{} {
 	return changes
 }
 
+// NewNamespacedGVRSource returns a GVRSource that only passes on the namespaced
+// GVRs of the given source. Informers for cluster-scoped resources are not started.
+func NewNamespacedGVRSource(source GVRSource) GVRSource {
+	return &namespacedGVRSource{GVRSource: source}
+}
+
+type namespacedGVRSource struct {
+	GVRSource
+}
+
+func (s *namespacedGVRSource) GVRs() map[schema.GroupVersionResource]GVRPartialMetadata {
+	all := s.GVRSource.GVRs()
+	result := make(map[schema.GroupVersionResource]GVRPartialMetadata, len(all))
+	for gvr, metadata := range all {
+		if metadata.Scope == apiextensionsv1.NamespaceScoped {
+			result[gvr] = metadata
+		}
+	}
+	return result
+}
+
 type restMapper struct {
 	meta.RESTMapper
 }
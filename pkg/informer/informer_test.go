@@ -320,3 +320,26 @@ func TestGVRsToDiscoveryData(t *testing.T) {
 
 	require.Empty(t, cmp.Diff(expected, actual, cmp.AllowUnexported(discoveryData{})))
 }
+
+type fakeGVRSource map[schema.GroupVersionResource]GVRPartialMetadata
+
+func (s fakeGVRSource) GVRs() map[schema.GroupVersionResource]GVRPartialMetadata { return s }
+func (s fakeGVRSource) Ready() bool                                              { return true }
+func (s fakeGVRSource) Subscribe() <-chan struct{}                               { return nil }
+
+func TestNamespacedGVRSource(t *testing.T) {
+	source := fakeGVRSource{
+		gvrFor("", "v1", "configmaps"):        withGVRPartialMetadata(apiextensionsv1.NamespaceScoped, "ConfigMap", "configmap"),
+		gvrFor("", "v1", "namespaces"):        withGVRPartialMetadata(apiextensionsv1.ClusterScoped, "Namespace", "namespace"),
+		gvrFor("example.io", "v1", "widgets"): withGVRPartialMetadata(apiextensionsv1.NamespaceScoped, "Widget", "widget"),
+		gvrFor("example.io", "v1", "gadgets"): withGVRPartialMetadata(apiextensionsv1.ClusterScoped, "Gadget", "gadget"),
+	}
+
+	got := NewNamespacedGVRSource(source).GVRs()
+
+	require.Equal(t, map[schema.GroupVersionResource]GVRPartialMetadata{
+		gvrFor("", "v1", "configmaps"):        withGVRPartialMetadata(apiextensionsv1.NamespaceScoped, "ConfigMap", "configmap"),
+		gvrFor("example.io", "v1", "widgets"): withGVRPartialMetadata(apiextensionsv1.NamespaceScoped, "Widget", "widget"),
+	}, got)
+	require.Len(t, source, 4, "the wrapped source must not be modified")
+}
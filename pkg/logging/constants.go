@@ -52,8 +52,12 @@ func WithReconciler(logger logr.Logger, reconciler string) logr.Logger {
 	return logger.WithValues(ReconcilerKey, reconciler)
 }
 
-// WithQueueKey adds the queue key to the logger.
+// WithQueueKey adds the queue key to the logger. Keys configured with SetTraceKeys
+// are logged verbosely.
 func WithQueueKey(logger logr.Logger, key string) logr.Logger {
+	if isTracedKey(key) {
+		logger = WithTracing(logger)
+	}
 	return logger.WithValues(QueueKeyKey, key)
 }
 
@@ -62,8 +66,12 @@ type Object interface {
 	runtime.Object
 }
 
-// WithObject adds object identifiers to the logger.
+// WithObject adds object identifiers to the logger. Objects annotated with
+// TraceAnnotationKey are logged verbosely if enabled with SetTraceAnnotationEnabled.
 func WithObject(logger logr.Logger, obj Object) logr.Logger {
+	if isTracedObject(obj) {
+		logger = WithTracing(logger)
+	}
 	return logger.WithValues(From(obj)...)
 }
 
@@ -0,0 +1,115 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logging
+
+import (
+	"sync/atomic"
+
+	"github.com/go-logr/logr"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const (
+	// TraceAnnotationKey turns on verbose reconcile logging for an object when set to "true",
+	// independent of the global verbosity. It is ignored unless enabled with
+	// SetTraceAnnotationEnabled.
+	TraceAnnotationKey = "debug.kcp.io/trace-reconcile"
+
+	// TraceKey marks log lines that are only emitted because of tracing.
+	TraceKey = "trace"
+)
+
+// traceKeys holds the queue keys that are traced in every controller.
+var traceKeys atomic.Pointer[sets.Set[string]]
+
+// SetTraceKeys sets the queue keys, e.g. "root:org|my-binding", whose reconciles are logged
+// verbosely in every controller, independent of the global verbosity.
+func SetTraceKeys(keys []string) {
+	if len(keys) == 0 {
+		traceKeys.Store(nil)
+		return
+	}
+	s := sets.New[string](keys...)
+	traceKeys.Store(&s)
+}
+
+// traceAnnotationEnabled is whether TraceAnnotationKey is honored.
+var traceAnnotationEnabled atomic.Bool
+
+// SetTraceAnnotationEnabled sets whether objects with the TraceAnnotationKey annotation are
+// logged verbosely. It is off by default, because everybody able to annotate an object could
+// otherwise flood the logs.
+func SetTraceAnnotationEnabled(enabled bool) {
+	traceAnnotationEnabled.Store(enabled)
+}
+
+func isTracedKey(key string) bool {
+	keys := traceKeys.Load()
+	return keys != nil && keys.Has(key)
+}
+
+func isTracedObject(obj Object) bool {
+	return traceAnnotationEnabled.Load() && obj.GetAnnotations()[TraceAnnotationKey] == "true"
+}
+
+// WithTracing returns a logger that emits all log lines regardless of their verbosity.
+func WithTracing(logger logr.Logger) logr.Logger {
+	sink := logger.GetSink()
+	if sink == nil {
+		return logger
+	}
+	if _, ok := sink.(tracingSink); ok {
+		return logger
+	}
+	// account for the additional frame of tracingSink.
+	if withCallDepth, ok := sink.(logr.CallDepthLogSink); ok {
+		sink = withCallDepth.WithCallDepth(1)
+	}
+	return logr.New(tracingSink{sink}).WithValues(TraceKey, true)
+}
+
+// tracingSink forwards every log line at verbosity 0 to the wrapped sink.
+type tracingSink struct {
+	logr.LogSink
+}
+
+// Init is a no-op, the wrapped sink is initialized already.
+func (s tracingSink) Init(logr.RuntimeInfo) {}
+
+func (s tracingSink) Enabled(int) bool {
+	return true
+}
+
+func (s tracingSink) Info(_ int, msg string, keysAndValues ...interface{}) {
+	s.LogSink.Info(0, msg, keysAndValues...)
+}
+
+func (s tracingSink) WithValues(keysAndValues ...interface{}) logr.LogSink {
+	return tracingSink{s.LogSink.WithValues(keysAndValues...)}
+}
+
+func (s tracingSink) WithName(name string) logr.LogSink {
+	return tracingSink{s.LogSink.WithName(name)}
+}
+
+func (s tracingSink) WithCallDepth(depth int) logr.LogSink {
+	if sink, ok := s.LogSink.(logr.CallDepthLogSink); ok {
+		return tracingSink{sink.WithCallDepth(depth)}
+	}
+	return s
+}
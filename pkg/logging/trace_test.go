@@ -0,0 +1,88 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logging
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr/funcr"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestTracing(t *testing.T) {
+	tests := map[string]struct {
+		annotations       map[string]string
+		annotationEnabled bool
+		traceKeys         []string
+		key               string
+
+		wantLines int
+	}{
+		"not traced": {
+			key:       "root|foo",
+			wantLines: 0,
+		},
+		"traced by annotation": {
+			annotations:       map[string]string{TraceAnnotationKey: "true"},
+			annotationEnabled: true,
+			key:               "root|foo",
+			wantLines:         1,
+		},
+		"annotation not enabled": {
+			annotations: map[string]string{TraceAnnotationKey: "true"},
+			key:         "root|foo",
+			wantLines:   0,
+		},
+		"annotation not true": {
+			annotations:       map[string]string{TraceAnnotationKey: "false"},
+			annotationEnabled: true,
+			key:               "root|foo",
+			wantLines:         0,
+		},
+		"traced by key": {
+			traceKeys: []string{"root|foo"},
+			key:       "root|foo",
+			wantLines: 1,
+		},
+		"other key": {
+			traceKeys: []string{"root|bar"},
+			key:       "root|foo",
+			wantLines: 0,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			SetTraceKeys(tc.traceKeys)
+			defer SetTraceKeys(nil)
+			SetTraceAnnotationEnabled(tc.annotationEnabled)
+			defer SetTraceAnnotationEnabled(false)
+
+			var lines int
+			logger := funcr.New(func(prefix, args string) { lines++ }, funcr.Options{Verbosity: 0})
+
+			obj := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "foo", Annotations: tc.annotations}}
+			logger = WithObject(WithQueueKey(logger, tc.key), obj)
+			logger.V(4).Info("step")
+
+			require.Equal(t, tc.wantLines, lines)
+		})
+	}
+}
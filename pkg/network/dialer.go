@@ -24,6 +24,7 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"time"
 
 	utilnet "k8s.io/apimachinery/pkg/util/net"
 	"k8s.io/klog/v2"
@@ -47,6 +48,40 @@ func DefaultTransportWrapper(rt http.RoundTripper) http.RoundTripper {
 	return rt
 }
 
+// KeepAliveDialContext returns a DialContext function from a network dialer sending
+// TCP keepalive probes in the given interval.
+func KeepAliveDialContext(keepAlive time.Duration) DialContext {
+	nd := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: keepAlive,
+	}
+	return nd.DialContext
+}
+
+// KeepAliveTransportWrapper returns a transport wrapper that sets the TCP keepalive
+// interval and the idle connection timeout of the wrapped transport. Zero values keep
+// the defaults.
+func KeepAliveTransportWrapper(keepAlive, idleConnTimeout time.Duration) func(rt http.RoundTripper) http.RoundTripper {
+	return func(rt http.RoundTripper) http.RoundTripper {
+		tr, err := transportFor(rt)
+		if err != nil {
+			klog.FromContext(context.Background()).Error(err, "Cannot set keepalive settings on roundtripper")
+			return rt
+		}
+		if tr == nil {
+			return rt
+		}
+		if keepAlive > 0 {
+			tr.DialContext = KeepAliveDialContext(keepAlive)
+		}
+		if idleConnTimeout > 0 {
+			// also used by the HTTP/2 transport configured on top of it.
+			tr.IdleConnTimeout = idleConnTimeout
+		}
+		return rt
+	}
+}
+
 func transportFor(rt http.RoundTripper) (*http.Transport, error) {
 	if rt == nil {
 		return nil, nil
@@ -0,0 +1,69 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+)
+
+type wrapper struct {
+	http.RoundTripper
+}
+
+var _ utilnet.RoundTripperWrapper = wrapper{}
+
+func (w wrapper) WrappedRoundTripper() http.RoundTripper {
+	return w.RoundTripper
+}
+
+func TestKeepAliveTransportWrapper(t *testing.T) {
+	tests := map[string]struct {
+		keepAlive, idleConnTimeout time.Duration
+
+		wantDialer      bool
+		wantIdleTimeout time.Duration
+	}{
+		"defaults kept": {
+			wantIdleTimeout: 90 * time.Second,
+		},
+		"keepalive": {
+			keepAlive:       15 * time.Second,
+			wantDialer:      true,
+			wantIdleTimeout: 90 * time.Second,
+		},
+		"idle timeout": {
+			idleConnTimeout: 20 * time.Second,
+			wantIdleTimeout: 20 * time.Second,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			tr := &http.Transport{IdleConnTimeout: 90 * time.Second}
+			rt := KeepAliveTransportWrapper(tt.keepAlive, tt.idleConnTimeout)(wrapper{tr})
+			require.Equal(t, wrapper{tr}, rt)
+			require.Equal(t, tt.wantDialer, tr.DialContext != nil)
+			require.Equal(t, tt.wantIdleTimeout, tr.IdleConnTimeout)
+		})
+	}
+}
@@ -973,6 +973,13 @@ func schema_sdk_apis_apis_v1alpha1_APIExportStatus(ref common.ReferenceCallback)
 							},
 						},
 					},
+					"bindingCount": {
+						SchemaProps: spec.SchemaProps{
+							Description: "bindingCount is the number of APIBindings bound to this APIExport on the shard of the APIExport. It is a per-shard count: APIBindings in workspaces on other shards are not included.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
 				},
 			},
 		},
@@ -2864,6 +2871,22 @@ func schema_sdk_apis_tenancy_v1alpha1_WorkspaceTypeSpec(ref common.ReferenceCall
 							},
 						},
 					},
+					"additionalWorkspaceAnnotations": {
+						SchemaProps: spec.SchemaProps{
+							Description: "additionalWorkspaceAnnotations are a set of annotations that will be added to a Workspace on creation, and restored if they are removed or changed later.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
 					"defaultChildWorkspaceType": {
 						SchemaProps: spec.SchemaProps{
 							Description: "defaultChildWorkspaceType is the WorkspaceType that will be used by default if another, nested Workspace is created in a workspace of this type. When this field is unset, the user must specify a type when creating nested workspaces. Extending another WorkspaceType does not inherit its defaultChildWorkspaceType.",
@@ -92,6 +92,9 @@ func (l *Labeler) LabelsFor(ctx context.Context, cluster logicalcluster.Name, gr
 	logger := klog.FromContext(ctx)
 
 	for _, binding := range bindings {
+		if !binding.DeletionTimestamp.IsZero() {
+			continue // the claims of deleting bindings are being stripped
+		}
 		logger := logging.WithObject(logger, binding)
 
 		path := logicalcluster.NewPath(binding.Spec.Reference.Export.Path)
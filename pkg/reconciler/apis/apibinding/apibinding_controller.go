@@ -29,6 +29,7 @@ import (
 
 	"k8s.io/apiextensions-apiserver/pkg/apihelpers"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -46,6 +47,8 @@ import (
 	"github.com/kcp-dev/kcp/pkg/logging"
 	"github.com/kcp-dev/kcp/pkg/reconciler/committer"
 	"github.com/kcp-dev/kcp/pkg/reconciler/events"
+	"github.com/kcp-dev/kcp/pkg/reconciler/limits"
+	"github.com/kcp-dev/kcp/pkg/reconciler/shutdown"
 	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
 	"github.com/kcp-dev/kcp/sdk/apis/core"
 	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
@@ -199,13 +202,23 @@ func NewController(
 
 	// APIExport handlers
 	_, _ = apiExportInformer.Informer().AddEventHandler(events.WithoutSyncs(cache.ResourceEventHandlerFuncs{
-		AddFunc:    func(obj interface{}) { c.enqueueAPIExport(objOrTombstone[*apisv1alpha1.APIExport](obj), logger, "") },
-		UpdateFunc: func(_, obj interface{}) { c.enqueueAPIExport(objOrTombstone[*apisv1alpha1.APIExport](obj), logger, "") },
+		AddFunc: func(obj interface{}) { c.enqueueAPIExport(objOrTombstone[*apisv1alpha1.APIExport](obj), logger, "") },
+		UpdateFunc: func(oldObj, obj interface{}) {
+			if isStatusOnlyAPIExportUpdate(objOrTombstone[*apisv1alpha1.APIExport](oldObj), objOrTombstone[*apisv1alpha1.APIExport](obj)) {
+				return
+			}
+			c.enqueueAPIExport(objOrTombstone[*apisv1alpha1.APIExport](obj), logger, "")
+		},
 		DeleteFunc: func(obj interface{}) { c.enqueueAPIExport(objOrTombstone[*apisv1alpha1.APIExport](obj), logger, "") },
 	}))
 	_, _ = globalAPIExportInformer.Informer().AddEventHandler(events.WithoutSyncs(cache.ResourceEventHandlerFuncs{
-		AddFunc:    func(obj interface{}) { c.enqueueAPIExport(objOrTombstone[*apisv1alpha1.APIExport](obj), logger, "") },
-		UpdateFunc: func(_, obj interface{}) { c.enqueueAPIExport(objOrTombstone[*apisv1alpha1.APIExport](obj), logger, "") },
+		AddFunc: func(obj interface{}) { c.enqueueAPIExport(objOrTombstone[*apisv1alpha1.APIExport](obj), logger, "") },
+		UpdateFunc: func(oldObj, obj interface{}) {
+			if isStatusOnlyAPIExportUpdate(objOrTombstone[*apisv1alpha1.APIExport](oldObj), objOrTombstone[*apisv1alpha1.APIExport](obj)) {
+				return
+			}
+			c.enqueueAPIExport(objOrTombstone[*apisv1alpha1.APIExport](obj), logger, "")
+		},
 		DeleteFunc: func(obj interface{}) { c.enqueueAPIExport(objOrTombstone[*apisv1alpha1.APIExport](obj), logger, "") },
 	}))
 
@@ -341,6 +354,18 @@ func (c *controller) enqueueAPIExport(export *apisv1alpha1.APIExport, logger log
 	}
 }
 
+// isStatusOnlyAPIExportUpdate returns true if the update of an APIExport does not change
+// anything the APIBindings depend on. The identity hash is the only status field bindings
+// use; other status writes, e.g. of status.bindingCount, would otherwise requeue every
+// binding of the export.
+func isStatusOnlyAPIExportUpdate(old, new *apisv1alpha1.APIExport) bool {
+	return old.Generation == new.Generation &&
+		equality.Semantic.DeepEqual(old.DeletionTimestamp, new.DeletionTimestamp) &&
+		equality.Semantic.DeepEqual(old.Labels, new.Labels) &&
+		equality.Semantic.DeepEqual(old.Annotations, new.Annotations) &&
+		old.Status.IdentityHash == new.Status.IdentityHash
+}
+
 // enqueueCRD maps a CRD to APIResourceSchema for enqueuing.
 func (c *controller) enqueueCRD(crd *apiextensionsv1.CustomResourceDefinition, logger logr.Logger) {
 	logger = logging.WithObject(logger, crd).WithValues(
@@ -424,6 +449,10 @@ func (c *controller) Start(ctx context.Context, numThreads int) {
 	<-ctx.Done()
 }
 
+func (c *controller) Drain(ctx context.Context) error {
+	return shutdown.DrainQueue(ctx, c.queue)
+}
+
 func (c *controller) startWorker(ctx context.Context) {
 	for c.processNextWorkItem(ctx) {
 	}
@@ -445,9 +474,15 @@ func (c *controller) processNextWorkItem(ctx context.Context) bool {
 	// other workers.
 	defer c.queue.Done(key)
 
+	ctx, done, ok := limits.BeginReconcile(ctx, ControllerName)
+	if !ok {
+		return false // shutting down
+	}
+	defer done()
+
 	if requeue, err := c.process(ctx, key); err != nil {
 		utilruntime.HandleError(fmt.Errorf("%q controller failed to sync %q, err: %w", ControllerName, key, err))
-		c.queue.AddRateLimited(key)
+		limits.Requeue(ctx, c.queue, key)
 		return true
 	} else if requeue {
 		// only requeue if we didn't error, but we still want to requeue
@@ -0,0 +1,69 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apibinding
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+)
+
+func TestIsStatusOnlyAPIExportUpdate(t *testing.T) {
+	tests := map[string]struct {
+		update func(export *apisv1alpha1.APIExport)
+		want   bool
+	}{
+		"binding count changed": {
+			update: func(export *apisv1alpha1.APIExport) { export.Status.BindingCount = 3 },
+			want:   true,
+		},
+		"identity hash changed": {
+			update: func(export *apisv1alpha1.APIExport) { export.Status.IdentityHash = "other" },
+			want:   false,
+		},
+		"spec changed": {
+			update: func(export *apisv1alpha1.APIExport) { export.Generation = 2 },
+			want:   false,
+		},
+		"annotations changed": {
+			update: func(export *apisv1alpha1.APIExport) { export.Annotations = map[string]string{"foo": "bar"} },
+			want:   false,
+		},
+		"deleted": {
+			update: func(export *apisv1alpha1.APIExport) {
+				now := metav1.Now()
+				export.DeletionTimestamp = &now
+			},
+			want: false,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			old := &apisv1alpha1.APIExport{
+				ObjectMeta: metav1.ObjectMeta{Name: "export", Generation: 1},
+				Status:     apisv1alpha1.APIExportStatus{IdentityHash: "hash", BindingCount: 2},
+			}
+			updated := old.DeepCopy()
+			tc.update(updated)
+			require.Equal(t, tc.want, isStatusOnlyAPIExportUpdate(old, updated))
+		})
+	}
+}
@@ -40,6 +40,8 @@ import (
 	"github.com/kcp-dev/kcp/pkg/logging"
 	"github.com/kcp-dev/kcp/pkg/reconciler/committer"
 	"github.com/kcp-dev/kcp/pkg/reconciler/core/logicalclusterdeletion/deletion"
+	"github.com/kcp-dev/kcp/pkg/reconciler/limits"
+	"github.com/kcp-dev/kcp/pkg/reconciler/shutdown"
 	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
 	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
 	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
@@ -156,6 +158,10 @@ func (c *Controller) Start(ctx context.Context, numThreads int) {
 	<-ctx.Done()
 }
 
+func (c *Controller) Drain(ctx context.Context) error {
+	return shutdown.DrainQueue(ctx, c.queue)
+}
+
 func (c *Controller) startWorker(ctx context.Context) {
 	for c.processNextWorkItem(ctx) {
 	}
@@ -177,6 +183,12 @@ func (c *Controller) processNextWorkItem(ctx context.Context) bool {
 	// other workers.
 	defer c.queue.Done(key)
 
+	ctx, done, ok := limits.BeginReconcile(ctx, ControllerName)
+	if !ok {
+		return false // shutting down
+	}
+	defer done()
+
 	err := c.process(ctx, key)
 
 	if err == nil {
@@ -193,7 +205,7 @@ func (c *Controller) processNextWorkItem(ctx context.Context) bool {
 		c.queue.AddAfter(key, duration)
 	} else {
 		// rather than wait for a full resync, re-add the workspace to the queue to be processed
-		c.queue.AddRateLimited(key)
+		limits.Requeue(ctx, c.queue, key)
 		utilruntime.HandleError(fmt.Errorf("deletion of apibinding %v failed: %w", key, err))
 	}
 
@@ -279,6 +291,16 @@ func (c *Controller) process(ctx context.Context, key string) error {
 }
 
 func (c *Controller) mutateResourceRemainingStatus(resourceRemaining gvrDeletionMetadataTotal, apibinding *apisv1alpha1.APIBinding) (*apisv1alpha1.APIBinding, error) {
+	remainingResources := []string{}
+	for gvr, numRemaining := range resourceRemaining.gvrToNumRemaining {
+		if numRemaining == 0 {
+			continue
+		}
+		remainingResources = append(remainingResources, fmt.Sprintf("%s.%s has %d resource instances", gvr.Resource, gvr.Group, numRemaining))
+	}
+	// sort for stable updates
+	sort.Strings(remainingResources)
+
 	if len(resourceRemaining.finalizersToNumRemaining) != 0 {
 		// requeue if there are still remaining finalizers
 		remainingByFinalizer := []string{}
@@ -295,8 +317,9 @@ func (c *Controller) mutateResourceRemainingStatus(resourceRemaining gvrDeletion
 			apisv1alpha1.BindingResourceDeleteSuccess,
 			ResourceFinalizersRemainReason,
 			conditionsv1alpha1.ConditionSeverityError,
-			"Some content in the workspace has finalizers remaining: %s",
+			"Some content in the workspace has finalizers remaining: %s. Resources blocking deletion: %s",
 			strings.Join(remainingByFinalizer, ", "),
+			strings.Join(remainingResources, ", "),
 		)
 
 		return apibinding, &deletion.ResourcesRemainingError{
@@ -305,18 +328,8 @@ func (c *Controller) mutateResourceRemainingStatus(resourceRemaining gvrDeletion
 		}
 	}
 
-	if len(resourceRemaining.gvrToNumRemaining) != 0 {
+	if len(remainingResources) != 0 {
 		// requeue if there are still remaining resources
-		remainingResources := []string{}
-		for gvr, numRemaining := range resourceRemaining.gvrToNumRemaining {
-			if numRemaining == 0 {
-				continue
-			}
-			remainingResources = append(remainingResources, fmt.Sprintf("%s.%s has %d resource instances", gvr.Resource, gvr.Group, numRemaining))
-		}
-		// sort for stable updates
-		sort.Strings(remainingResources)
-
 		conditions.MarkFalse(
 			apibinding,
 			apisv1alpha1.BindingResourceDeleteSuccess,
@@ -50,6 +50,7 @@ func TestMutateResourceRemainingStatus(t *testing.T) {
 		resourceRemaining   gvrDeletionMetadataTotal
 		expectErrorOnDelete error
 		expectConditions    conditionsv1alpha1.Conditions
+		expectMessage       string
 	}{
 		{
 			name: "resource is cleaned",
@@ -83,6 +84,7 @@ func TestMutateResourceRemainingStatus(t *testing.T) {
 					Reason: ResourceFinalizersRemainReason,
 				},
 			},
+			expectMessage: "Some content in the workspace has finalizers remaining: dev.kcp.io/test in 1 resource instances. Resources blocking deletion: pods. has 1 resource instances",
 		},
 		{
 			name: "some resource is remaining",
@@ -124,6 +126,10 @@ func TestMutateResourceRemainingStatus(t *testing.T) {
 				if cond.Reason != expCondition.Reason {
 					t.Errorf("expect condition reason %q, got %q for type %s", expCondition.Reason, cond.Reason, cond.Type)
 				}
+
+				if tt.expectMessage != "" && cond.Message != tt.expectMessage {
+					t.Errorf("expect condition message %q, got %q for type %s", tt.expectMessage, cond.Message, cond.Type)
+				}
 			}
 		})
 	}
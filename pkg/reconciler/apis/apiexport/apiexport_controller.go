@@ -41,6 +41,8 @@ import (
 	"github.com/kcp-dev/kcp/pkg/logging"
 	"github.com/kcp-dev/kcp/pkg/reconciler/committer"
 	"github.com/kcp-dev/kcp/pkg/reconciler/events"
+	"github.com/kcp-dev/kcp/pkg/reconciler/limits"
+	"github.com/kcp-dev/kcp/pkg/reconciler/shutdown"
 	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
 	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
 	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
@@ -265,6 +267,10 @@ func (c *controller) Start(ctx context.Context, numThreads int) {
 	<-ctx.Done()
 }
 
+func (c *controller) Drain(ctx context.Context) error {
+	return shutdown.DrainQueue(ctx, c.queue)
+}
+
 func (c *controller) startWorker(ctx context.Context) {
 	for c.processNextWorkItem(ctx) {
 	}
@@ -286,9 +292,15 @@ func (c *controller) processNextWorkItem(ctx context.Context) bool {
 	// other workers.
 	defer c.queue.Done(key)
 
+	ctx, done, ok := limits.BeginReconcile(ctx, ControllerName)
+	if !ok {
+		return false // shutting down
+	}
+	defer done()
+
 	if err := c.process(ctx, key); err != nil {
 		utilruntime.HandleError(fmt.Errorf("%q controller failed to sync %q, err: %w", ControllerName, key, err))
-		c.queue.AddRateLimited(key)
+		limits.Requeue(ctx, c.queue, key)
 		return true
 	}
 	c.queue.Forget(key)
@@ -331,6 +343,23 @@ func (c *controller) process(ctx context.Context, key string) error {
 		errs = append(errs, err)
 	}
 
+	// A new identity secret ref was committed above. Store the hash of the secret in a second
+	// commit right away, such that bindings do not wait for another iteration.
+	if len(errs) == 0 && (old.Spec.Identity == nil || old.Spec.Identity.SecretRef == nil) && obj.Spec.Identity != nil && obj.Spec.Identity.SecretRef != nil {
+		committed := obj.DeepCopy()
+		committed.ResourceVersion = "" // changed by the commit above
+		withHash := committed.DeepCopy()
+		if err := c.updateOrVerifyIdentitySecretHash(ctx, cluster, withHash); err != nil {
+			return err
+		}
+
+		oldResource := &Resource{ObjectMeta: committed.ObjectMeta, Spec: &committed.Spec, Status: &committed.Status}
+		newResource := &Resource{ObjectMeta: withHash.ObjectMeta, Spec: &withHash.Spec, Status: &withHash.Status}
+		if err := c.commit(ctx, oldResource, newResource); err != nil {
+			return err
+		}
+	}
+
 	return utilerrors.NewAggregate(errs)
 }
 
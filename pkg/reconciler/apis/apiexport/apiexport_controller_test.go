@@ -30,10 +30,12 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/kcp-dev/kcp/pkg/reconciler/committer"
 	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
 	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
 	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
 	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+	kcpfakeclient "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster/fake"
 )
 
 func TestReconcile(t *testing.T) {
@@ -304,6 +306,72 @@ func TestReconcile(t *testing.T) {
 	}
 }
 
+func TestProcessNewAPIExport(t *testing.T) {
+	clusterName := logicalcluster.Name("root:org:ws")
+	kcpClient := kcpfakeclient.NewSimpleClientset(&apisv1alpha1.APIExport{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				logicalcluster.AnnotationKey: clusterName.String(),
+			},
+			Name: "my-export",
+		},
+	})
+
+	var createdSecret *corev1.Secret
+	c := &controller{
+		getAPIExport: func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIExport, error) {
+			return kcpClient.Cluster(clusterName.Path()).ApisV1alpha1().APIExports().Get(context.Background(), name, metav1.GetOptions{})
+		},
+		getNamespace: func(clusterName logicalcluster.Name, name string) (*corev1.Namespace, error) {
+			return &corev1.Namespace{}, nil
+		},
+		createNamespace: func(ctx context.Context, clusterName logicalcluster.Path, ns *corev1.Namespace) error {
+			return nil
+		},
+		secretNamespace: "default-ns",
+		getSecret: func(ctx context.Context, clusterName logicalcluster.Name, ns, name string) (*corev1.Secret, error) {
+			if createdSecret != nil {
+				return createdSecret, nil
+			}
+			return nil, apierrors.NewNotFound(corev1.Resource("secrets"), name)
+		},
+		createSecret: func(ctx context.Context, clusterName logicalcluster.Path, secret *corev1.Secret) error {
+			// like the apiserver, convert stringData to data
+			createdSecret = secret.DeepCopy()
+			createdSecret.Data = map[string][]byte{}
+			for k, v := range secret.StringData {
+				createdSecret.Data[k] = []byte(v)
+			}
+			createdSecret.StringData = nil
+			return nil
+		},
+		listShards: func() ([]*corev1alpha1.Shard, error) {
+			return nil, nil
+		},
+		commit: committer.NewCommitter[*APIExport, Patcher, *APIExportSpec, *APIExportStatus](kcpClient.ApisV1alpha1().APIExports()),
+	}
+
+	key := clusterName.String() + "|my-export"
+
+	// The secret ref is committed first and the hash right after, in the same iteration.
+	// Committing spec and status together would panic.
+	require.NoError(t, c.process(context.Background(), key))
+	apiExport, err := c.getAPIExport(clusterName, "my-export")
+	require.NoError(t, err)
+	require.NotNil(t, apiExport.Spec.Identity)
+	require.Equal(t, &corev1.SecretReference{Namespace: "default-ns", Name: "my-export"}, apiExport.Spec.Identity.SecretRef)
+	hash, err := IdentityHash(createdSecret)
+	require.NoError(t, err)
+	require.Equal(t, hash, apiExport.Status.IdentityHash)
+	require.True(t, conditions.IsTrue(apiExport, apisv1alpha1.APIExportIdentityValid))
+
+	// The next iteration keeps the hash.
+	require.NoError(t, c.process(context.Background(), key))
+	apiExport, err = c.getAPIExport(clusterName, "my-export")
+	require.NoError(t, err)
+	require.Equal(t, hash, apiExport.Status.IdentityHash)
+}
+
 // requireConditionMatches looks for a condition matching c in g. Only fields that are set in c are compared (Type is
 // required, though). If c.Message is set, the test performed is contains rather than an exact match.
 func requireConditionMatches(t *testing.T, g conditions.Getter, c *conditionsv1alpha1.Condition) {
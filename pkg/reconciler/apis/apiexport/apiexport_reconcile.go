@@ -80,7 +80,7 @@ func (c *controller) reconcile(ctx context.Context, apiExport *apisv1alpha1.APIE
 
 		apiExport.Spec.Identity = identity
 
-		// Record the spec change. A future iteration will store the hash in status.
+		// Record the spec change. process stores the hash in status right after committing it.
 		return nil
 	}
 
@@ -30,6 +30,10 @@ import (
 	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
 )
 
+// IdentitySecretGeneratedAnnotationKey marks identity secrets generated by kcp. Only these are
+// deleted together with their APIExport. User provided secrets do not carry it.
+const IdentitySecretGeneratedAnnotationKey = "apis.kcp.io/generated-identity"
+
 func GenerateIdentitySecret(ctx context.Context, ns string, apiExportName string) (*corev1.Secret, error) {
 	logger := klog.FromContext(ctx)
 	start := time.Now()
@@ -40,9 +44,11 @@ func GenerateIdentitySecret(ctx context.Context, ns string, apiExportName string
 
 	secret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
-			Namespace:   ns,
-			Name:        apiExportName,
-			Annotations: map[string]string{},
+			Namespace: ns,
+			Name:      apiExportName,
+			Annotations: map[string]string{
+				IdentitySecretGeneratedAnnotationKey: "true",
+			},
 		},
 		StringData: map[string]string{
 			apisv1alpha1.SecretKeyAPIExportIdentity: key,
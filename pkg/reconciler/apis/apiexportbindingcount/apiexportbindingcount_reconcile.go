@@ -0,0 +1,33 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiexportbindingcount
+
+import (
+	"context"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+)
+
+// reconcile sets status.bindingCount of the given APIExport to the number of its APIBindings.
+func (c *controller) reconcile(_ context.Context, apiExport *apisv1alpha1.APIExport) error {
+	bindings, err := c.listAPIBindingsByAPIExport(apiExport)
+	if err != nil {
+		return err
+	}
+	apiExport.Status.BindingCount = int32(len(bindings))
+	return nil
+}
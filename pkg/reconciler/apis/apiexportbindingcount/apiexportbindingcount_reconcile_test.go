@@ -0,0 +1,58 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiexportbindingcount
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+)
+
+func TestReconcile(t *testing.T) {
+	tests := map[string]struct {
+		bindings int
+		current  int32
+	}{
+		"unbound":         {},
+		"bound":           {bindings: 3},
+		"binding added":   {bindings: 2, current: 1},
+		"binding removed": {bindings: 0, current: 1},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := &controller{
+				listAPIBindingsByAPIExport: func(export *apisv1alpha1.APIExport) ([]*apisv1alpha1.APIBinding, error) {
+					return make([]*apisv1alpha1.APIBinding, tc.bindings), nil
+				},
+			}
+
+			export := &apisv1alpha1.APIExport{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+				Status:     apisv1alpha1.APIExportStatus{BindingCount: tc.current},
+			}
+
+			require.NoError(t, c.reconcile(context.Background(), export))
+			require.Equal(t, int32(tc.bindings), export.Status.BindingCount)
+		})
+	}
+}
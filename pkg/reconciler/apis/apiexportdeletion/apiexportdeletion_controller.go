@@ -0,0 +1,288 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiexportdeletion
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	kcpcache "github.com/kcp-dev/apimachinery/v2/pkg/cache"
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+
+	"github.com/kcp-dev/kcp/pkg/indexers"
+	"github.com/kcp-dev/kcp/pkg/logging"
+	"github.com/kcp-dev/kcp/pkg/reconciler/apis/apiexport"
+	"github.com/kcp-dev/kcp/pkg/reconciler/committer"
+	"github.com/kcp-dev/kcp/pkg/reconciler/limits"
+	"github.com/kcp-dev/kcp/pkg/reconciler/shutdown"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	apisv1alpha1client "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/typed/apis/v1alpha1"
+	apisv1alpha1informers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/apis/v1alpha1"
+)
+
+const (
+	ControllerName = "kcp-apiexportdeletion"
+
+	APIExportFinalizer = "apis.kcp.io/apiexport-finalizer"
+)
+
+// NewController returns a new controller that cleans up the resources owned by deleted
+// APIExports and removes the APIExport finalizer afterwards.
+func NewController(
+	kcpClusterClient kcpclientset.ClusterInterface,
+	kubeClusterClient kcpkubernetesclientset.ClusterInterface,
+	apiExportInformer apisv1alpha1informers.APIExportClusterInformer,
+) (*controller, error) {
+	c := &controller{
+		queue: workqueue.NewTypedRateLimitingQueueWithConfig(
+			workqueue.DefaultTypedControllerRateLimiter[string](),
+			workqueue.TypedRateLimitingQueueConfig[string]{
+				Name: ControllerName,
+			},
+		),
+
+		getAPIExport: func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIExport, error) {
+			return apiExportInformer.Lister().Cluster(clusterName).Get(name)
+		},
+		listAPIExportsForSecret: func(clusterName logicalcluster.Name, namespace, name string) ([]*apisv1alpha1.APIExport, error) {
+			key := kcpcache.ToClusterAwareKey(clusterName.String(), namespace, name)
+			return indexers.ByIndex[*apisv1alpha1.APIExport](apiExportInformer.Informer().GetIndexer(), indexers.APIExportBySecret, key)
+		},
+		getSecret: func(ctx context.Context, clusterName logicalcluster.Name, namespace, name string) (*corev1.Secret, error) {
+			return kubeClusterClient.Cluster(clusterName.Path()).CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+		},
+		deleteSecret: func(ctx context.Context, clusterName logicalcluster.Name, namespace, name string) error {
+			return kubeClusterClient.Cluster(clusterName.Path()).CoreV1().Secrets(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+		},
+
+		commit: committer.NewCommitter[*APIExport, Patcher, *APIExportSpec, *APIExportStatus](kcpClusterClient.ApisV1alpha1().APIExports()),
+	}
+
+	logger := logging.WithReconciler(klog.Background(), ControllerName)
+
+	_, _ = apiExportInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: func(obj interface{}) bool {
+			export, ok := obj.(*apisv1alpha1.APIExport)
+			if !ok {
+				return false
+			}
+			return !export.DeletionTimestamp.IsZero() && sets.New[string](export.Finalizers...).Has(APIExportFinalizer)
+		},
+		Handler: cache.ResourceEventHandlerFuncs{
+			AddFunc:    func(obj interface{}) { c.enqueueAPIExport(obj, logger) },
+			UpdateFunc: func(_, obj interface{}) { c.enqueueAPIExport(obj, logger) },
+		},
+	})
+
+	return c, nil
+}
+
+type APIExport = apisv1alpha1.APIExport
+type APIExportSpec = apisv1alpha1.APIExportSpec
+type APIExportStatus = apisv1alpha1.APIExportStatus
+type Patcher = apisv1alpha1client.APIExportInterface
+type Resource = committer.Resource[*APIExportSpec, *APIExportStatus]
+type CommitFunc = func(context.Context, *Resource, *Resource) error
+
+// controller deletes the identity secret generated for an APIExport when the APIExport
+// is deleted, unless another APIExport in the same workspace still references it. User
+// provided identity secrets are left alone.
+type controller struct {
+	queue workqueue.TypedRateLimitingInterface[string]
+
+	getAPIExport            func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIExport, error)
+	listAPIExportsForSecret func(clusterName logicalcluster.Name, namespace, name string) ([]*apisv1alpha1.APIExport, error)
+	getSecret               func(ctx context.Context, clusterName logicalcluster.Name, namespace, name string) (*corev1.Secret, error)
+	deleteSecret            func(ctx context.Context, clusterName logicalcluster.Name, namespace, name string) error
+
+	commit CommitFunc
+}
+
+// enqueueAPIExport enqueues an APIExport.
+func (c *controller) enqueueAPIExport(obj interface{}, logger klog.Logger) {
+	key, err := kcpcache.DeletionHandlingMetaClusterNamespaceKeyFunc(obj)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+
+	logging.WithQueueKey(logger, key).V(4).Info("queueing APIExport")
+	c.queue.Add(key)
+}
+
+// Start starts the controller, which stops when ctx.Done() is closed.
+func (c *controller) Start(ctx context.Context, numThreads int) {
+	defer utilruntime.HandleCrash()
+	defer c.queue.ShutDown()
+
+	logger := logging.WithReconciler(klog.FromContext(ctx), ControllerName)
+	ctx = klog.NewContext(ctx, logger)
+	logger.Info("Starting controller")
+	defer logger.Info("Shutting down controller")
+
+	for i := 0; i < numThreads; i++ {
+		go wait.UntilWithContext(ctx, c.startWorker, time.Second)
+	}
+
+	<-ctx.Done()
+}
+
+func (c *controller) Drain(ctx context.Context) error {
+	return shutdown.DrainQueue(ctx, c.queue)
+}
+
+func (c *controller) startWorker(ctx context.Context) {
+	for c.processNextWorkItem(ctx) {
+	}
+}
+
+func (c *controller) processNextWorkItem(ctx context.Context) bool {
+	// Wait until there is a new item in the working queue
+	k, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	key := k
+
+	logger := logging.WithQueueKey(klog.FromContext(ctx), key)
+	ctx = klog.NewContext(ctx, logger)
+	logger.V(4).Info("processing key")
+
+	// No matter what, tell the queue we're done with this key, to unblock
+	// other workers.
+	defer c.queue.Done(key)
+
+	ctx, done, ok := limits.BeginReconcile(ctx, ControllerName)
+	if !ok {
+		return false // shutting down
+	}
+	defer done()
+
+	if err := c.process(ctx, key); err != nil {
+		utilruntime.HandleError(fmt.Errorf("%q controller failed to sync %q, err: %w", ControllerName, key, err))
+		limits.Requeue(ctx, c.queue, key)
+		return true
+	}
+	c.queue.Forget(key)
+	return true
+}
+
+func (c *controller) process(ctx context.Context, key string) error {
+	clusterName, _, name, err := kcpcache.SplitMetaClusterNamespaceKey(key)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return nil
+	}
+
+	obj, err := c.getAPIExport(clusterName, name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil // object deleted before we handled it
+		}
+		return err
+	}
+	if obj.DeletionTimestamp.IsZero() {
+		return nil
+	}
+
+	old := obj
+	obj = obj.DeepCopy()
+
+	logger := logging.WithObject(klog.FromContext(ctx), obj)
+	ctx = klog.NewContext(ctx, logger)
+
+	if err := c.deleteIdentitySecret(ctx, obj); err != nil {
+		return err
+	}
+
+	finalizers := sets.New[string](obj.Finalizers...)
+	if !finalizers.Has(APIExportFinalizer) {
+		return nil
+	}
+	logger.V(2).Info("removing finalizer")
+	finalizers.Delete(APIExportFinalizer)
+	obj.Finalizers = sets.List[string](finalizers)
+
+	oldResource := &Resource{ObjectMeta: old.ObjectMeta, Spec: &old.Spec, Status: &old.Status}
+	newResource := &Resource{ObjectMeta: obj.ObjectMeta, Spec: &obj.Spec, Status: &obj.Status}
+	return c.commit(ctx, oldResource, newResource)
+}
+
+// deleteIdentitySecret deletes the identity secret that the apiexport controller generated
+// for the given APIExport, if no other APIExport uses it. Secrets without the generated
+// annotation were provided by the user and are kept.
+func (c *controller) deleteIdentitySecret(ctx context.Context, export *apisv1alpha1.APIExport) error {
+	logger := klog.FromContext(ctx)
+
+	if export.Spec.Identity == nil || export.Spec.Identity.SecretRef == nil {
+		return nil
+	}
+	ref := export.Spec.Identity.SecretRef
+	if ref.Namespace != apiexport.DefaultIdentitySecretNamespace || ref.Name != export.Name {
+		return nil // not generated by kcp
+	}
+
+	clusterName := logicalcluster.From(export)
+	users, err := c.listAPIExportsForSecret(clusterName, ref.Namespace, ref.Name)
+	if err != nil {
+		return err
+	}
+	for _, other := range users {
+		if other.Name != export.Name {
+			logger.V(2).Info("keeping identity secret, still referenced by another APIExport", "other", other.Name)
+			return nil
+		}
+	}
+
+	secret, err := c.getSecret(ctx, clusterName, ref.Namespace, ref.Name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if secret.Annotations[apiexport.IdentitySecretGeneratedAnnotationKey] != "true" {
+		logger.V(2).Info("keeping identity secret, not generated by kcp", "namespace", ref.Namespace, "name", ref.Name)
+		return nil
+	}
+
+	logger.V(2).Info("deleting identity secret", "namespace", ref.Namespace, "name", ref.Name)
+	if err := c.deleteSecret(ctx, clusterName, ref.Namespace, ref.Name); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// InstallIndexers adds the additional indexers that this controller requires to the informers.
+func InstallIndexers(apiExportInformer apisv1alpha1informers.APIExportClusterInformer) {
+	indexers.AddIfNotPresentOrDie(apiExportInformer.Informer().GetIndexer(), cache.Indexers{
+		indexers.APIExportBySecret: indexers.IndexAPIExportBySecret,
+	})
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiexportdeletion
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kcp-dev/kcp/pkg/reconciler/apis/apiexport"
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+)
+
+func TestDeleteIdentitySecret(t *testing.T) {
+	export := func(name, secretNamespace, secretName string) *apisv1alpha1.APIExport {
+		e := &apisv1alpha1.APIExport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+				Annotations: map[string]string{
+					logicalcluster.AnnotationKey: "org-ws",
+				},
+			},
+		}
+		if secretName != "" {
+			e.Spec.Identity = &apisv1alpha1.Identity{
+				SecretRef: &corev1.SecretReference{Namespace: secretNamespace, Name: secretName},
+			}
+		}
+		return e
+	}
+
+	tests := map[string]struct {
+		export          *apisv1alpha1.APIExport
+		users           []*apisv1alpha1.APIExport
+		secretGenerated bool
+
+		wantDeleted bool
+	}{
+		"generated secret": {
+			export:          export("foo", "kcp-system", "foo"),
+			users:           []*apisv1alpha1.APIExport{export("foo", "kcp-system", "foo")},
+			secretGenerated: true,
+			wantDeleted:     true,
+		},
+		"generated secret shared with another export": {
+			export:          export("foo", "kcp-system", "foo"),
+			users:           []*apisv1alpha1.APIExport{export("foo", "kcp-system", "foo"), export("bar", "kcp-system", "foo")},
+			secretGenerated: true,
+		},
+		"user pre-created secret with the generated name": {
+			export: export("foo", "kcp-system", "foo"),
+			users:  []*apisv1alpha1.APIExport{export("foo", "kcp-system", "foo")},
+		},
+		"user provided secret": {
+			export: export("foo", "my-ns", "my-secret"),
+			users:  []*apisv1alpha1.APIExport{export("foo", "my-ns", "my-secret")},
+		},
+		"no identity": {
+			export: export("foo", "", ""),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var deleted bool
+			c := &controller{
+				listAPIExportsForSecret: func(clusterName logicalcluster.Name, namespace, name string) ([]*apisv1alpha1.APIExport, error) {
+					return tc.users, nil
+				},
+				getSecret: func(ctx context.Context, clusterName logicalcluster.Name, namespace, name string) (*corev1.Secret, error) {
+					secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+					if tc.secretGenerated {
+						secret.Annotations = map[string]string{apiexport.IdentitySecretGeneratedAnnotationKey: "true"}
+					}
+					return secret, nil
+				},
+				deleteSecret: func(ctx context.Context, clusterName logicalcluster.Name, namespace, name string) error {
+					require.Equal(t, logicalcluster.Name("org-ws"), clusterName)
+					deleted = true
+					return nil
+				},
+			}
+
+			require.NoError(t, c.deleteIdentitySecret(context.Background(), tc.export))
+			require.Equal(t, tc.wantDeleted, deleted)
+		})
+	}
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiexportexpiry
+
+import (
+	"context"
+	"time"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+)
+
+// reconcile updates the InUse condition of the given APIExport. It returns whether the APIExport
+// has expired, and otherwise when to check again if it is unused but not old enough yet.
+func (c *controller) reconcile(_ context.Context, apiExport *apisv1alpha1.APIExport) (expired bool, requeueAfter time.Duration, err error) {
+	bindings, err := c.listAPIBindingsByAPIExport(apiExport)
+	if err != nil {
+		return false, 0, err
+	}
+	if len(bindings) > 0 {
+		conditions.MarkTrue(apiExport, apisv1alpha1.APIExportInUse)
+		return false, 0, nil
+	}
+
+	age := c.now().Sub(apiExport.CreationTimestamp.Time)
+	if age < c.maxAge {
+		conditions.Delete(apiExport, apisv1alpha1.APIExportInUse)
+		return false, c.maxAge - age, nil
+	}
+
+	conditions.MarkFalse(
+		apiExport,
+		apisv1alpha1.APIExportInUse,
+		apisv1alpha1.UnusedReason,
+		conditionsv1alpha1.ConditionSeverityWarning,
+		"APIExport has no APIBindings and is older than %s",
+		c.maxAge,
+	)
+
+	return true, 0, nil
+}
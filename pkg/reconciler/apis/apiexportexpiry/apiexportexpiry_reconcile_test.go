@@ -0,0 +1,157 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiexportexpiry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+)
+
+func TestReconcile(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		age      time.Duration
+		bindings int
+
+		wantExpired      bool
+		wantRequeueAfter time.Duration
+		wantCondition    bool
+		wantStatus       bool
+	}{
+		"bound": {
+			age:           48 * time.Hour,
+			bindings:      1,
+			wantCondition: true,
+			wantStatus:    true,
+		},
+		"unbound but young": {
+			age:              time.Hour,
+			wantRequeueAfter: 23 * time.Hour,
+		},
+		"unbound and old": {
+			age:           48 * time.Hour,
+			wantExpired:   true,
+			wantCondition: true,
+			wantStatus:    false,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := &controller{
+				maxAge: 24 * time.Hour,
+				now:    func() time.Time { return now },
+				listAPIBindingsByAPIExport: func(export *apisv1alpha1.APIExport) ([]*apisv1alpha1.APIBinding, error) {
+					return make([]*apisv1alpha1.APIBinding, tc.bindings), nil
+				},
+			}
+
+			export := &apisv1alpha1.APIExport{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "foo",
+					CreationTimestamp: metav1.NewTime(now.Add(-tc.age)),
+					Annotations: map[string]string{
+						logicalcluster.AnnotationKey: "org",
+					},
+				},
+			}
+
+			expired, requeueAfter, err := c.reconcile(context.Background(), export)
+			require.NoError(t, err)
+			require.Equal(t, tc.wantExpired, expired)
+			require.Equal(t, tc.wantRequeueAfter, requeueAfter)
+
+			if !tc.wantCondition {
+				require.False(t, conditions.Has(export, apisv1alpha1.APIExportInUse))
+				return
+			}
+			require.Equal(t, tc.wantStatus, conditions.IsTrue(export, apisv1alpha1.APIExportInUse))
+			if !tc.wantStatus {
+				require.Equal(t, apisv1alpha1.UnusedReason, conditions.GetReason(export, apisv1alpha1.APIExportInUse))
+				require.Equal(t, conditionsv1alpha1.ConditionSeverityWarning, *conditions.GetSeverity(export, apisv1alpha1.APIExportInUse))
+			}
+		})
+	}
+}
+
+func TestProcessPrunesOnlySingleShard(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		shards     int
+		wantDelete bool
+	}{
+		"single shard": {
+			shards:     1,
+			wantDelete: true,
+		},
+		"multiple shards": {
+			shards:     2,
+			wantDelete: false,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			export := &apisv1alpha1.APIExport{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "foo",
+					CreationTimestamp: metav1.NewTime(now.Add(-48 * time.Hour)),
+					Annotations: map[string]string{
+						logicalcluster.AnnotationKey: "org",
+					},
+				},
+			}
+
+			var deleted bool
+			c := &controller{
+				maxAge: 24 * time.Hour,
+				prune:  true,
+				now:    func() time.Time { return now },
+				getAPIExport: func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIExport, error) {
+					return export, nil
+				},
+				listAPIBindingsByAPIExport: func(export *apisv1alpha1.APIExport) ([]*apisv1alpha1.APIBinding, error) {
+					return nil, nil
+				},
+				listShards: func() ([]*corev1alpha1.Shard, error) {
+					return make([]*corev1alpha1.Shard, tc.shards), nil
+				},
+				deleteAPIExport: func(ctx context.Context, clusterName logicalcluster.Name, name string) error {
+					deleted = true
+					return nil
+				},
+				commit: func(ctx context.Context, old, new *Resource) error { return nil },
+			}
+
+			require.NoError(t, c.process(context.Background(), "org|foo"))
+			require.Equal(t, tc.wantDelete, deleted)
+		})
+	}
+}
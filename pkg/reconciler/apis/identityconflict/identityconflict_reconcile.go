@@ -0,0 +1,102 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package identityconflict
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+)
+
+func (c *controller) reconcile(ctx context.Context, apiExport *apisv1alpha1.APIExport) error {
+	if apiExport.Status.IdentityHash == "" {
+		// the apiexport controller has not computed the identity yet.
+		return nil
+	}
+
+	local, err := c.listAPIExportsByIdentity(apiExport.Status.IdentityHash)
+	if err != nil {
+		return err
+	}
+	global, err := c.listGlobalAPIExportsByIdentity(apiExport.Status.IdentityHash)
+	if err != nil {
+		return err
+	}
+
+	duplicates := findDuplicates(apiExport, append(local, global...))
+	if len(duplicates) == 0 {
+		conditions.MarkTrue(apiExport, apisv1alpha1.APIExportIdentityUnique)
+		return nil
+	}
+
+	conditions.MarkFalse(
+		apiExport,
+		apisv1alpha1.APIExportIdentityUnique,
+		apisv1alpha1.DuplicateIdentityReason,
+		conditionsv1alpha1.ConditionSeverityWarning,
+		"Identity hash is shared with APIExports exporting the same resources: %s",
+		strings.Join(duplicates, ", "),
+	)
+
+	return nil
+}
+
+// findDuplicates returns the sorted, de-duplicated "<cluster>|<name>" keys of those candidates that
+// have the same identity hash as apiExport and export at least one of the same resources.
+func findDuplicates(apiExport *apisv1alpha1.APIExport, candidates []*apisv1alpha1.APIExport) []string {
+	self := exportKey(apiExport)
+	resources := exportedResources(apiExport)
+
+	duplicates := sets.New[string]()
+	for _, other := range candidates {
+		key := exportKey(other)
+		if key == self || other.Status.IdentityHash != apiExport.Status.IdentityHash {
+			continue
+		}
+		if resources.HasAny(sets.List(exportedResources(other))...) {
+			duplicates.Insert(key)
+		}
+	}
+
+	return sets.List(duplicates)
+}
+
+func exportKey(apiExport *apisv1alpha1.APIExport) string {
+	return fmt.Sprintf("%s|%s", logicalcluster.From(apiExport), apiExport.Name)
+}
+
+// exportedResources returns the "<plural>.<group>" resources of the latest schemas of the given
+// APIExport. Schema names have the form "<prefix>.<plural>.<group>".
+func exportedResources(apiExport *apisv1alpha1.APIExport) sets.Set[string] {
+	resources := sets.New[string]()
+	for _, schemaName := range apiExport.Spec.LatestResourceSchemas {
+		_, resource, ok := strings.Cut(schemaName, ".")
+		if !ok {
+			continue
+		}
+		resources.Insert(resource)
+	}
+	return resources
+}
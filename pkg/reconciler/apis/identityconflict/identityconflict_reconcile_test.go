@@ -0,0 +1,127 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package identityconflict
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+)
+
+func newExport(cluster, name, hash string, schemas ...string) *apisv1alpha1.APIExport {
+	return &apisv1alpha1.APIExport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Annotations: map[string]string{
+				logicalcluster.AnnotationKey: cluster,
+			},
+		},
+		Spec: apisv1alpha1.APIExportSpec{
+			LatestResourceSchemas: schemas,
+		},
+		Status: apisv1alpha1.APIExportStatus{
+			IdentityHash: hash,
+		},
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	tests := map[string]struct {
+		export *apisv1alpha1.APIExport
+		local  []*apisv1alpha1.APIExport
+		global []*apisv1alpha1.APIExport
+
+		wantCondition  bool
+		wantStatus     bool
+		wantDuplicates string
+	}{
+		"no identity hash yet": {
+			export: newExport("root", "foo", "", "v1.widgets.example.io"),
+		},
+		"unique": {
+			export: newExport("root", "foo", "abc", "v1.widgets.example.io"),
+			local: []*apisv1alpha1.APIExport{
+				newExport("root", "foo", "abc", "v1.widgets.example.io"),
+			},
+			global: []*apisv1alpha1.APIExport{
+				newExport("root", "foo", "abc", "v1.widgets.example.io"),
+			},
+			wantCondition: true,
+			wantStatus:    true,
+		},
+		"same identity, different resources": {
+			export: newExport("root", "foo", "abc", "v1.widgets.example.io"),
+			global: []*apisv1alpha1.APIExport{
+				newExport("other", "bar", "abc", "v1.gadgets.example.io"),
+			},
+			wantCondition: true,
+			wantStatus:    true,
+		},
+		"same identity, same resource with other schema prefix": {
+			export: newExport("root", "foo", "abc", "v1.widgets.example.io", "v1.things.example.io"),
+			local: []*apisv1alpha1.APIExport{
+				newExport("root:org", "baz", "abc", "v2.things.example.io"),
+			},
+			global: []*apisv1alpha1.APIExport{
+				newExport("root:org", "baz", "abc", "v2.things.example.io"),
+				newExport("other", "bar", "abc", "v3.widgets.example.io"),
+			},
+			wantCondition:  true,
+			wantStatus:     false,
+			wantDuplicates: "other|bar, root:org|baz",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := &controller{
+				listAPIExportsByIdentity: func(identityHash string) ([]*apisv1alpha1.APIExport, error) {
+					return tc.local, nil
+				},
+				listGlobalAPIExportsByIdentity: func(identityHash string) ([]*apisv1alpha1.APIExport, error) {
+					return tc.global, nil
+				},
+			}
+
+			err := c.reconcile(context.Background(), tc.export)
+			require.NoError(t, err)
+
+			cond := conditions.Get(tc.export, apisv1alpha1.APIExportIdentityUnique)
+			if !tc.wantCondition {
+				require.Nil(t, cond)
+				return
+			}
+			require.NotNil(t, cond)
+			if tc.wantStatus {
+				require.True(t, conditions.IsTrue(tc.export, apisv1alpha1.APIExportIdentityUnique))
+				return
+			}
+			require.True(t, conditions.IsFalse(tc.export, apisv1alpha1.APIExportIdentityUnique))
+			require.Equal(t, apisv1alpha1.DuplicateIdentityReason, cond.Reason)
+			require.Equal(t, conditionsv1alpha1.ConditionSeverityWarning, cond.Severity)
+			require.Contains(t, cond.Message, tc.wantDuplicates)
+		})
+	}
+}
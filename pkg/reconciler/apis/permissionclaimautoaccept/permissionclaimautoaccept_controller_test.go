@@ -0,0 +1,169 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package permissionclaimautoaccept
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+)
+
+func TestProcess(t *testing.T) {
+	configMaps := apisv1alpha1.PermissionClaim{GroupResource: apisv1alpha1.GroupResource{Resource: "configmaps"}, All: true}
+	secrets := apisv1alpha1.PermissionClaim{GroupResource: apisv1alpha1.GroupResource{Resource: "secrets"}, All: true}
+	widgets := apisv1alpha1.PermissionClaim{GroupResource: apisv1alpha1.GroupResource{Group: "example.com", Resource: "widgets"}, IdentityHash: "def", All: true}
+
+	newBinding := func(claims ...apisv1alpha1.AcceptablePermissionClaim) *apisv1alpha1.APIBinding {
+		return &apisv1alpha1.APIBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "binding",
+				Annotations: map[string]string{logicalcluster.AnnotationKey: "consumer"},
+			},
+			Spec: apisv1alpha1.APIBindingSpec{
+				Reference: apisv1alpha1.BindingReference{
+					Export: &apisv1alpha1.ExportBindingReference{Path: "root:provider", Name: "export"},
+				},
+				PermissionClaims: claims,
+			},
+		}
+	}
+	export := &apisv1alpha1.APIExport{
+		ObjectMeta: metav1.ObjectMeta{Name: "export"},
+		Spec: apisv1alpha1.APIExportSpec{
+			PermissionClaims: []apisv1alpha1.PermissionClaim{configMaps, secrets, widgets},
+		},
+		Status: apisv1alpha1.APIExportStatus{IdentityHash: "abc"},
+	}
+	accepted := func(claim apisv1alpha1.PermissionClaim) apisv1alpha1.AcceptablePermissionClaim {
+		return apisv1alpha1.AcceptablePermissionClaim{PermissionClaim: claim, State: apisv1alpha1.ClaimAccepted}
+	}
+	rejected := func(claim apisv1alpha1.PermissionClaim) apisv1alpha1.AcceptablePermissionClaim {
+		return apisv1alpha1.AcceptablePermissionClaim{PermissionClaim: claim, State: apisv1alpha1.ClaimRejected}
+	}
+
+	tests := map[string]struct {
+		policy    Policy
+		binding   *apisv1alpha1.APIBinding
+		export    *apisv1alpha1.APIExport
+		exportErr error
+
+		wantClaims []apisv1alpha1.AcceptablePermissionClaim
+		wantCommit bool
+		wantErr    bool
+	}{
+		"untrusted export": {
+			policy:  Policy{"other": sets.New[string](AnyResource)},
+			binding: newBinding(),
+			export:  export,
+		},
+		"all claims of a trusted export": {
+			policy:     Policy{"abc": sets.New[string](AnyResource)},
+			binding:    newBinding(),
+			export:     export,
+			wantClaims: []apisv1alpha1.AcceptablePermissionClaim{accepted(configMaps), accepted(secrets), accepted(widgets)},
+			wantCommit: true,
+		},
+		"only trusted resources": {
+			policy:     Policy{"abc": sets.New[string]("configmaps", "widgets.example.com")},
+			binding:    newBinding(),
+			export:     export,
+			wantClaims: []apisv1alpha1.AcceptablePermissionClaim{accepted(configMaps), accepted(widgets)},
+			wantCommit: true,
+		},
+		"claims mentioned by the binding are kept": {
+			policy:     Policy{"abc": sets.New[string](AnyResource)},
+			binding:    newBinding(rejected(secrets)),
+			export:     export,
+			wantClaims: []apisv1alpha1.AcceptablePermissionClaim{rejected(secrets), accepted(configMaps), accepted(widgets)},
+			wantCommit: true,
+		},
+		"export not found": {
+			policy:    Policy{"abc": sets.New[string](AnyResource)},
+			binding:   newBinding(),
+			exportErr: apierrors.NewNotFound(apisv1alpha1.Resource("apiexports"), "export"),
+		},
+		"export without identity": {
+			policy:  Policy{"abc": sets.New[string](AnyResource)},
+			binding: newBinding(),
+			export:  &apisv1alpha1.APIExport{ObjectMeta: metav1.ObjectMeta{Name: "export"}},
+			wantErr: true,
+		},
+		"getting the export fails": {
+			policy:    Policy{"abc": sets.New[string](AnyResource)},
+			binding:   newBinding(),
+			exportErr: errors.New("boom"),
+			wantErr:   true,
+		},
+		"binding being deleted": {
+			policy: Policy{"abc": sets.New[string](AnyResource)},
+			binding: func() *apisv1alpha1.APIBinding {
+				b := newBinding()
+				b.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+				return b
+			}(),
+			export: export,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var committed *Resource
+			c := &controller{
+				policy: tc.policy,
+				getAPIBinding: func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIBinding, error) {
+					require.Equal(t, logicalcluster.Name("consumer"), clusterName)
+					return tc.binding, nil
+				},
+				getAPIExport: func(path logicalcluster.Path, name string) (*apisv1alpha1.APIExport, error) {
+					require.Equal(t, "root:provider", path.String())
+					require.Equal(t, "export", name)
+					return tc.export, tc.exportErr
+				},
+				commit: func(ctx context.Context, old, obj *Resource) error {
+					if !equality.Semantic.DeepEqual(old.Spec, obj.Spec) {
+						committed = obj
+					}
+					return nil
+				},
+			}
+
+			err := c.process(context.Background(), "consumer|binding")
+			if tc.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			if !tc.wantCommit {
+				require.Nil(t, committed)
+				return
+			}
+			require.NotNil(t, committed)
+			require.Equal(t, tc.wantClaims, committed.Spec.PermissionClaims)
+		})
+	}
+}
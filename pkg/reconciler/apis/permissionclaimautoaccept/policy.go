@@ -0,0 +1,67 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package permissionclaimautoaccept
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+)
+
+// AnyResource matches every claimed resource of a trusted APIExport.
+const AnyResource = "*"
+
+// Policy maps the identity hashes of trusted APIExports to the claimed resources,
+// in the "<resource>.<group>" form (or "<resource>" for the core group), that are
+// accepted automatically.
+type Policy map[string]sets.Set[string]
+
+// ParsePolicy parses policy entries of the form "<export identity hash>=<resource>[.<group>]",
+// where the resource can be "*" to accept all claims of the export.
+func ParsePolicy(entries []string) (Policy, error) {
+	policy := Policy{}
+	for _, entry := range entries {
+		identityHash, resource, ok := strings.Cut(entry, "=")
+		if !ok || identityHash == "" || resource == "" {
+			return nil, fmt.Errorf("invalid permission claim auto-accept policy %q, must be <export identity hash>=<resource>[.<group>]", entry)
+		}
+		if policy[identityHash] == nil {
+			policy[identityHash] = sets.New[string]()
+		}
+		policy[identityHash].Insert(resource)
+	}
+	return policy, nil
+}
+
+// Accepts returns true if the given claim of an APIExport with the given identity hash
+// is to be accepted automatically.
+func (p Policy) Accepts(exportIdentityHash string, claim apisv1alpha1.PermissionClaim) bool {
+	resources, ok := p[exportIdentityHash]
+	if !ok {
+		return false
+	}
+	if resources.Has(AnyResource) {
+		return true
+	}
+	if claim.Group == "" {
+		return resources.Has(claim.Resource)
+	}
+	return resources.Has(claim.Resource + "." + claim.Group)
+}
@@ -0,0 +1,46 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package permissionclaimautoaccept
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+)
+
+func TestPolicy(t *testing.T) {
+	_, err := ParsePolicy([]string{"abc"})
+	require.Error(t, err)
+	_, err = ParsePolicy([]string{"=configmaps"})
+	require.Error(t, err)
+
+	policy, err := ParsePolicy([]string{"abc=configmaps", "abc=widgets.example.io", "def=*"})
+	require.NoError(t, err)
+
+	claim := func(group, resource string) apisv1alpha1.PermissionClaim {
+		return apisv1alpha1.PermissionClaim{GroupResource: apisv1alpha1.GroupResource{Group: group, Resource: resource}}
+	}
+
+	require.True(t, policy.Accepts("abc", claim("", "configmaps")))
+	require.True(t, policy.Accepts("abc", claim("example.io", "widgets")))
+	require.False(t, policy.Accepts("abc", claim("", "secrets")))
+	require.False(t, policy.Accepts("abc", claim("other.io", "widgets")))
+	require.True(t, policy.Accepts("def", claim("", "secrets")))
+	require.False(t, policy.Accepts("ghi", claim("", "configmaps")))
+}
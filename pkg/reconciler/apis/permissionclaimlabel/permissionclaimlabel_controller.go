@@ -29,6 +29,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
@@ -38,6 +39,8 @@ import (
 	"github.com/kcp-dev/kcp/pkg/informer"
 	"github.com/kcp-dev/kcp/pkg/logging"
 	"github.com/kcp-dev/kcp/pkg/reconciler/committer"
+	"github.com/kcp-dev/kcp/pkg/reconciler/limits"
+	"github.com/kcp-dev/kcp/pkg/reconciler/shutdown"
 	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
 	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
 	apisv1alpha1client "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/typed/apis/v1alpha1"
@@ -47,6 +50,11 @@ import (
 
 const (
 	ControllerName = "kcp-permissionclaimlabel"
+
+	// ClaimLabelsFinalizer is set on APIBindings with accepted or applied permission claims. It
+	// is removed once the claim labels of a deleted APIBinding have been stripped from the
+	// claimed resources.
+	ClaimLabelsFinalizer = "apis.kcp.io/permission-claim-labels"
 )
 
 // NewController returns a new controller for handling permission claims for an APIBinding.
@@ -145,6 +153,10 @@ func (c *controller) Start(ctx context.Context, numThreads int) {
 	<-ctx.Done()
 }
 
+func (c *controller) Drain(ctx context.Context) error {
+	return shutdown.DrainQueue(ctx, c.queue)
+}
+
 func (c *controller) startWorker(ctx context.Context) {
 	for c.processNextWorkItem(ctx) {
 	}
@@ -166,9 +178,15 @@ func (c *controller) processNextWorkItem(ctx context.Context) bool {
 	// other workers.
 	defer c.queue.Done(key)
 
+	ctx, done, ok := limits.BeginReconcile(ctx, ControllerName)
+	if !ok {
+		return false // shutting down
+	}
+	defer done()
+
 	if err := c.process(ctx, key); err != nil {
 		utilruntime.HandleError(fmt.Errorf("%q controller failed to sync %q, err: %w", ControllerName, key, err))
-		c.queue.AddRateLimited(key)
+		limits.Requeue(ctx, c.queue, key)
 		return true
 	}
 	c.queue.Forget(key)
@@ -197,6 +215,29 @@ func (c *controller) process(ctx context.Context, key string) error {
 	logger = logging.WithObject(logger, obj)
 	ctx = klog.NewContext(ctx, logger)
 
+	// The finalizer is added or removed in its own patch, never together with the status.
+	switch {
+	case !obj.DeletionTimestamp.IsZero():
+		if !sets.New(obj.Finalizers...).Has(ClaimLabelsFinalizer) {
+			return nil
+		}
+		if err := c.reconcileDeleted(ctx, obj); err != nil {
+			return err
+		}
+		logger.V(2).Info("removing finalizer after stripping claim labels")
+		obj.Finalizers = nil
+		for _, f := range old.Finalizers {
+			if f != ClaimLabelsFinalizer {
+				obj.Finalizers = append(obj.Finalizers, f)
+			}
+		}
+		return c.commit(ctx, &Resource{ObjectMeta: old.ObjectMeta, Spec: &old.Spec, Status: &old.Status}, &Resource{ObjectMeta: obj.ObjectMeta, Spec: &obj.Spec, Status: &obj.Status})
+	case needsClaimLabelsFinalizer(obj):
+		logger.V(2).Info("adding finalizer")
+		obj.Finalizers = append(obj.Finalizers, ClaimLabelsFinalizer)
+		return c.commit(ctx, &Resource{ObjectMeta: old.ObjectMeta, Spec: &old.Spec, Status: &old.Status}, &Resource{ObjectMeta: obj.ObjectMeta, Spec: &obj.Spec, Status: &obj.Status})
+	}
+
 	var errs []error
 	if err := c.reconcile(ctx, obj); err != nil {
 		errs = append(errs, err)
@@ -215,6 +256,23 @@ func (c *controller) process(ctx context.Context, key string) error {
 	return utilerrors.NewAggregate(errs)
 }
 
+// needsClaimLabelsFinalizer returns true if the APIBinding has accepted or applied permission
+// claims, but not the finalizer yet.
+func needsClaimLabelsFinalizer(apiBinding *apisv1alpha1.APIBinding) bool {
+	if sets.New(apiBinding.Finalizers...).Has(ClaimLabelsFinalizer) {
+		return false
+	}
+	if len(apiBinding.Status.AppliedPermissionClaims) > 0 {
+		return true
+	}
+	for _, claim := range apiBinding.Spec.PermissionClaims {
+		if claim.State == apisv1alpha1.ClaimAccepted {
+			return true
+		}
+	}
+	return false
+}
+
 // InstallIndexers adds the additional indexers that this controller requires to the informers.
 func InstallIndexers(apiExportInformer apisv1alpha1informers.APIExportClusterInformer, apiBindingInformer apisv1alpha1informers.APIBindingClusterInformer) {
 	indexers.AddIfNotPresentOrDie(apiExportInformer.Informer().GetIndexer(), cache.Indexers{
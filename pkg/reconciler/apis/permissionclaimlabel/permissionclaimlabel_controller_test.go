@@ -0,0 +1,71 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package permissionclaimlabel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+)
+
+func TestNeedsClaimLabelsFinalizer(t *testing.T) {
+	claim := apisv1alpha1.PermissionClaim{GroupResource: apisv1alpha1.GroupResource{Resource: "configmaps"}}
+
+	tests := map[string]struct {
+		binding *apisv1alpha1.APIBinding
+		want    bool
+	}{
+		"no claims": {
+			binding: &apisv1alpha1.APIBinding{},
+		},
+		"rejected claim": {
+			binding: &apisv1alpha1.APIBinding{Spec: apisv1alpha1.APIBindingSpec{
+				PermissionClaims: []apisv1alpha1.AcceptablePermissionClaim{{PermissionClaim: claim, State: apisv1alpha1.ClaimRejected}},
+			}},
+		},
+		"accepted claim": {
+			binding: &apisv1alpha1.APIBinding{Spec: apisv1alpha1.APIBindingSpec{
+				PermissionClaims: []apisv1alpha1.AcceptablePermissionClaim{{PermissionClaim: claim, State: apisv1alpha1.ClaimAccepted}},
+			}},
+			want: true,
+		},
+		"applied claim": {
+			binding: &apisv1alpha1.APIBinding{Status: apisv1alpha1.APIBindingStatus{
+				AppliedPermissionClaims: []apisv1alpha1.PermissionClaim{claim},
+			}},
+			want: true,
+		},
+		"applied claim, finalizer already set": {
+			binding: &apisv1alpha1.APIBinding{
+				ObjectMeta: metav1.ObjectMeta{Finalizers: []string{ClaimLabelsFinalizer}},
+				Status: apisv1alpha1.APIBindingStatus{
+					AppliedPermissionClaims: []apisv1alpha1.PermissionClaim{claim},
+				},
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.want, needsClaimLabelsFinalizer(tc.binding))
+		})
+	}
+}
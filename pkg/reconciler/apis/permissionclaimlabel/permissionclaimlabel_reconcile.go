@@ -264,6 +264,74 @@ func claimFromSetKey(key string) apisv1alpha1.PermissionClaim {
 	}
 }
 
+// reconcileDeleted strips the claim labels from the resources claimed by a deleting APIBinding.
+// An empty patch lets the admission plugin recompute the labels, which ignores the claims of
+// deleting bindings.
+func (c *controller) reconcileDeleted(ctx context.Context, apiBinding *apisv1alpha1.APIBinding) error {
+	logger := klog.FromContext(ctx)
+	clusterName := logicalcluster.From(apiBinding)
+
+	var errs []error
+	for _, claim := range apiBinding.Status.AppliedPermissionClaims {
+		if _, nonPersisted := permissionclaim.NonPersistedResourcesClaimable[schema.GroupResource{Group: claim.Group, Resource: claim.Resource}]; nonPersisted {
+			continue
+		}
+
+		informer, gvr, err := c.getInformerForGroupResource(claim.Group, claim.Resource)
+		if err != nil {
+			// the resource is gone with the binding, nothing left to clean up.
+			logger.V(4).Info("no informer for claimed resource, skipping cleanup", "group", claim.Group, "resource", claim.Resource)
+			continue
+		}
+
+		objs, err := informer.Lister().ByCluster(clusterName).List(labels.Everything())
+		if err != nil {
+			errs = append(errs, fmt.Errorf("error listing group=%q, resource=%q: %w", claim.Group, claim.Resource, err))
+			continue
+		}
+
+		for _, obj := range objs {
+			u, ok := obj.(*unstructured.Unstructured)
+			if !ok {
+				errs = append(errs, fmt.Errorf("unexpected type %T", obj))
+				continue
+			}
+			if !hasClaimLabel(u) {
+				continue
+			}
+			if gvr == apisv1alpha1.SchemeGroupVersion.WithResource("apibindings") && logicalcluster.From(u) == clusterName && u.GetName() == apiBinding.Name {
+				continue // the binding itself is about to go away
+			}
+
+			actualGVR := gvr
+			if actualVersion := u.GetAnnotations()[handlers.KCPOriginalAPIVersionAnnotation]; actualVersion != "" {
+				actualGV, err := schema.ParseGroupVersion(actualVersion)
+				if err != nil {
+					errs = append(errs, fmt.Errorf("error parsing original API version annotation %q: %w", actualVersion, err))
+					continue
+				}
+				actualGVR.Version = actualGV.Version
+			}
+
+			logging.WithObject(logger, u).V(4).Info("patching to remove claim labels of deleted APIBinding", "actualGVR", actualGVR)
+			if err := c.patchGenericObject(ctx, u, actualGVR, clusterName.Path()); err != nil {
+				errs = append(errs, fmt.Errorf("error patching %q %s|%s/%s: %w", actualGVR, clusterName, u.GetNamespace(), u.GetName(), err))
+			}
+		}
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+func hasClaimLabel(obj metav1.Object) bool {
+	for k := range obj.GetLabels() {
+		if strings.HasPrefix(k, apisv1alpha1.APIExportPermissionClaimLabelPrefix) {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *controller) getInformerForGroupResource(group, resource string) (kcpkubernetesinformers.GenericClusterInformer, schema.GroupVersionResource, error) {
 	informers, _ := c.ddsif.Informers()
 
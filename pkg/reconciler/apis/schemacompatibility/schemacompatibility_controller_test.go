@@ -0,0 +1,78 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schemacompatibility
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+)
+
+func TestBoundResourcesChanged(t *testing.T) {
+	binding := &apisv1alpha1.APIBinding{
+		Spec: apisv1alpha1.APIBindingSpec{
+			Reference: apisv1alpha1.BindingReference{Export: &apisv1alpha1.ExportBindingReference{Path: "root:org", Name: "export"}},
+		},
+		Status: apisv1alpha1.APIBindingStatus{
+			APIExportClusterName: "abc",
+			BoundResources: []apisv1alpha1.BoundAPIResource{
+				{Group: "example.com", Resource: "widgets", Schema: apisv1alpha1.BoundAPIResourceSchema{Name: "v1.widgets.example.com"}},
+			},
+		},
+	}
+
+	tests := map[string]struct {
+		mutate func(b *apisv1alpha1.APIBinding)
+		want   bool
+	}{
+		"no change": {
+			mutate: func(b *apisv1alpha1.APIBinding) {},
+		},
+		"condition changed": {
+			mutate: func(b *apisv1alpha1.APIBinding) { conditions.MarkTrue(b, apisv1alpha1.SchemasCompatible) },
+		},
+		"bound schema changed": {
+			mutate: func(b *apisv1alpha1.APIBinding) { b.Status.BoundResources[0].Schema.Name = "v2.widgets.example.com" },
+			want:   true,
+		},
+		"export changed": {
+			mutate: func(b *apisv1alpha1.APIBinding) { b.Spec.Reference.Export.Name = "other" },
+			want:   true,
+		},
+		"export resolved": {
+			mutate: func(b *apisv1alpha1.APIBinding) { b.Status.APIExportClusterName = "def" },
+			want:   true,
+		},
+		"deleted": {
+			mutate: func(b *apisv1alpha1.APIBinding) { now := metav1.Now(); b.DeletionTimestamp = &now },
+			want:   true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			updated := binding.DeepCopy()
+			tc.mutate(updated)
+			require.Equal(t, tc.want, boundResourcesChanged(binding, updated))
+		})
+	}
+}
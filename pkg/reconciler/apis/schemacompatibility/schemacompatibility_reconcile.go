@@ -0,0 +1,204 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schemacompatibility
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+)
+
+func (c *controller) reconcile(ctx context.Context, apiBinding *apisv1alpha1.APIBinding) error {
+	logger := klog.FromContext(ctx)
+
+	if apiBinding.Spec.Reference.Export == nil || !apiBinding.DeletionTimestamp.IsZero() {
+		return nil
+	}
+	if apiBinding.Status.APIExportClusterName == "" {
+		// the apibinding controller has not resolved the export yet.
+		return nil
+	}
+	exportClusterName := logicalcluster.Name(apiBinding.Status.APIExportClusterName)
+
+	apiExport, err := c.getAPIExport(exportClusterName, apiBinding.Spec.Reference.Export.Name)
+	if apierrors.IsNotFound(err) {
+		return nil // the apibinding controller reports this
+	} else if err != nil {
+		return err
+	}
+
+	latest := map[schema.GroupResource]*apisv1alpha1.APIResourceSchema{}
+	for _, schemaName := range apiExport.Spec.LatestResourceSchemas {
+		sch, err := c.getAPIResourceSchema(exportClusterName, schemaName)
+		if apierrors.IsNotFound(err) {
+			continue // the apibinding controller reports this
+		} else if err != nil {
+			return err
+		}
+		latest[schema.GroupResource{Group: sch.Spec.Group, Resource: sch.Spec.Names.Plural}] = sch
+	}
+
+	var changed bool
+	var incompatibilities []string
+	for _, br := range apiBinding.Status.BoundResources {
+		next, ok := latest[schema.GroupResource{Group: br.Group, Resource: br.Resource}]
+		if !ok || next.Name == br.Schema.Name {
+			continue
+		}
+
+		bound, err := c.getAPIResourceSchema(exportClusterName, br.Schema.Name)
+		if apierrors.IsNotFound(err) {
+			logger.V(4).Info("bound APIResourceSchema not found, skipping compatibility check", "schema", br.Schema.Name)
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		changed = true
+		for _, incompatibility := range findIncompatibilities(&bound.Spec, &next.Spec) {
+			incompatibilities = append(incompatibilities, fmt.Sprintf("%s: %s", next.Name, incompatibility))
+		}
+	}
+
+	if len(incompatibilities) > 0 {
+		conditions.MarkFalse(
+			apiBinding,
+			apisv1alpha1.SchemasCompatible,
+			apisv1alpha1.IncompatibleSchemaChangeReason,
+			conditionsv1alpha1.ConditionSeverityWarning,
+			"APIExport %s|%s offers schemas with breaking changes: %s",
+			exportClusterName,
+			apiExport.Name,
+			strings.Join(incompatibilities, "; "),
+		)
+		return nil
+	}
+
+	// Once the apibinding controller has moved the binding to the latest schemas, there is nothing
+	// to compare anymore. Keep a previous warning until the export changes its schemas again,
+	// otherwise it would vanish as soon as the consumers hit the breaking change.
+	if changed || !conditions.Has(apiBinding, apisv1alpha1.SchemasCompatible) {
+		conditions.MarkTrue(apiBinding, apisv1alpha1.SchemasCompatible)
+	}
+
+	return nil
+}
+
+// findIncompatibilities returns the changes from the old to the new APIResourceSchema that break
+// existing clients: versions that are no longer served, and removed fields, changed field types
+// and newly required fields in versions served by both.
+func findIncompatibilities(old, new *apisv1alpha1.APIResourceSchemaSpec) []string {
+	var incompatibilities []string
+
+	for i := range old.Versions {
+		oldVersion := &old.Versions[i]
+		if !oldVersion.Served {
+			continue
+		}
+
+		var newVersion *apisv1alpha1.APIResourceVersion
+		for j := range new.Versions {
+			if new.Versions[j].Name == oldVersion.Name {
+				newVersion = &new.Versions[j]
+				break
+			}
+		}
+		if newVersion == nil || !newVersion.Served {
+			incompatibilities = append(incompatibilities, fmt.Sprintf("version %s is no longer served", oldVersion.Name))
+			continue
+		}
+
+		oldSchema, err := oldVersion.GetSchema()
+		if err != nil {
+			incompatibilities = append(incompatibilities, fmt.Sprintf("version %s: invalid schema: %v", oldVersion.Name, err))
+			continue
+		}
+		newSchema, err := newVersion.GetSchema()
+		if err != nil {
+			incompatibilities = append(incompatibilities, fmt.Sprintf("version %s: invalid schema: %v", newVersion.Name, err))
+			continue
+		}
+		if oldSchema == nil || newSchema == nil {
+			continue
+		}
+
+		for _, incompatibility := range compareProps(oldSchema, newSchema, "") {
+			incompatibilities = append(incompatibilities, fmt.Sprintf("version %s: %s", oldVersion.Name, incompatibility))
+		}
+	}
+
+	return incompatibilities
+}
+
+// compareProps walks the old schema and returns the breaking changes found in the new one,
+// prefixed with the JSON path of the field.
+func compareProps(old, new *apiextensionsv1.JSONSchemaProps, path string) []string {
+	var incompatibilities []string
+	fieldPath := path
+	if fieldPath == "" {
+		fieldPath = "."
+	}
+
+	if old.Type != "" && new.Type != "" && old.Type != new.Type {
+		incompatibilities = append(incompatibilities, fmt.Sprintf("%s changed type from %s to %s", fieldPath, old.Type, new.Type))
+		return incompatibilities
+	}
+
+	oldRequired := sets.New[string](old.Required...)
+	for _, name := range new.Required {
+		if !oldRequired.Has(name) {
+			incompatibilities = append(incompatibilities, fmt.Sprintf("%s.%s is newly required", path, name))
+		}
+	}
+
+	names := make([]string, 0, len(old.Properties))
+	for name := range old.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		oldProp := old.Properties[name]
+		newProp, ok := new.Properties[name]
+		if !ok {
+			if new.XPreserveUnknownFields != nil && *new.XPreserveUnknownFields {
+				continue // the field is still accepted, just not validated anymore
+			}
+			incompatibilities = append(incompatibilities, fmt.Sprintf("%s.%s was removed", path, name))
+			continue
+		}
+		incompatibilities = append(incompatibilities, compareProps(&oldProp, &newProp, path+"."+name)...)
+	}
+
+	if old.Items != nil && old.Items.Schema != nil && new.Items != nil && new.Items.Schema != nil {
+		incompatibilities = append(incompatibilities, compareProps(old.Items.Schema, new.Items.Schema, path+"[*]")...)
+	}
+
+	return incompatibilities
+}
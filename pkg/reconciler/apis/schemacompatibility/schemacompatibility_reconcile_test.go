@@ -0,0 +1,247 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schemacompatibility
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+)
+
+func newVersion(t *testing.T, name string, served bool, schema *apiextensionsv1.JSONSchemaProps) apisv1alpha1.APIResourceVersion {
+	t.Helper()
+	v := apisv1alpha1.APIResourceVersion{Name: name, Served: served}
+	require.NoError(t, v.SetSchema(schema))
+	return v
+}
+
+func object(required []string, properties map[string]apiextensionsv1.JSONSchemaProps) *apiextensionsv1.JSONSchemaProps {
+	return &apiextensionsv1.JSONSchemaProps{
+		Type:       "object",
+		Required:   required,
+		Properties: properties,
+	}
+}
+
+func TestFindIncompatibilities(t *testing.T) {
+	spec := object(nil, map[string]apiextensionsv1.JSONSchemaProps{
+		"spec": *object([]string{"size"}, map[string]apiextensionsv1.JSONSchemaProps{
+			"size": {Type: "integer"},
+			"tags": {Type: "array", Items: &apiextensionsv1.JSONSchemaPropsOrArray{Schema: &apiextensionsv1.JSONSchemaProps{Type: "string"}}},
+		}),
+	})
+
+	tests := map[string]struct {
+		old, new []apisv1alpha1.APIResourceVersion
+		want     []string
+	}{
+		"unchanged": {
+			old: []apisv1alpha1.APIResourceVersion{newVersion(t, "v1", true, spec)},
+			new: []apisv1alpha1.APIResourceVersion{newVersion(t, "v1", true, spec)},
+		},
+		"new version added": {
+			old: []apisv1alpha1.APIResourceVersion{newVersion(t, "v1", true, spec)},
+			new: []apisv1alpha1.APIResourceVersion{newVersion(t, "v1", true, spec), newVersion(t, "v2", true, object(nil, nil))},
+		},
+		"new optional field": {
+			old: []apisv1alpha1.APIResourceVersion{newVersion(t, "v1", true, object(nil, map[string]apiextensionsv1.JSONSchemaProps{
+				"a": {Type: "string"},
+			}))},
+			new: []apisv1alpha1.APIResourceVersion{newVersion(t, "v1", true, object(nil, map[string]apiextensionsv1.JSONSchemaProps{
+				"a": {Type: "string"},
+				"b": {Type: "string"},
+			}))},
+		},
+		"version removed": {
+			old:  []apisv1alpha1.APIResourceVersion{newVersion(t, "v1", true, spec), newVersion(t, "v2", true, spec)},
+			new:  []apisv1alpha1.APIResourceVersion{newVersion(t, "v2", true, spec)},
+			want: []string{"version v1 is no longer served"},
+		},
+		"version no longer served": {
+			old:  []apisv1alpha1.APIResourceVersion{newVersion(t, "v1", true, spec)},
+			new:  []apisv1alpha1.APIResourceVersion{newVersion(t, "v1", false, spec)},
+			want: []string{"version v1 is no longer served"},
+		},
+		"unserved version removed": {
+			old: []apisv1alpha1.APIResourceVersion{newVersion(t, "v1", false, spec), newVersion(t, "v2", true, spec)},
+			new: []apisv1alpha1.APIResourceVersion{newVersion(t, "v2", true, spec)},
+		},
+		"field removed, type changed and newly required": {
+			old: []apisv1alpha1.APIResourceVersion{newVersion(t, "v1", true, spec)},
+			new: []apisv1alpha1.APIResourceVersion{newVersion(t, "v1", true, object(nil, map[string]apiextensionsv1.JSONSchemaProps{
+				"spec": *object([]string{"size", "color"}, map[string]apiextensionsv1.JSONSchemaProps{
+					"size":  {Type: "string"},
+					"color": {Type: "string"},
+				}),
+			}))},
+			want: []string{
+				"version v1: .spec.color is newly required",
+				"version v1: .spec.size changed type from integer to string",
+				"version v1: .spec.tags was removed",
+			},
+		},
+		"array item type changed": {
+			old: []apisv1alpha1.APIResourceVersion{newVersion(t, "v1", true, spec)},
+			new: []apisv1alpha1.APIResourceVersion{newVersion(t, "v1", true, object(nil, map[string]apiextensionsv1.JSONSchemaProps{
+				"spec": *object([]string{"size"}, map[string]apiextensionsv1.JSONSchemaProps{
+					"size": {Type: "integer"},
+					"tags": {Type: "array", Items: &apiextensionsv1.JSONSchemaPropsOrArray{Schema: &apiextensionsv1.JSONSchemaProps{Type: "integer"}}},
+				}),
+			}))},
+			want: []string{"version v1: .spec.tags[*] changed type from string to integer"},
+		},
+		"field removed but unknown fields preserved": {
+			old: []apisv1alpha1.APIResourceVersion{newVersion(t, "v1", true, object(nil, map[string]apiextensionsv1.JSONSchemaProps{
+				"a": {Type: "string"},
+			}))},
+			new: []apisv1alpha1.APIResourceVersion{newVersion(t, "v1", true, &apiextensionsv1.JSONSchemaProps{
+				Type:                   "object",
+				XPreserveUnknownFields: ptr.To(true),
+			})},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := findIncompatibilities(
+				&apisv1alpha1.APIResourceSchemaSpec{Versions: tt.old},
+				&apisv1alpha1.APIResourceSchemaSpec{Versions: tt.new},
+			)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	newSchema := func(name string, versions ...apisv1alpha1.APIResourceVersion) *apisv1alpha1.APIResourceSchema {
+		return &apisv1alpha1.APIResourceSchema{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: apisv1alpha1.APIResourceSchemaSpec{
+				Group:    "example.io",
+				Names:    apiextensionsv1.CustomResourceDefinitionNames{Plural: "widgets"},
+				Versions: versions,
+			},
+		}
+	}
+	v1 := newVersion(t, "v1", true, object(nil, map[string]apiextensionsv1.JSONSchemaProps{"a": {Type: "string"}}))
+	v1WithoutA := newVersion(t, "v1", true, object(nil, nil))
+	schemas := map[string]*apisv1alpha1.APIResourceSchema{
+		"rev1.widgets.example.io": newSchema("rev1.widgets.example.io", v1),
+		"rev2.widgets.example.io": newSchema("rev2.widgets.example.io", v1, newVersion(t, "v2", true, object(nil, nil))),
+		"rev3.widgets.example.io": newSchema("rev3.widgets.example.io", v1WithoutA),
+	}
+
+	tests := map[string]struct {
+		bound     string
+		latest    string
+		condition *conditionsv1alpha1.Condition
+
+		wantStatus bool
+		wantReason string
+	}{
+		"up to date": {
+			bound:      "rev1.widgets.example.io",
+			latest:     "rev1.widgets.example.io",
+			wantStatus: true,
+		},
+		"compatible update": {
+			bound:      "rev1.widgets.example.io",
+			latest:     "rev2.widgets.example.io",
+			wantStatus: true,
+		},
+		"breaking update": {
+			bound:      "rev1.widgets.example.io",
+			latest:     "rev3.widgets.example.io",
+			wantStatus: false,
+			wantReason: apisv1alpha1.IncompatibleSchemaChangeReason,
+		},
+		"warning kept after rebinding": {
+			bound:  "rev3.widgets.example.io",
+			latest: "rev3.widgets.example.io",
+			condition: &conditionsv1alpha1.Condition{
+				Type:   apisv1alpha1.SchemasCompatible,
+				Status: "False",
+				Reason: apisv1alpha1.IncompatibleSchemaChangeReason,
+			},
+			wantStatus: false,
+			wantReason: apisv1alpha1.IncompatibleSchemaChangeReason,
+		},
+		"bound schema gone": {
+			bound:  "rev0.widgets.example.io",
+			latest: "rev3.widgets.example.io",
+			// nothing to compare, but nothing known to be broken either.
+			wantStatus: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := &controller{
+				getAPIExport: func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIExport, error) {
+					return &apisv1alpha1.APIExport{
+						ObjectMeta: metav1.ObjectMeta{Name: name},
+						Spec:       apisv1alpha1.APIExportSpec{LatestResourceSchemas: []string{tt.latest}},
+					}, nil
+				},
+				getAPIResourceSchema: func(clusterName logicalcluster.Name, name string) (*apisv1alpha1.APIResourceSchema, error) {
+					if sch, ok := schemas[name]; ok {
+						return sch, nil
+					}
+					return nil, apierrors.NewNotFound(apisv1alpha1.Resource("apiresourceschemas"), name)
+				},
+			}
+
+			binding := &apisv1alpha1.APIBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: "widgets"},
+				Spec: apisv1alpha1.APIBindingSpec{
+					Reference: apisv1alpha1.BindingReference{
+						Export: &apisv1alpha1.ExportBindingReference{Name: "widgets"},
+					},
+				},
+				Status: apisv1alpha1.APIBindingStatus{
+					APIExportClusterName: "provider",
+					BoundResources: []apisv1alpha1.BoundAPIResource{{
+						Group:    "example.io",
+						Resource: "widgets",
+						Schema:   apisv1alpha1.BoundAPIResourceSchema{Name: tt.bound},
+					}},
+				},
+			}
+			if tt.condition != nil {
+				conditions.Set(binding, tt.condition)
+			}
+
+			require.NoError(t, c.reconcile(context.Background(), binding))
+
+			cond := conditions.Get(binding, apisv1alpha1.SchemasCompatible)
+			require.NotNil(t, cond)
+			require.Equal(t, tt.wantStatus, cond.Status == "True")
+			require.Equal(t, tt.wantReason, cond.Reason)
+		})
+	}
+}
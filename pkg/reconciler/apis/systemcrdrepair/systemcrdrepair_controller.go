@@ -0,0 +1,207 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package systemcrdrepair
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	kcpapiextensionsclientset "github.com/kcp-dev/client-go/apiextensions/client"
+	kcpapiextensionsv1informers "github.com/kcp-dev/client-go/apiextensions/informers/apiextensions/v1"
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+
+	configcrds "github.com/kcp-dev/kcp/config/crds"
+	systemcrds "github.com/kcp-dev/kcp/config/system-crds"
+	"github.com/kcp-dev/kcp/pkg/logging"
+	"github.com/kcp-dev/kcp/pkg/reconciler/limits"
+	"github.com/kcp-dev/kcp/pkg/reconciler/shutdown"
+)
+
+const (
+	ControllerName = "kcp-systemcrdrepair"
+)
+
+// NewController returns a new controller that recreates the system CRDs in the given
+// logical cluster from their embedded definitions if they get deleted.
+func NewController(
+	clusterName logicalcluster.Name,
+	crdClusterClient kcpapiextensionsclientset.ClusterInterface,
+	crdInformer kcpapiextensionsv1informers.CustomResourceDefinitionClusterInformer,
+) (*controller, error) {
+	c := &controller{
+		queue: workqueue.NewTypedRateLimitingQueueWithConfig(
+			workqueue.DefaultTypedControllerRateLimiter[string](),
+			workqueue.TypedRateLimitingQueueConfig[string]{
+				Name: ControllerName,
+			},
+		),
+		systemCRDs: map[string]metav1.GroupResource{},
+		getCRD: func(name string) (*apiextensionsv1.CustomResourceDefinition, error) {
+			return crdInformer.Lister().Cluster(clusterName).Get(name)
+		},
+		createCRD: func(ctx context.Context, gr metav1.GroupResource) error {
+			return configcrds.Create(ctx, crdClusterClient.ApiextensionsV1().CustomResourceDefinitions().Cluster(clusterName.Path()), gr)
+		},
+	}
+
+	for _, gr := range systemcrds.CRDs() {
+		c.systemCRDs[gr.String()] = gr
+	}
+
+	_, _ = crdInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: func(obj interface{}) bool {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			crd, ok := obj.(*apiextensionsv1.CustomResourceDefinition)
+			if !ok {
+				return false
+			}
+			_, isSystemCRD := c.systemCRDs[crd.Name]
+			return isSystemCRD && logicalcluster.From(crd) == clusterName
+		},
+		Handler: cache.ResourceEventHandlerFuncs{
+			UpdateFunc: func(_, obj interface{}) {
+				if crd := obj.(*apiextensionsv1.CustomResourceDefinition); crd.DeletionTimestamp != nil {
+					c.enqueue(crd.Name)
+				}
+			},
+			DeleteFunc: func(obj interface{}) {
+				if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+					obj = tombstone.Obj
+				}
+				c.enqueue(obj.(*apiextensionsv1.CustomResourceDefinition).Name)
+			},
+		},
+	})
+
+	return c, nil
+}
+
+// controller ensures that the system CRDs exist. APIExports, APIBindings and friends are
+// served from them, so losing one of them breaks every workspace.
+type controller struct {
+	queue workqueue.TypedRateLimitingInterface[string]
+
+	systemCRDs map[string]metav1.GroupResource
+
+	getCRD    func(name string) (*apiextensionsv1.CustomResourceDefinition, error)
+	createCRD func(ctx context.Context, gr metav1.GroupResource) error
+}
+
+func (c *controller) enqueue(name string) {
+	logger := logging.WithQueueKey(logging.WithReconciler(klog.Background(), ControllerName), name)
+	logger.V(4).Info("queueing system CRD")
+	c.queue.Add(name)
+}
+
+// Start starts the controller, which stops when ctx.Done() is closed.
+func (c *controller) Start(ctx context.Context, numThreads int) {
+	defer utilruntime.HandleCrash()
+	defer c.queue.ShutDown()
+
+	logger := logging.WithReconciler(klog.FromContext(ctx), ControllerName)
+	ctx = klog.NewContext(ctx, logger)
+	logger.Info("Starting controller")
+	defer logger.Info("Shutting down controller")
+
+	// check all system CRDs once, in case they were deleted while we were down.
+	for name := range c.systemCRDs {
+		c.queue.Add(name)
+	}
+
+	for i := 0; i < numThreads; i++ {
+		go wait.UntilWithContext(ctx, c.startWorker, time.Second)
+	}
+
+	<-ctx.Done()
+}
+
+func (c *controller) Drain(ctx context.Context) error {
+	return shutdown.DrainQueue(ctx, c.queue)
+}
+
+func (c *controller) startWorker(ctx context.Context) {
+	for c.processNextWorkItem(ctx) {
+	}
+}
+
+func (c *controller) processNextWorkItem(ctx context.Context) bool {
+	// Wait until there is a new item in the working queue
+	k, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	key := k
+
+	logger := logging.WithQueueKey(klog.FromContext(ctx), key)
+	ctx = klog.NewContext(ctx, logger)
+	logger.V(4).Info("processing key")
+
+	// No matter what, tell the queue we're done with this key, to unblock
+	// other workers.
+	defer c.queue.Done(key)
+
+	ctx, done, ok := limits.BeginReconcile(ctx, ControllerName)
+	if !ok {
+		return false // shutting down
+	}
+	defer done()
+
+	if err := c.process(ctx, key); err != nil {
+		utilruntime.HandleError(fmt.Errorf("%q controller failed to sync %q, err: %w", ControllerName, key, err))
+		limits.Requeue(ctx, c.queue, key)
+		return true
+	}
+	c.queue.Forget(key)
+	return true
+}
+
+func (c *controller) process(ctx context.Context, key string) error {
+	logger := klog.FromContext(ctx)
+
+	gr, ok := c.systemCRDs[key]
+	if !ok {
+		return nil
+	}
+
+	crd, err := c.getCRD(key)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	if err == nil && crd.DeletionTimestamp == nil {
+		return nil
+	}
+	if err == nil {
+		// wait for the deletion to finish, the delete event will bring us back.
+		logger.Info("system CRD is being deleted, will recreate it once it is gone")
+		return nil
+	}
+
+	logger.Info("recreating missing system CRD")
+	return c.createCRD(ctx, gr)
+}
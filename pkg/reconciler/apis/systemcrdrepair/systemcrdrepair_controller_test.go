@@ -0,0 +1,147 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package systemcrdrepair
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	kcpfakeapiextensionsclient "github.com/kcp-dev/client-go/apiextensions/client/fake"
+	kcpapiextensionsinformers "github.com/kcp-dev/client-go/apiextensions/informers"
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+func TestProcess(t *testing.T) {
+	apiBindings := metav1.GroupResource{Group: "apis.kcp.io", Resource: "apibindings"}
+
+	tests := map[string]struct {
+		key       string
+		crd       *apiextensionsv1.CustomResourceDefinition
+		getErr    error
+		createErr error
+
+		wantCreated []metav1.GroupResource
+		wantErr     bool
+	}{
+		"present": {
+			key: "apibindings.apis.kcp.io",
+			crd: &apiextensionsv1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "apibindings.apis.kcp.io"}},
+		},
+		"missing": {
+			key:         "apibindings.apis.kcp.io",
+			wantCreated: []metav1.GroupResource{apiBindings},
+		},
+		"being deleted": {
+			key: "apibindings.apis.kcp.io",
+			crd: &apiextensionsv1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "apibindings.apis.kcp.io", DeletionTimestamp: &metav1.Time{Time: time.Now()}}},
+		},
+		"not a system CRD": {
+			key: "widgets.example.com",
+		},
+		"get fails": {
+			key:     "apibindings.apis.kcp.io",
+			getErr:  errors.New("boom"),
+			wantErr: true,
+		},
+		"create fails": {
+			key:         "apibindings.apis.kcp.io",
+			createErr:   errors.New("boom"),
+			wantCreated: []metav1.GroupResource{apiBindings},
+			wantErr:     true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var created []metav1.GroupResource
+			c := &controller{
+				systemCRDs: map[string]metav1.GroupResource{"apibindings.apis.kcp.io": apiBindings},
+				getCRD: func(name string) (*apiextensionsv1.CustomResourceDefinition, error) {
+					if tc.getErr != nil {
+						return nil, tc.getErr
+					}
+					if tc.crd == nil || tc.crd.Name != name {
+						return nil, apierrors.NewNotFound(apiextensionsv1.Resource("customresourcedefinitions"), name)
+					}
+					return tc.crd, nil
+				},
+				createCRD: func(ctx context.Context, gr metav1.GroupResource) error {
+					created = append(created, gr)
+					return tc.createErr
+				},
+			}
+
+			err := c.process(context.Background(), tc.key)
+			if tc.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tc.wantCreated, created)
+		})
+	}
+}
+
+func TestEnqueueDeletedSystemCRDs(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clusterName := logicalcluster.Name("system:system-crds")
+	newCRD := func(clusterName logicalcluster.Name, name string) *apiextensionsv1.CustomResourceDefinition {
+		return &apiextensionsv1.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Annotations: map[string]string{logicalcluster.AnnotationKey: clusterName.String()},
+			},
+		}
+	}
+
+	client := kcpfakeapiextensionsclient.NewSimpleClientset(
+		newCRD(clusterName, "apibindings.apis.kcp.io"),
+		newCRD(clusterName, "widgets.example.com"),
+		newCRD("root", "apiexports.apis.kcp.io"),
+	)
+	informers := kcpapiextensionsinformers.NewSharedInformerFactory(client, 0)
+	c, err := NewController(clusterName, client, informers.Apiextensions().V1().CustomResourceDefinitions())
+	require.NoError(t, err)
+	defer c.queue.ShutDown()
+
+	informers.Start(ctx.Done())
+	informers.WaitForCacheSync(ctx.Done())
+	require.Zero(t, c.queue.Len(), "expected nothing to be queued for existing CRDs")
+
+	crds := client.ApiextensionsV1().CustomResourceDefinitions()
+	require.NoError(t, crds.Cluster(clusterName.Path()).Delete(ctx, "widgets.example.com", metav1.DeleteOptions{}))
+	require.NoError(t, crds.Cluster(logicalcluster.NewPath("root")).Delete(ctx, "apiexports.apis.kcp.io", metav1.DeleteOptions{}))
+	require.NoError(t, crds.Cluster(clusterName.Path()).Delete(ctx, "apibindings.apis.kcp.io", metav1.DeleteOptions{}))
+
+	// the delete events are handled in order, hence the others are handled once the system CRD is queued.
+	require.Eventually(t, func() bool {
+		return c.queue.Len() > 0
+	}, wait.ForeverTestTimeout, 10*time.Millisecond)
+	key, _ := c.queue.Get()
+	require.Equal(t, "apibindings.apis.kcp.io", key)
+	require.Zero(t, c.queue.Len(), "expected only the deleted system CRD of the system CRD cluster to be queued")
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	kcpcache "github.com/kcp-dev/apimachinery/v2/pkg/cache"
@@ -40,6 +41,8 @@ import (
 	"github.com/kcp-dev/kcp/pkg/cache/client/shard"
 	"github.com/kcp-dev/kcp/pkg/indexers"
 	"github.com/kcp-dev/kcp/pkg/logging"
+	"github.com/kcp-dev/kcp/pkg/reconciler/limits"
+	"github.com/kcp-dev/kcp/pkg/reconciler/shutdown"
 	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
 	"github.com/kcp-dev/kcp/sdk/apis/core"
 	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
@@ -75,7 +78,7 @@ func NewController(
 	}
 
 	for gvr, info := range c.Gvrs {
-		_, _ = info.Local.AddEventHandler(cache.FilteringResourceEventHandler{
+		localRegistration, err := info.Local.AddEventHandler(cache.FilteringResourceEventHandler{
 			FilterFunc: IsNoSystemClusterName,
 			Handler: cache.ResourceEventHandlerFuncs{
 				AddFunc:    func(obj interface{}) { c.enqueueObject(obj, gvr) },
@@ -84,7 +87,11 @@ func NewController(
 			},
 		})
 
-		_, _ = info.Global.AddEventHandler(cache.FilteringResourceEventHandler{
+		if err != nil {
+			return nil, err
+		}
+
+		globalRegistration, err := info.Global.AddEventHandler(cache.FilteringResourceEventHandler{
 			FilterFunc: IsNoSystemClusterName, // not really needed, but cannot harm
 			Handler: cache.ResourceEventHandlerFuncs{
 				AddFunc:    func(obj interface{}) { c.enqueueCacheObject(obj, gvr) },
@@ -92,6 +99,11 @@ func NewController(
 				DeleteFunc: func(obj interface{}) { c.enqueueCacheObject(obj, gvr) },
 			},
 		})
+		if err != nil {
+			return nil, err
+		}
+
+		c.handlersSynced = append(c.handlersSynced, localRegistration.HasSynced, globalRegistration.HasSynced)
 	}
 
 	return c, nil
@@ -133,6 +145,38 @@ func (c *controller) Start(ctx context.Context, workers int) {
 	<-ctx.Done()
 }
 
+// RunOnce replicates all objects once and returns when done, e.g. for migrations. The
+// informers must have synced. Changes after the initial objects are queued are not replicated,
+// and failed reconciles are not retried, but reported in the returned error.
+func (c *controller) RunOnce(ctx context.Context, workers int) error {
+	defer utilruntime.HandleCrash()
+
+	logger := logging.WithReconciler(klog.FromContext(ctx), ControllerName)
+	ctx = klog.NewContext(cacheclient.WithShardInContext(ctx, shard.New(c.shardName)), logger)
+	logger.Info("Starting controller for a single replication pass")
+	defer logger.Info("Shutting down controller")
+
+	// the initial objects are only all queued once the event handlers have seen them.
+	if !cache.WaitForCacheSync(ctx.Done(), c.handlersSynced...) {
+		c.queue.ShutDown()
+		return fmt.Errorf("event handlers did not sync: %w", ctx.Err())
+	}
+
+	workerCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	for i := 0; i < workers; i++ {
+		go wait.UntilWithContext(workerCtx, c.startWorker, time.Second)
+	}
+
+	if err := shutdown.DrainQueue(ctx, c.queue); err != nil {
+		return err
+	}
+	if failed := c.failedReconciles.Load(); failed > 0 {
+		return fmt.Errorf("%d reconciles failed", failed)
+	}
+	return nil
+}
+
 func (c *controller) startWorker(ctx context.Context) {
 	for c.processNextWorkItem(ctx) {
 	}
@@ -145,6 +189,12 @@ func (c *controller) processNextWorkItem(ctx context.Context) bool {
 	}
 	defer c.queue.Done(grKey)
 
+	ctx, done, ok := limits.BeginReconcile(ctx, ControllerName)
+	if !ok {
+		return false // shutting down
+	}
+	defer done()
+
 	logger := logging.WithQueueKey(klog.FromContext(ctx), grKey)
 	ctx = klog.NewContext(ctx, logger)
 	err := c.reconcile(ctx, grKey)
@@ -154,7 +204,8 @@ func (c *controller) processNextWorkItem(ctx context.Context) bool {
 	}
 
 	utilruntime.HandleError(fmt.Errorf("%v failed with: %w", grKey, err))
-	c.queue.AddRateLimited(grKey)
+	c.failedReconciles.Add(1)
+	limits.Requeue(ctx, c.queue, grKey)
 
 	return true
 }
@@ -183,6 +234,11 @@ type controller struct {
 
 	dynamicCacheClient kcpdynamic.ClusterInterface
 
+	// handlersSynced tell whether the event handlers have seen the initial objects of the informers.
+	handlersSynced []cache.InformerSynced
+	// failedReconciles counts the failed reconciles, reported by RunOnce.
+	failedReconciles atomic.Int64
+
 	Gvrs map[schema.GroupVersionResource]ReplicatedGVR
 }
 
@@ -0,0 +1,76 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+	"fmt"
+
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	corev1 "k8s.io/api/core/v1"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+// NewClusterAwareSink returns an event sink writing every event to the logical cluster
+// named by its kcp.io/cluster annotation. Events without the annotation are rejected.
+func NewClusterAwareSink(kubeClusterClient kcpkubernetesclientset.ClusterInterface) record.EventSink {
+	return &clusterAwareSink{kubeClusterClient: kubeClusterClient}
+}
+
+type clusterAwareSink struct {
+	kubeClusterClient kcpkubernetesclientset.ClusterInterface
+}
+
+func (s *clusterAwareSink) Create(event *corev1.Event) (*corev1.Event, error) {
+	sink, event, err := s.sinkFor(event)
+	if err != nil {
+		return nil, err
+	}
+	return sink.Create(event)
+}
+
+func (s *clusterAwareSink) Update(event *corev1.Event) (*corev1.Event, error) {
+	sink, event, err := s.sinkFor(event)
+	if err != nil {
+		return nil, err
+	}
+	return sink.Update(event)
+}
+
+func (s *clusterAwareSink) Patch(event *corev1.Event, data []byte) (*corev1.Event, error) {
+	sink, event, err := s.sinkFor(event)
+	if err != nil {
+		return nil, err
+	}
+	return sink.Patch(event, data)
+}
+
+// sinkFor returns the sink of the logical cluster of the event, and a copy of the event
+// without the cluster annotation, which is set by the server.
+func (s *clusterAwareSink) sinkFor(event *corev1.Event) (record.EventSink, *corev1.Event, error) {
+	clusterName := logicalcluster.From(event)
+	if clusterName.Empty() {
+		return nil, nil, fmt.Errorf("event %s/%s has no %s annotation", event.Namespace, event.Name, logicalcluster.AnnotationKey)
+	}
+
+	event = event.DeepCopy()
+	delete(event.Annotations, logicalcluster.AnnotationKey)
+
+	return &typedcorev1.EventSinkImpl{Interface: s.kubeClusterClient.Cluster(clusterName.Path()).CoreV1().Events("")}, event, nil
+}
@@ -39,8 +39,10 @@ import (
 	"github.com/kcp-dev/kcp/pkg/informer"
 	"github.com/kcp-dev/kcp/pkg/logging"
 	"github.com/kcp-dev/kcp/pkg/projection"
+	"github.com/kcp-dev/kcp/pkg/reconciler/limits"
+	"github.com/kcp-dev/kcp/pkg/reconciler/shutdown"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
 	corev1alpha1informers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/core/v1alpha1"
-	corev1alpha1listers "github.com/kcp-dev/kcp/sdk/client/listers/core/v1alpha1"
 )
 
 const (
@@ -54,7 +56,6 @@ type Controller struct {
 	dynamicDiscoverySharedInformerFactory *informer.DiscoveringDynamicSharedInformerFactory
 	kubeClusterClient                     kcpkubernetesclient.ClusterInterface
 	metadataClient                        kcpmetadataclient.ClusterInterface
-	logicalClusterLister                  corev1alpha1listers.LogicalClusterClusterLister
 	informersStarted                      <-chan struct{}
 
 	workersPerLogicalCluster int
@@ -64,6 +65,10 @@ type Controller struct {
 	cancelFuncs map[logicalcluster.Name]func()
 
 	ignoredResources map[schema.GroupResource]struct{}
+
+	// For better testability
+	getLogicalCluster      func(clusterName logicalcluster.Name, name string) (*corev1alpha1.LogicalCluster, error)
+	startForLogicalCluster func(ctx context.Context, clusterName logicalcluster.Name) error
 }
 
 // NewController creates a new Controller.
@@ -86,7 +91,6 @@ func NewController(
 		dynamicDiscoverySharedInformerFactory: dynamicDiscoverySharedInformerFactory,
 		kubeClusterClient:                     kubeClusterClient,
 		metadataClient:                        metadataClient,
-		logicalClusterLister:                  logicalClusterInformer.Lister(),
 		informersStarted:                      informersStarted,
 
 		workersPerLogicalCluster: workersPerLogicalCluster,
@@ -94,7 +98,12 @@ func NewController(
 		cancelFuncs: map[logicalcluster.Name]func(){},
 
 		ignoredResources: defaultIgnoredResources(),
+
+		getLogicalCluster: func(clusterName logicalcluster.Name, name string) (*corev1alpha1.LogicalCluster, error) {
+			return logicalClusterInformer.Lister().Cluster(clusterName).Get(name)
+		},
 	}
+	c.startForLogicalCluster = c.startGarbageCollectorForLogicalCluster
 
 	_, _ = logicalClusterInformer.Informer().AddEventHandler(
 		cache.ResourceEventHandlerFuncs{
@@ -152,6 +161,10 @@ func (c *Controller) Start(ctx context.Context, numThreads int) {
 	<-ctx.Done()
 }
 
+func (c *Controller) Drain(ctx context.Context) error {
+	return shutdown.DrainQueue(ctx, c.queue)
+}
+
 // startWorker runs a single worker goroutine.
 func (c *Controller) startWorker(ctx context.Context) {
 	for c.processNextWorkItem(ctx) {
@@ -175,9 +188,18 @@ func (c *Controller) processNextWorkItem(ctx context.Context) bool {
 	// other workers.
 	defer c.queue.Done(key)
 
-	if err := c.process(ctx, key); err != nil {
+	// The per-cluster controllers outlive the reconcile starting them, hence they must not
+	// inherit its deadline.
+	workerCtx := ctx
+	ctx, done, ok := limits.BeginReconcile(ctx, ControllerName)
+	if !ok {
+		return false // shutting down
+	}
+	defer done()
+
+	if err := c.process(ctx, workerCtx, key); err != nil {
 		utilruntime.HandleError(fmt.Errorf("%q controller failed to sync %q, err: %w", ControllerName, key, err))
-		c.queue.AddRateLimited(key)
+		limits.Requeue(ctx, c.queue, key)
 		return true
 	}
 
@@ -186,8 +208,9 @@ func (c *Controller) processNextWorkItem(ctx context.Context) bool {
 	return true
 }
 
-// process processes a single key from the queue.
-func (c *Controller) process(ctx context.Context, key string) error {
+// process processes a single key from the queue. The per-cluster controller is started
+// with a context derived from workerCtx.
+func (c *Controller) process(ctx, workerCtx context.Context, key string) error {
 	logger := klog.FromContext(ctx)
 	clusterName, _, name, err := kcpcache.SplitMetaClusterNamespaceKey(key)
 	if err != nil {
@@ -197,7 +220,7 @@ func (c *Controller) process(ctx context.Context, key string) error {
 
 	logger = logger.WithValues("cluster", clusterName.String())
 
-	ws, err := c.logicalClusterLister.Cluster(clusterName).Get(name)
+	ws, err := c.getLogicalCluster(clusterName, name)
 	if err != nil {
 		if kerrors.IsNotFound(err) {
 			logger.V(2).Info("LogicalCluster not found - stopping garbage collector controller for it (if needed)")
@@ -230,11 +253,11 @@ func (c *Controller) process(ctx context.Context, key string) error {
 
 	logger.V(2).Info("starting garbage collector controller")
 
-	ctx, cancel := context.WithCancel(ctx)
-	ctx = klog.NewContext(ctx, logger)
+	ctx, cancel := context.WithCancel(workerCtx)
+	ctx = klog.NewContext(ctx, logging.WithObject(klog.FromContext(workerCtx).WithValues("cluster", clusterName.String()), ws))
 	c.cancelFuncs[clusterName] = cancel
 
-	if err := c.startGarbageCollectorForLogicalCluster(ctx, clusterName); err != nil {
+	if err := c.startForLogicalCluster(ctx, clusterName); err != nil {
 		cancel()
 		return fmt.Errorf("error starting garbage collector controller for cluster %q: %w", clusterName, err)
 	}
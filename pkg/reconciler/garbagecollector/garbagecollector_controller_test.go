@@ -0,0 +1,63 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package garbagecollector
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/workqueue"
+
+	"github.com/kcp-dev/kcp/pkg/reconciler/limits"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+)
+
+func TestGarbageCollectorOutlivesReconcileTimeout(t *testing.T) {
+	limits.SetReconcileTimeouts(map[string]time.Duration{limits.AnyController: 10 * time.Millisecond})
+	t.Cleanup(func() { limits.SetReconcileTimeouts(nil) })
+
+	var gcCtx context.Context
+	c := &Controller{
+		queue:       workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[string]()),
+		cancelFuncs: map[logicalcluster.Name]func(){},
+		getLogicalCluster: func(clusterName logicalcluster.Name, name string) (*corev1alpha1.LogicalCluster, error) {
+			return &corev1alpha1.LogicalCluster{ObjectMeta: metav1.ObjectMeta{Name: name}}, nil
+		},
+		startForLogicalCluster: func(ctx context.Context, clusterName logicalcluster.Name) error {
+			gcCtx = ctx
+			return nil
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	c.queue.Add("root:org|cluster")
+	require.True(t, c.processNextWorkItem(ctx))
+	require.NotNil(t, gcCtx)
+
+	time.Sleep(50 * time.Millisecond)
+	require.NoError(t, gcCtx.Err(), "garbage collector must not be stopped by the reconcile timeout")
+
+	cancel()
+	require.Error(t, gcCtx.Err(), "garbage collector must stop with the controller")
+}
@@ -29,9 +29,13 @@ import (
 	"github.com/kcp-dev/logicalcluster/v3"
 
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/apiserver/pkg/quota/v1/generic"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
@@ -41,6 +45,8 @@ import (
 
 	"github.com/kcp-dev/kcp/pkg/informer"
 	"github.com/kcp-dev/kcp/pkg/logging"
+	"github.com/kcp-dev/kcp/pkg/reconciler/limits"
+	"github.com/kcp-dev/kcp/pkg/reconciler/shutdown"
 	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
 	corev1alpha1informers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/core/v1alpha1"
 )
@@ -65,6 +71,8 @@ type Controller struct {
 	quotaRecalculationPeriod time.Duration
 	// fullResyncPeriod controls how often the dynamic informers do a full resync
 	fullResyncPeriod time.Duration
+	// trackedResources limits the resources quota is tracked for. All are tracked if empty.
+	trackedResources sets.Set[schema.GroupResource]
 
 	workersPerLogicalCluster int
 
@@ -76,7 +84,8 @@ type Controller struct {
 	scopingGenericSharedInformerFactory scopeableInformerFactory
 
 	// For better testability
-	getLogicalCluster func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error)
+	getLogicalCluster      func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error)
+	startForLogicalCluster func(ctx context.Context, clusterName logicalcluster.Name) error
 }
 
 // NewController creates a new Controller.
@@ -87,6 +96,7 @@ func NewController(
 	dynamicDiscoverySharedInformerFactory *informer.DiscoveringDynamicSharedInformerFactory,
 	quotaRecalculationPeriod time.Duration,
 	fullResyncPeriod time.Duration,
+	trackedResources []schema.GroupResource,
 	workersPerLogicalCluster int,
 	informersStarted <-chan struct{},
 ) (*Controller, error) {
@@ -104,6 +114,7 @@ func NewController(
 
 		quotaRecalculationPeriod: quotaRecalculationPeriod,
 		fullResyncPeriod:         fullResyncPeriod,
+		trackedResources:         sets.New(trackedResources...),
 
 		workersPerLogicalCluster: workersPerLogicalCluster,
 
@@ -116,6 +127,7 @@ func NewController(
 			return logicalClusterInformer.Lister().Cluster(clusterName).Get(corev1alpha1.LogicalClusterName)
 		},
 	}
+	c.startForLogicalCluster = c.startQuotaForLogicalCluster
 
 	_, _ = logicalClusterInformer.Informer().AddEventHandler(
 		cache.ResourceEventHandlerFuncs{
@@ -160,6 +172,10 @@ func (c *Controller) Start(ctx context.Context, numThreads int) {
 	<-ctx.Done()
 }
 
+func (c *Controller) Drain(ctx context.Context) error {
+	return shutdown.DrainQueue(ctx, c.queue)
+}
+
 // startWorker runs a single worker goroutine.
 func (c *Controller) startWorker(ctx context.Context) {
 	for c.processNextWorkItem(ctx) {
@@ -183,9 +199,18 @@ func (c *Controller) processNextWorkItem(ctx context.Context) bool {
 	// other workers.
 	defer c.queue.Done(key)
 
-	if err := c.process(ctx, key); err != nil {
+	// The per-cluster controllers outlive the reconcile starting them, hence they must not
+	// inherit its deadline.
+	workerCtx := ctx
+	ctx, done, ok := limits.BeginReconcile(ctx, ControllerName)
+	if !ok {
+		return false // shutting down
+	}
+	defer done()
+
+	if err := c.process(ctx, workerCtx, key); err != nil {
 		utilruntime.HandleError(fmt.Errorf("%q controller failed to sync %q, err: %w", ControllerName, key, err))
-		c.queue.AddRateLimited(key)
+		limits.Requeue(ctx, c.queue, key)
 		return true
 	}
 
@@ -194,8 +219,9 @@ func (c *Controller) processNextWorkItem(ctx context.Context) bool {
 	return true
 }
 
-// process processes a single key from the queue.
-func (c *Controller) process(ctx context.Context, key string) error {
+// process processes a single key from the queue. The per-cluster controller is started
+// with a context derived from workerCtx.
+func (c *Controller) process(ctx, workerCtx context.Context, key string) error {
 	logger := klog.FromContext(ctx)
 	cluster, _, _, err := kcpcache.SplitMetaClusterNamespaceKey(key)
 	if err != nil {
@@ -239,11 +265,11 @@ func (c *Controller) process(ctx context.Context, key string) error {
 
 	logger.V(2).Info("starting quota controller")
 
-	ctx, cancel := context.WithCancel(ctx)
-	ctx = klog.NewContext(ctx, logger)
+	ctx, cancel := context.WithCancel(workerCtx)
+	ctx = klog.NewContext(ctx, logging.WithObject(klog.FromContext(workerCtx), ws))
 	c.cancelFuncs[clusterName] = cancel
 
-	if err := c.startQuotaForLogicalCluster(ctx, clusterName); err != nil {
+	if err := c.startForLogicalCluster(ctx, clusterName); err != nil {
 		cancel()
 		return fmt.Errorf("error starting quota controller for cluster %q: %w", clusterName, err)
 	}
@@ -253,7 +279,6 @@ func (c *Controller) process(ctx context.Context, key string) error {
 
 func (c *Controller) startQuotaForLogicalCluster(ctx context.Context, clusterName logicalcluster.Name) error {
 	logger := klog.FromContext(ctx)
-	resourceQuotaControllerClient := c.kubeClusterClient.Cluster(clusterName.Path())
 
 	// TODO(ncdc): find a way to support the default configuration. For now, don't use it, because it is difficult
 	// to get support for the special evaluators for pods/services/pvcs.
@@ -262,7 +287,7 @@ func (c *Controller) startQuotaForLogicalCluster(ctx context.Context, clusterNam
 	quotaConfiguration := generic.NewConfiguration(nil, install.DefaultIgnoredResources())
 
 	resourceQuotaControllerOptions := &resourcequota.ControllerOptions{
-		QuotaClient:           resourceQuotaControllerClient.CoreV1(),
+		QuotaClient:           c.quotaClient(clusterName),
 		ResourceQuotaInformer: c.resourceQuotaClusterInformer.Cluster(clusterName),
 		ResyncPeriod:          controller.StaticResyncPeriodFunc(c.quotaRecalculationPeriod),
 		InformerFactory:       c.scopingGenericSharedInformerFactory.Cluster(clusterName),
@@ -271,7 +296,7 @@ func (c *Controller) startQuotaForLogicalCluster(ctx context.Context, clusterNam
 		},
 		// TODO(sttts): this discovery function is wrong. It is some aggregation of all logical clusters, but has non-deterministic
 		//              behaviour if logical clusters don't agree about REST mappings.
-		DiscoveryFunc:        c.dynamicDiscoverySharedInformerFactory.ServerPreferredResources,
+		DiscoveryFunc:        c.serverPreferredResources,
 		IgnoredResourcesFunc: quotaConfiguration.IgnoredResources,
 		InformersStarted:     c.informersStarted,
 		Registry:             generic.NewRegistry(quotaConfiguration.Evaluators()),
@@ -291,11 +316,13 @@ func (c *Controller) startQuotaForLogicalCluster(ctx context.Context, clusterNam
 		queue: workqueue.NewTypedRateLimitingQueueWithConfig(
 			workqueue.DefaultTypedControllerRateLimiter[string](),
 			workqueue.TypedRateLimitingQueueConfig[string]{
-				Name: "quota-" + clusterName.String(),
+				// shared by the queues of all logical clusters, to not create workqueue
+				// metrics per logical cluster.
+				Name: ControllerName + "-monitors",
 			},
 		),
 		work: func(ctx context.Context) {
-			resourceQuotaController.UpdateMonitors(ctx, c.dynamicDiscoverySharedInformerFactory.ServerPreferredResources)
+			resourceQuotaController.UpdateMonitors(ctx, c.serverPreferredResources)
 		},
 	}
 	go quotaController.Start(ctx)
@@ -317,7 +344,7 @@ func (c *Controller) startQuotaForLogicalCluster(ctx context.Context, clusterNam
 	// Do this in a goroutine to avoid holding up a worker in the event UpdateMonitors stalls for whatever reason
 	go func() {
 		// Make sure the monitors are synced at least once
-		resourceQuotaController.UpdateMonitors(ctx, c.dynamicDiscoverySharedInformerFactory.ServerPreferredResources)
+		resourceQuotaController.UpdateMonitors(ctx, c.serverPreferredResources)
 
 		go resourceQuotaController.Run(ctx, c.workersPerLogicalCluster)
 	}()
@@ -325,6 +352,12 @@ func (c *Controller) startQuotaForLogicalCluster(ctx context.Context, clusterNam
 	return nil
 }
 
+// quotaClient returns the client of the resource quota controller of the given logical cluster.
+// No multi-cluster round tripper is needed, the client is scoped to the logical cluster.
+func (c *Controller) quotaClient(clusterName logicalcluster.Name) corev1client.ResourceQuotasGetter {
+	return c.kubeClusterClient.Cluster(clusterName.Path()).CoreV1()
+}
+
 type quotaController struct {
 	clusterName    logicalcluster.Name
 	queue          workqueue.TypedRateLimitingInterface[string]
@@ -370,3 +403,38 @@ func (c *quotaController) processNextWorkItem(ctx context.Context) bool {
 	c.queue.Forget(key)
 	return true
 }
+
+// serverPreferredResources returns the discovered resources, limited to the tracked resources
+// if any are configured. Quota is neither tracked nor replenished for the others.
+func (c *Controller) serverPreferredResources() ([]*metav1.APIResourceList, error) {
+	lists, err := c.dynamicDiscoverySharedInformerFactory.ServerPreferredResources()
+	if err != nil || c.trackedResources.Len() == 0 {
+		return lists, err
+	}
+	return filterResources(lists, c.trackedResources), nil
+}
+
+func filterResources(lists []*metav1.APIResourceList, resources sets.Set[schema.GroupResource]) []*metav1.APIResourceList {
+	var filtered []*metav1.APIResourceList
+	for _, list := range lists {
+		gv, err := schema.ParseGroupVersion(list.GroupVersion)
+		if err != nil {
+			continue
+		}
+		var apiResources []metav1.APIResource
+		for _, r := range list.APIResources {
+			if resources.Has(gv.WithResource(r.Name).GroupResource()) {
+				apiResources = append(apiResources, r)
+			}
+		}
+		if len(apiResources) == 0 {
+			continue
+		}
+		filtered = append(filtered, &metav1.APIResourceList{
+			TypeMeta:     list.TypeMeta,
+			GroupVersion: list.GroupVersion,
+			APIResources: apiResources,
+		})
+	}
+	return filtered
+}
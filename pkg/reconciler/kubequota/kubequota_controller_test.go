@@ -0,0 +1,133 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubequota
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/workqueue"
+
+	"github.com/kcp-dev/kcp/pkg/reconciler/limits"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+)
+
+func TestFilterResources(t *testing.T) {
+	lists := []*metav1.APIResourceList{
+		{
+			GroupVersion: "v1",
+			APIResources: []metav1.APIResource{{Name: "pods"}, {Name: "configmaps"}, {Name: "secrets"}},
+		},
+		{
+			GroupVersion: "example.io/v1",
+			APIResources: []metav1.APIResource{{Name: "widgets"}, {Name: "gadgets"}},
+		},
+		{
+			GroupVersion: "apps/v1",
+			APIResources: []metav1.APIResource{{Name: "deployments"}},
+		},
+	}
+
+	got := filterResources(lists, sets.New(
+		schema.GroupResource{Resource: "configmaps"},
+		schema.GroupResource{Group: "example.io", Resource: "widgets"},
+		schema.GroupResource{Resource: "deployments"}, // wrong group
+	))
+
+	require.Equal(t, []*metav1.APIResourceList{
+		{
+			GroupVersion: "v1",
+			APIResources: []metav1.APIResource{{Name: "configmaps"}},
+		},
+		{
+			GroupVersion: "example.io/v1",
+			APIResources: []metav1.APIResource{{Name: "widgets"}},
+		},
+	}, got)
+}
+
+func TestQuotaControllerOutlivesReconcileTimeout(t *testing.T) {
+	limits.SetReconcileTimeouts(map[string]time.Duration{limits.AnyController: 10 * time.Millisecond})
+	t.Cleanup(func() { limits.SetReconcileTimeouts(nil) })
+
+	var quotaCtx context.Context
+	c := &Controller{
+		queue:       workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[string]()),
+		cancelFuncs: map[logicalcluster.Name]func(){},
+		getLogicalCluster: func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error) {
+			return &corev1alpha1.LogicalCluster{ObjectMeta: metav1.ObjectMeta{Name: corev1alpha1.LogicalClusterName}}, nil
+		},
+		startForLogicalCluster: func(ctx context.Context, clusterName logicalcluster.Name) error {
+			quotaCtx = ctx
+			return nil
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	c.queue.Add("root:org|cluster")
+	require.True(t, c.processNextWorkItem(ctx))
+	require.NotNil(t, quotaCtx)
+
+	time.Sleep(50 * time.Millisecond)
+	require.NoError(t, quotaCtx.Err(), "quota controller must not be stopped by the reconcile timeout")
+
+	cancel()
+	require.Error(t, quotaCtx.Err(), "quota controller must stop with the controller")
+}
+
+func TestQuotaClientTargetsLogicalCluster(t *testing.T) {
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(&corev1.ResourceQuota{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ResourceQuota"},
+			ObjectMeta: metav1.ObjectMeta{Name: "quota", Namespace: "default"},
+		})
+	}))
+	defer srv.Close()
+
+	kubeClusterClient, err := kcpkubernetesclientset.NewForConfig(&rest.Config{Host: srv.URL})
+	require.NoError(t, err)
+	c := &Controller{kubeClusterClient: kubeClusterClient}
+
+	quota := &corev1.ResourceQuota{ObjectMeta: metav1.ObjectMeta{Name: "quota", Namespace: "default"}}
+	_, err = c.quotaClient("root:org:team").ResourceQuotas("default").UpdateStatus(context.Background(), quota, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	_, err = c.quotaClient("root").ResourceQuotas("default").UpdateStatus(context.Background(), quota, metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	require.Equal(t, []string{
+		"/clusters/root:org:team/api/v1/namespaces/default/resourcequotas/quota/status",
+		"/clusters/root/api/v1/namespaces/default/resourcequotas/quota/status",
+	}, paths)
+}
@@ -0,0 +1,272 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package limits holds process-wide limits shared by all kcp controllers.
+// They are configured once from the server options before the controllers start.
+package limits
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	kcpcache "github.com/kcp-dev/apimachinery/v2/pkg/cache"
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+)
+
+// RetriesExhaustedReason is the reason of the warning event recorded when a key is dropped
+// from the queue after too many failed retries.
+const RetriesExhaustedReason = "RetriesExhausted"
+
+// AnyController is the key of the reconcile timeout applying to all controllers without
+// their own entry.
+const AnyController = "*"
+
+// inFlight bounds the number of reconciles running concurrently across all
+// controllers. nil means unlimited.
+var inFlight atomic.Pointer[chan struct{}]
+
+type controllerNameContextKeyType int
+
+const (
+	// controllerNameContextKey is the context key for the name of the reconciling controller.
+	controllerNameContextKey controllerNameContextKeyType = iota
+	// reconcileIDContextKey is the context key for the ID of the running reconcile.
+	reconcileIDContextKey
+)
+
+// tracer creates the reconcile spans. nil means no tracing.
+var tracer atomic.Pointer[trace.Tracer]
+
+// reconcileTimeouts maps controller names, or AnyController, to the deadline of a single reconcile.
+var reconcileTimeouts atomic.Pointer[map[string]time.Duration]
+
+// maxRetries maps controller names, or AnyController, to the number of retries of a failing
+// key before it is dropped.
+var maxRetries atomic.Pointer[map[string]int]
+
+// eventRecorder records a warning event for keys dropped after too many retries. nil means
+// no events.
+var eventRecorder atomic.Pointer[record.EventRecorder]
+
+// SetMaxInFlightReconciles sets the maximum number of reconciles running concurrently
+// across all controllers. Zero or a negative value means unlimited. It must be called
+// before the controllers start.
+func SetMaxInFlightReconciles(n int) {
+	if n <= 0 {
+		inFlight.Store(nil)
+		return
+	}
+	ch := make(chan struct{}, n)
+	inFlight.Store(&ch)
+}
+
+// SetReconcileTimeouts sets the maximum duration of a single reconcile per controller name.
+// The AnyController entry applies to all controllers not listed explicitly. It must be called
+// before the controllers start.
+func SetReconcileTimeouts(timeouts map[string]time.Duration) {
+	if len(timeouts) == 0 {
+		reconcileTimeouts.Store(nil)
+		return
+	}
+	reconcileTimeouts.Store(&timeouts)
+}
+
+// SetMaxRetries sets the number of retries of a failing key per controller name, after which
+// the key is dropped from the queue. The AnyController entry applies to all controllers not
+// listed explicitly. Zero means retrying forever. It must be called before the controllers start.
+func SetMaxRetries(retries map[string]int) {
+	if len(retries) == 0 {
+		maxRetries.Store(nil)
+		return
+	}
+	maxRetries.Store(&retries)
+}
+
+// SetEventRecorder sets the recorder of the warning events emitted when a key is dropped
+// from the queue after too many failed retries. The events are annotated with the logical
+// cluster of the key. It must be called before the controllers start.
+func SetEventRecorder(r record.EventRecorder) {
+	if r == nil {
+		eventRecorder.Store(nil)
+		return
+	}
+	eventRecorder.Store(&r)
+}
+
+// SetTracerProvider sets the tracer provider used to emit a span for every reconcile.
+// It must be called before the controllers start.
+func SetTracerProvider(tp trace.TracerProvider) {
+	if tp == nil {
+		tracer.Store(nil)
+		return
+	}
+	t := tp.Tracer("github.com/kcp-dev/kcp/pkg/reconciler")
+	tracer.Store(&t)
+}
+
+// BeginReconcile is to be called by a controller worker before reconciling a key. It waits for
+// a slot within the global in-flight budget and bounds the returned context by the reconcile
+// timeout of the named controller. The returned function must be called once the reconcile is
+// finished. ok is false if ctx was done before a slot became free.
+//
+// A reconcile running into its deadline fails with a context.DeadlineExceeded error, which makes
+// the worker requeue the key with backoff instead of blocking indefinitely.
+//
+// If a tracer provider is set, the reconcile is recorded as a span, which the returned
+// context carries for the client requests of the reconcile.
+//
+// Every reconcile gets a unique ID, which is added as reconcileID to the logger of the returned
+// context, such that the log lines of one reconcile can be correlated.
+func BeginReconcile(ctx context.Context, controllerName string) (_ context.Context, done func(), ok bool) {
+	release, ok := acquireReconcileSlot(ctx)
+	if !ok {
+		return ctx, nil, false
+	}
+
+	ctx = context.WithValue(ctx, controllerNameContextKey, controllerName)
+	reconcileID := string(uuid.NewUUID())
+	ctx = context.WithValue(ctx, reconcileIDContextKey, reconcileID)
+	ctx = klog.NewContext(ctx, klog.FromContext(ctx).WithValues("reconcileID", reconcileID))
+	ctx, span := reconcileTracer().Start(ctx, "Reconcile", trace.WithAttributes(attribute.String("controller", controllerName)))
+
+	timeout := reconcileTimeout(controllerName)
+	if timeout <= 0 {
+		return ctx, func() {
+			span.End()
+			release()
+		}, true
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	return ctx, func() {
+		cancel()
+		span.End()
+		release()
+	}, true
+}
+
+// ControllerFrom returns the name of the controller reconciling with the given context,
+// or an empty string outside of a reconcile.
+func ControllerFrom(ctx context.Context) string {
+	name, _ := ctx.Value(controllerNameContextKey).(string)
+	return name
+}
+
+// ReconcileIDFrom returns the ID of the reconcile running with the given context,
+// or an empty string outside of a reconcile.
+func ReconcileIDFrom(ctx context.Context) string {
+	id, _ := ctx.Value(reconcileIDContextKey).(string)
+	return id
+}
+
+// Requeue is to be called by a controller worker when reconciling key failed, with the context
+// returned by BeginReconcile. It adds the key back to the queue with backoff, unless the key has
+// already been retried as often as the reconciling controller allows. Then the key is dropped,
+// and picked up again only when it is enqueued by an event or resync. A warning event is recorded
+// for the object of a dropped key if an event recorder is set.
+func Requeue[T comparable](ctx context.Context, queue workqueue.TypedRateLimitingInterface[T], key T) {
+	if n := maxRetriesFor(ControllerFrom(ctx)); n > 0 && queue.NumRequeues(key) >= n {
+		klog.FromContext(ctx).Error(nil, "dropping key from the queue after too many failed retries", "retries", n)
+		recordDroppedKey(ctx, key, n)
+		queue.Forget(key)
+		return
+	}
+	queue.AddRateLimited(key)
+}
+
+// recordDroppedKey records a warning event for the object of a key dropped after the given
+// number of retries. Only cluster-aware string keys can be mapped to an object, all others
+// are skipped.
+func recordDroppedKey(ctx context.Context, key any, retries int) {
+	r := eventRecorder.Load()
+	if r == nil {
+		return
+	}
+	s, ok := key.(string)
+	if !ok {
+		return
+	}
+	clusterName, namespace, name, err := kcpcache.SplitMetaClusterNamespaceKey(s)
+	if err != nil || clusterName.Empty() || name == "" {
+		return
+	}
+
+	(*r).AnnotatedEventf(
+		&corev1.ObjectReference{Namespace: namespace, Name: name},
+		map[string]string{logicalcluster.AnnotationKey: clusterName.String()},
+		corev1.EventTypeWarning,
+		RetriesExhaustedReason,
+		"The %s controller stopped retrying after %d failed attempts. It retries again on the next change or resync.",
+		ControllerFrom(ctx), retries,
+	)
+}
+
+func maxRetriesFor(controllerName string) int {
+	retries := maxRetries.Load()
+	if retries == nil {
+		return 0
+	}
+	if n, ok := (*retries)[controllerName]; ok {
+		return n
+	}
+	return (*retries)[AnyController]
+}
+
+func reconcileTracer() trace.Tracer {
+	if t := tracer.Load(); t != nil {
+		return *t
+	}
+	return noop.Tracer{}
+}
+
+func reconcileTimeout(controllerName string) time.Duration {
+	timeouts := reconcileTimeouts.Load()
+	if timeouts == nil {
+		return 0
+	}
+	if timeout, ok := (*timeouts)[controllerName]; ok {
+		return timeout
+	}
+	return (*timeouts)[AnyController]
+}
+
+// acquireReconcileSlot blocks until a reconcile may run within the global in-flight
+// budget. It returns a function to release the slot again, and false if ctx was done
+// before a slot became free.
+func acquireReconcileSlot(ctx context.Context) (release func(), ok bool) {
+	ch := inFlight.Load()
+	if ch == nil {
+		return func() {}, true
+	}
+
+	select {
+	case *ch <- struct{}{}:
+		return func() { <-*ch }, true
+	case <-ctx.Done():
+		return nil, false
+	}
+}
@@ -0,0 +1,253 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package limits
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/workqueue"
+)
+
+func TestBeginReconcileInFlight(t *testing.T) {
+	SetMaxInFlightReconciles(1)
+	t.Cleanup(func() { SetMaxInFlightReconciles(0) })
+
+	_, done, ok := BeginReconcile(context.Background(), "foo")
+	require.True(t, ok)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, _, ok = BeginReconcile(ctx, "bar")
+	require.False(t, ok, "expected second reconcile to wait for a free slot")
+
+	done()
+	_, done, ok = BeginReconcile(context.Background(), "bar")
+	require.True(t, ok)
+	done()
+}
+
+func TestBeginReconcileTimeout(t *testing.T) {
+	SetReconcileTimeouts(map[string]time.Duration{
+		"foo":         time.Minute,
+		AnyController: time.Hour,
+	})
+	t.Cleanup(func() { SetReconcileTimeouts(nil) })
+
+	tests := map[string]struct {
+		controllerName string
+		wantTimeout    time.Duration
+	}{
+		"explicit":  {controllerName: "foo", wantTimeout: time.Minute},
+		"catch-all": {controllerName: "bar", wantTimeout: time.Hour},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			start := time.Now()
+			ctx, done, ok := BeginReconcile(context.Background(), tc.controllerName)
+			require.True(t, ok)
+			defer done()
+
+			deadline, ok := ctx.Deadline()
+			require.True(t, ok)
+			require.WithinDuration(t, start.Add(tc.wantTimeout), deadline, time.Second)
+		})
+	}
+
+	SetReconcileTimeouts(nil)
+	ctx, done, ok := BeginReconcile(context.Background(), "foo")
+	require.True(t, ok)
+	defer done()
+	_, hasDeadline := ctx.Deadline()
+	require.False(t, hasDeadline)
+}
+
+type fakeTracerProvider struct {
+	noop.TracerProvider
+	started, ended []string
+}
+
+func (p *fakeTracerProvider) Tracer(string, ...trace.TracerOption) trace.Tracer {
+	return &fakeTracer{provider: p}
+}
+
+type fakeTracer struct {
+	noop.Tracer
+	provider *fakeTracerProvider
+}
+
+func (t *fakeTracer) Start(ctx context.Context, name string, _ ...trace.SpanStartOption) (context.Context, trace.Span) {
+	t.provider.started = append(t.provider.started, name)
+	return ctx, &fakeSpan{name: name, provider: t.provider}
+}
+
+type fakeSpan struct {
+	noop.Span
+	name     string
+	provider *fakeTracerProvider
+}
+
+func (s *fakeSpan) End(...trace.SpanEndOption) {
+	s.provider.ended = append(s.provider.ended, s.name)
+}
+
+func TestBeginReconcileSpan(t *testing.T) {
+	tp := &fakeTracerProvider{}
+	SetTracerProvider(tp)
+	t.Cleanup(func() { SetTracerProvider(nil) })
+
+	_, done, ok := BeginReconcile(context.Background(), "foo")
+	require.True(t, ok)
+	require.Equal(t, []string{"Reconcile"}, tp.started)
+	require.Empty(t, tp.ended)
+
+	done()
+	require.Equal(t, []string{"Reconcile"}, tp.ended)
+}
+
+func TestBeginReconcileControllerName(t *testing.T) {
+	require.Empty(t, ControllerFrom(context.Background()))
+
+	ctx, done, ok := BeginReconcile(context.Background(), "foo")
+	require.True(t, ok)
+	defer done()
+	require.Equal(t, "foo", ControllerFrom(ctx))
+}
+
+func TestBeginReconcileID(t *testing.T) {
+	require.Empty(t, ReconcileIDFrom(context.Background()))
+
+	ctx1, done, ok := BeginReconcile(context.Background(), "foo")
+	require.True(t, ok)
+	defer done()
+	ctx2, done, ok := BeginReconcile(context.Background(), "foo")
+	require.True(t, ok)
+	defer done()
+
+	require.NotEmpty(t, ReconcileIDFrom(ctx1))
+	require.NotEqual(t, ReconcileIDFrom(ctx1), ReconcileIDFrom(ctx2))
+}
+
+func TestRequeueMaxRetries(t *testing.T) {
+	SetMaxRetries(map[string]int{
+		"foo":         2,
+		"bar":         0,
+		AnyController: 1,
+	})
+	t.Cleanup(func() { SetMaxRetries(nil) })
+
+	tests := map[string]struct {
+		controllerName string
+		failures       int
+		wantDropped    bool
+	}{
+		"explicit, within limit":   {controllerName: "foo", failures: 2},
+		"explicit, exceeded":       {controllerName: "foo", failures: 3, wantDropped: true},
+		"catch-all, exceeded":      {controllerName: "baz", failures: 2, wantDropped: true},
+		"zero means retry forever": {controllerName: "bar", failures: 10},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			queue := workqueue.NewTypedRateLimitingQueue(workqueue.NewTypedItemExponentialFailureRateLimiter[string](time.Millisecond, time.Millisecond))
+			defer queue.ShutDown()
+
+			ctx, done, ok := BeginReconcile(context.Background(), tc.controllerName)
+			require.True(t, ok)
+			defer done()
+
+			for i := 0; i < tc.failures; i++ {
+				Requeue(ctx, queue, "key")
+			}
+
+			if tc.wantDropped {
+				require.Zero(t, queue.NumRequeues("key"), "expected key to be dropped")
+			} else {
+				require.Equal(t, tc.failures, queue.NumRequeues("key"))
+			}
+		})
+	}
+}
+
+// fakeEventRecorder records the events as strings of the involved object, the annotations,
+// the type, the reason and the message.
+type fakeEventRecorder struct {
+	events []string
+}
+
+func (r *fakeEventRecorder) Event(object runtime.Object, eventtype, reason, message string) {
+	r.AnnotatedEventf(object, nil, eventtype, reason, "%s", message)
+}
+
+func (r *fakeEventRecorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	r.AnnotatedEventf(object, nil, eventtype, reason, messageFmt, args...)
+}
+
+func (r *fakeEventRecorder) AnnotatedEventf(object runtime.Object, annotations map[string]string, eventtype, reason, messageFmt string, args ...interface{}) {
+	ref := object.(*corev1.ObjectReference)
+	r.events = append(r.events, fmt.Sprintf("%s/%s %v %s %s: %s", ref.Namespace, ref.Name, annotations, eventtype, reason, fmt.Sprintf(messageFmt, args...)))
+}
+
+func TestRequeueRecordsDroppedKey(t *testing.T) {
+	SetMaxRetries(map[string]int{AnyController: 1})
+	t.Cleanup(func() { SetMaxRetries(nil) })
+
+	tests := map[string]struct {
+		key        string
+		failures   int
+		wantEvents []string
+	}{
+		"within limit": {key: "root|ns/name", failures: 1},
+		"namespaced, exceeded": {key: "root|ns/name", failures: 2, wantEvents: []string{
+			"ns/name map[kcp.io/cluster:root] Warning RetriesExhausted: The foo controller stopped retrying after 1 failed attempts. It retries again on the next change or resync.",
+		}},
+		"cluster-scoped, exceeded": {key: "root|name", failures: 2, wantEvents: []string{
+			"/name map[kcp.io/cluster:root] Warning RetriesExhausted: The foo controller stopped retrying after 1 failed attempts. It retries again on the next change or resync.",
+		}},
+		"not cluster-aware, exceeded": {key: "name", failures: 2},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			recorder := &fakeEventRecorder{}
+			SetEventRecorder(recorder)
+			t.Cleanup(func() { SetEventRecorder(nil) })
+
+			queue := workqueue.NewTypedRateLimitingQueue(workqueue.NewTypedItemExponentialFailureRateLimiter[string](time.Millisecond, time.Millisecond))
+			defer queue.ShutDown()
+
+			ctx, done, ok := BeginReconcile(context.Background(), "foo")
+			require.True(t, ok)
+			defer done()
+
+			for i := 0; i < tc.failures; i++ {
+				Requeue(ctx, queue, tc.key)
+			}
+
+			require.Equal(t, tc.wantEvents, recorder.events)
+		})
+	}
+}
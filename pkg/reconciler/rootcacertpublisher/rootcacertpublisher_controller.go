@@ -0,0 +1,289 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rootcacertpublisher
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	kcpcache "github.com/kcp-dev/apimachinery/v2/pkg/cache"
+	kcpcorev1informers "github.com/kcp-dev/client-go/informers/core/v1"
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+	kuberootcacertpublisher "k8s.io/kubernetes/pkg/controller/certificates/rootcacertpublisher"
+
+	"github.com/kcp-dev/kcp/pkg/logging"
+	"github.com/kcp-dev/kcp/pkg/reconciler/limits"
+	"github.com/kcp-dev/kcp/pkg/reconciler/shutdown"
+)
+
+const (
+	ControllerName = "kube-root-ca-configmap-controller"
+)
+
+// NewController returns a new controller that publishes the given root CA in the
+// kube-root-ca.crt configmap of every namespace, like the root CA cert publisher of
+// kube-controller-manager. Unlike the latter, the root CA can be changed with SetRootCA.
+func NewController(
+	kubeClusterClient kcpkubernetesclientset.ClusterInterface,
+	configMapInformer kcpcorev1informers.ConfigMapClusterInformer,
+	namespaceInformer kcpcorev1informers.NamespaceClusterInformer,
+	rootCA []byte,
+) (*controller, error) {
+	c := &controller{
+		queue: workqueue.NewTypedRateLimitingQueueWithConfig(
+			workqueue.DefaultTypedControllerRateLimiter[string](),
+			workqueue.TypedRateLimitingQueueConfig[string]{
+				Name: ControllerName,
+			},
+		),
+		rootCA: rootCA,
+
+		getConfigMap: func(clusterName logicalcluster.Name, namespace string) (*corev1.ConfigMap, error) {
+			return configMapInformer.Lister().Cluster(clusterName).ConfigMaps(namespace).Get(kuberootcacertpublisher.RootCACertConfigMapName)
+		},
+		listNamespaces: func() ([]*corev1.Namespace, error) {
+			return namespaceInformer.Lister().List(labels.Everything())
+		},
+		createConfigMap: func(ctx context.Context, clusterName logicalcluster.Name, cm *corev1.ConfigMap) error {
+			_, err := kubeClusterClient.Cluster(clusterName.Path()).CoreV1().ConfigMaps(cm.Namespace).Create(ctx, cm, metav1.CreateOptions{})
+			return err
+		},
+		updateConfigMap: func(ctx context.Context, clusterName logicalcluster.Name, cm *corev1.ConfigMap) error {
+			_, err := kubeClusterClient.Cluster(clusterName.Path()).CoreV1().ConfigMaps(cm.Namespace).Update(ctx, cm, metav1.UpdateOptions{})
+			return err
+		},
+	}
+
+	logger := logging.WithReconciler(klog.Background(), ControllerName)
+
+	_, _ = configMapInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: func(obj interface{}) bool {
+			if d, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = d.Obj
+			}
+			cm, ok := obj.(*corev1.ConfigMap)
+			return ok && cm.Name == kuberootcacertpublisher.RootCACertConfigMapName
+		},
+		Handler: cache.ResourceEventHandlerFuncs{
+			UpdateFunc: func(_, obj interface{}) { c.enqueueConfigMap(obj, logger) },
+			DeleteFunc: func(obj interface{}) { c.enqueueConfigMap(obj, logger) },
+		},
+	})
+
+	_, _ = namespaceInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) { c.enqueueNamespace(obj, logger) },
+		UpdateFunc: func(_, obj interface{}) {
+			if ns, ok := obj.(*corev1.Namespace); ok && ns.Status.Phase == corev1.NamespaceActive {
+				c.enqueueNamespace(obj, logger)
+			}
+		},
+	})
+
+	return c, nil
+}
+
+// controller ensures the kube-root-ca.crt configmap with the current root CA in every
+// namespace. The queue is keyed by namespace.
+type controller struct {
+	queue workqueue.TypedRateLimitingInterface[string]
+
+	lock   sync.RWMutex
+	rootCA []byte
+
+	getConfigMap    func(clusterName logicalcluster.Name, namespace string) (*corev1.ConfigMap, error)
+	listNamespaces  func() ([]*corev1.Namespace, error)
+	createConfigMap func(ctx context.Context, clusterName logicalcluster.Name, cm *corev1.ConfigMap) error
+	updateConfigMap func(ctx context.Context, clusterName logicalcluster.Name, cm *corev1.ConfigMap) error
+}
+
+// SetRootCA switches to the given root CA and enqueues all namespaces to update their
+// configmaps.
+func (c *controller) SetRootCA(rootCA []byte) error {
+	c.lock.Lock()
+	changed := !bytes.Equal(c.rootCA, rootCA)
+	c.rootCA = rootCA
+	c.lock.Unlock()
+
+	if !changed {
+		return nil
+	}
+
+	namespaces, err := c.listNamespaces()
+	if err != nil {
+		return err
+	}
+	logger := logging.WithReconciler(klog.Background(), ControllerName)
+	logger.Info("root CA changed, queueing all namespaces", "namespaces", len(namespaces))
+	for _, ns := range namespaces {
+		c.enqueueNamespace(ns, logger)
+	}
+	return nil
+}
+
+func (c *controller) getRootCA() []byte {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.rootCA
+}
+
+// enqueueConfigMap enqueues the namespace of a root CA configmap.
+func (c *controller) enqueueConfigMap(obj interface{}, logger klog.Logger) {
+	if d, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = d.Obj
+	}
+
+	cm, ok := obj.(*corev1.ConfigMap)
+	if !ok {
+		utilruntime.HandleError(fmt.Errorf("obj is supposed to be a ConfigMap, but is %T", obj))
+		return
+	}
+
+	key := kcpcache.ToClusterAwareKey(logicalcluster.From(cm).String(), "", cm.Namespace)
+	logging.WithQueueKey(logger, key).V(4).Info("queueing namespace because of root CA configmap")
+	c.queue.Add(key)
+}
+
+// enqueueNamespace enqueues a namespace.
+func (c *controller) enqueueNamespace(obj interface{}, logger klog.Logger) {
+	key, err := kcpcache.DeletionHandlingMetaClusterNamespaceKeyFunc(obj)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+
+	logging.WithQueueKey(logger, key).V(4).Info("queueing namespace")
+	c.queue.Add(key)
+}
+
+// Start starts the controller, which stops when ctx.Done() is closed.
+func (c *controller) Start(ctx context.Context, numThreads int) {
+	defer utilruntime.HandleCrash()
+	defer c.queue.ShutDown()
+
+	logger := logging.WithReconciler(klog.FromContext(ctx), ControllerName)
+	ctx = klog.NewContext(ctx, logger)
+	logger.Info("Starting controller")
+	defer logger.Info("Shutting down controller")
+
+	for i := 0; i < numThreads; i++ {
+		go wait.UntilWithContext(ctx, c.startWorker, time.Second)
+	}
+
+	<-ctx.Done()
+}
+
+func (c *controller) Drain(ctx context.Context) error {
+	return shutdown.DrainQueue(ctx, c.queue)
+}
+
+func (c *controller) startWorker(ctx context.Context) {
+	for c.processNextWorkItem(ctx) {
+	}
+}
+
+func (c *controller) processNextWorkItem(ctx context.Context) bool {
+	// Wait until there is a new item in the working queue
+	k, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	key := k
+
+	logger := logging.WithQueueKey(klog.FromContext(ctx), key)
+	ctx = klog.NewContext(ctx, logger)
+	logger.V(4).Info("processing key")
+
+	// No matter what, tell the queue we're done with this key, to unblock
+	// other workers.
+	defer c.queue.Done(key)
+
+	ctx, done, ok := limits.BeginReconcile(ctx, ControllerName)
+	if !ok {
+		return false // shutting down
+	}
+	defer done()
+
+	if err := c.process(ctx, key); err != nil {
+		utilruntime.HandleError(fmt.Errorf("%q controller failed to sync %q, err: %w", ControllerName, key, err))
+		limits.Requeue(ctx, c.queue, key)
+		return true
+	}
+	c.queue.Forget(key)
+	return true
+}
+
+func (c *controller) process(ctx context.Context, key string) error {
+	clusterName, _, namespace, err := kcpcache.SplitMetaClusterNamespaceKey(key)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return nil
+	}
+
+	data := map[string]string{
+		"ca.crt": string(c.getRootCA()),
+	}
+
+	cm, err := c.getConfigMap(clusterName, namespace)
+	switch {
+	case apierrors.IsNotFound(err):
+		err := c.createConfigMap(ctx, clusterName, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        kuberootcacertpublisher.RootCACertConfigMapName,
+				Namespace:   namespace,
+				Annotations: map[string]string{kuberootcacertpublisher.DescriptionAnnotation: kuberootcacertpublisher.Description},
+			},
+			Data: data,
+		})
+		// don't retry a create if the namespace doesn't exist or is terminating
+		if apierrors.IsNotFound(err) || apierrors.HasStatusCause(err, corev1.NamespaceTerminatingCause) {
+			return nil
+		}
+		return err
+	case err != nil:
+		return err
+	}
+
+	// ensure the data and the one annotation describing usage of this configmap match.
+	if reflect.DeepEqual(cm.Data, data) && len(cm.Annotations[kuberootcacertpublisher.DescriptionAnnotation]) > 0 {
+		return nil
+	}
+
+	// copy so we don't modify the cache's instance of the configmap
+	cm = cm.DeepCopy()
+	cm.Data = data
+	if cm.Annotations == nil {
+		cm.Annotations = map[string]string{}
+	}
+	cm.Annotations[kuberootcacertpublisher.DescriptionAnnotation] = kuberootcacertpublisher.Description
+
+	return c.updateConfigMap(ctx, clusterName, cm)
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rootcacertpublisher
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/workqueue"
+	kuberootcacertpublisher "k8s.io/kubernetes/pkg/controller/certificates/rootcacertpublisher"
+)
+
+func TestSetRootCA(t *testing.T) {
+	configMaps := map[string]*corev1.ConfigMap{}
+	c := &controller{
+		queue:  workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[string]()),
+		rootCA: []byte("old"),
+
+		getConfigMap: func(clusterName logicalcluster.Name, namespace string) (*corev1.ConfigMap, error) {
+			if cm, ok := configMaps[namespace]; ok {
+				return cm, nil
+			}
+			return nil, apierrors.NewNotFound(corev1.Resource("configmaps"), kuberootcacertpublisher.RootCACertConfigMapName)
+		},
+		listNamespaces: func() ([]*corev1.Namespace, error) {
+			return []*corev1.Namespace{
+				{ObjectMeta: metav1.ObjectMeta{Name: "a", Annotations: map[string]string{logicalcluster.AnnotationKey: "root"}}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "b", Annotations: map[string]string{logicalcluster.AnnotationKey: "root"}}},
+			}, nil
+		},
+		createConfigMap: func(ctx context.Context, clusterName logicalcluster.Name, cm *corev1.ConfigMap) error {
+			configMaps[cm.Namespace] = cm
+			return nil
+		},
+		updateConfigMap: func(ctx context.Context, clusterName logicalcluster.Name, cm *corev1.ConfigMap) error {
+			configMaps[cm.Namespace] = cm
+			return nil
+		},
+	}
+	defer c.queue.ShutDown()
+
+	for _, key := range []string{"root|a", "root|b"} {
+		require.NoError(t, c.process(context.Background(), key))
+	}
+	require.Equal(t, "old", configMaps["a"].Data["ca.crt"])
+	require.Equal(t, "old", configMaps["b"].Data["ca.crt"])
+
+	require.NoError(t, c.SetRootCA([]byte("old")))
+	require.Equal(t, 0, c.queue.Len(), "expected an unchanged root CA not to queue namespaces")
+
+	require.NoError(t, c.SetRootCA([]byte("new")))
+	require.Equal(t, 2, c.queue.Len(), "expected a changed root CA to queue all namespaces")
+
+	for c.queue.Len() > 0 {
+		key, _ := c.queue.Get()
+		require.NoError(t, c.process(context.Background(), key))
+		c.queue.Done(key)
+	}
+	require.Equal(t, "new", configMaps["a"].Data["ca.crt"])
+	require.Equal(t, "new", configMaps["b"].Data["ca.crt"])
+	require.Equal(t, kuberootcacertpublisher.Description, configMaps["a"].Annotations[kuberootcacertpublisher.DescriptionAnnotation])
+}
@@ -0,0 +1,228 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rootcacleanup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	kcpcache "github.com/kcp-dev/apimachinery/v2/pkg/cache"
+	kcpcorev1informers "github.com/kcp-dev/client-go/informers/core/v1"
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+	"k8s.io/kubernetes/pkg/controller/certificates/rootcacertpublisher"
+
+	"github.com/kcp-dev/kcp/pkg/logging"
+	"github.com/kcp-dev/kcp/pkg/reconciler/limits"
+	"github.com/kcp-dev/kcp/pkg/reconciler/shutdown"
+)
+
+const (
+	ControllerName = "kcp-root-ca-configmap-cleanup"
+)
+
+// NewController returns a new controller that deletes root CA configmaps left behind
+// in namespaces that do not exist anymore.
+func NewController(
+	kubeClusterClient kcpkubernetesclientset.ClusterInterface,
+	configMapInformer kcpcorev1informers.ConfigMapClusterInformer,
+	namespaceInformer kcpcorev1informers.NamespaceClusterInformer,
+) (*controller, error) {
+	c := &controller{
+		queue: workqueue.NewTypedRateLimitingQueueWithConfig(
+			workqueue.DefaultTypedControllerRateLimiter[string](),
+			workqueue.TypedRateLimitingQueueConfig[string]{
+				Name: ControllerName,
+			},
+		),
+
+		getConfigMap: func(clusterName logicalcluster.Name, namespace, name string) (*corev1.ConfigMap, error) {
+			return configMapInformer.Lister().Cluster(clusterName).ConfigMaps(namespace).Get(name)
+		},
+		getNamespace: func(clusterName logicalcluster.Name, name string) (*corev1.Namespace, error) {
+			return namespaceInformer.Lister().Cluster(clusterName).Get(name)
+		},
+		getLiveNamespace: func(ctx context.Context, clusterName logicalcluster.Name, name string) (*corev1.Namespace, error) {
+			return kubeClusterClient.Cluster(clusterName.Path()).CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
+		},
+		deleteConfigMap: func(ctx context.Context, clusterName logicalcluster.Name, namespace, name string) error {
+			return kubeClusterClient.Cluster(clusterName.Path()).CoreV1().ConfigMaps(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+		},
+	}
+
+	logger := logging.WithReconciler(klog.Background(), ControllerName)
+
+	_, _ = configMapInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: func(obj interface{}) bool {
+			cm, ok := obj.(*corev1.ConfigMap)
+			return ok && cm.Name == rootcacertpublisher.RootCACertConfigMapName
+		},
+		Handler: cache.ResourceEventHandlerFuncs{
+			AddFunc:    func(obj interface{}) { c.enqueueConfigMap(obj, logger) },
+			UpdateFunc: func(_, obj interface{}) { c.enqueueConfigMap(obj, logger) },
+		},
+	})
+
+	_, _ = namespaceInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		DeleteFunc: func(obj interface{}) { c.enqueueNamespace(obj, logger) },
+	})
+
+	return c, nil
+}
+
+// controller deletes root CA configmaps whose namespace is gone. The root CA publisher
+// can race with the deletion of a namespace and recreate the configmap after the namespace
+// controller removed the namespace contents.
+type controller struct {
+	queue workqueue.TypedRateLimitingInterface[string]
+
+	getConfigMap     func(clusterName logicalcluster.Name, namespace, name string) (*corev1.ConfigMap, error)
+	getNamespace     func(clusterName logicalcluster.Name, name string) (*corev1.Namespace, error)
+	getLiveNamespace func(ctx context.Context, clusterName logicalcluster.Name, name string) (*corev1.Namespace, error)
+	deleteConfigMap  func(ctx context.Context, clusterName logicalcluster.Name, namespace, name string) error
+}
+
+// enqueueConfigMap enqueues a root CA configmap.
+func (c *controller) enqueueConfigMap(obj interface{}, logger klog.Logger) {
+	key, err := kcpcache.DeletionHandlingMetaClusterNamespaceKeyFunc(obj)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+
+	logging.WithQueueKey(logger, key).V(4).Info("queueing root CA configmap")
+	c.queue.Add(key)
+}
+
+// enqueueNamespace enqueues the root CA configmap of a deleted namespace.
+func (c *controller) enqueueNamespace(obj interface{}, logger klog.Logger) {
+	if d, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = d.Obj
+	}
+
+	ns, ok := obj.(*corev1.Namespace)
+	if !ok {
+		utilruntime.HandleError(fmt.Errorf("obj is supposed to be a Namespace, but is %T", obj))
+		return
+	}
+
+	key := kcpcache.ToClusterAwareKey(logicalcluster.From(ns).String(), ns.Name, rootcacertpublisher.RootCACertConfigMapName)
+	logging.WithQueueKey(logger, key).V(4).Info("queueing root CA configmap because of deleted namespace")
+	c.queue.Add(key)
+}
+
+// Start starts the controller, which stops when ctx.Done() is closed.
+func (c *controller) Start(ctx context.Context, numThreads int) {
+	defer utilruntime.HandleCrash()
+	defer c.queue.ShutDown()
+
+	logger := logging.WithReconciler(klog.FromContext(ctx), ControllerName)
+	ctx = klog.NewContext(ctx, logger)
+	logger.Info("Starting controller")
+	defer logger.Info("Shutting down controller")
+
+	for i := 0; i < numThreads; i++ {
+		go wait.UntilWithContext(ctx, c.startWorker, time.Second)
+	}
+
+	<-ctx.Done()
+}
+
+func (c *controller) Drain(ctx context.Context) error {
+	return shutdown.DrainQueue(ctx, c.queue)
+}
+
+func (c *controller) startWorker(ctx context.Context) {
+	for c.processNextWorkItem(ctx) {
+	}
+}
+
+func (c *controller) processNextWorkItem(ctx context.Context) bool {
+	// Wait until there is a new item in the working queue
+	k, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	key := k
+
+	logger := logging.WithQueueKey(klog.FromContext(ctx), key)
+	ctx = klog.NewContext(ctx, logger)
+	logger.V(4).Info("processing key")
+
+	// No matter what, tell the queue we're done with this key, to unblock
+	// other workers.
+	defer c.queue.Done(key)
+
+	ctx, done, ok := limits.BeginReconcile(ctx, ControllerName)
+	if !ok {
+		return false // shutting down
+	}
+	defer done()
+
+	if err := c.process(ctx, key); err != nil {
+		utilruntime.HandleError(fmt.Errorf("%q controller failed to sync %q, err: %w", ControllerName, key, err))
+		limits.Requeue(ctx, c.queue, key)
+		return true
+	}
+	c.queue.Forget(key)
+	return true
+}
+
+func (c *controller) process(ctx context.Context, key string) error {
+	logger := klog.FromContext(ctx)
+
+	clusterName, namespace, name, err := kcpcache.SplitMetaClusterNamespaceKey(key)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return nil
+	}
+
+	if _, err := c.getConfigMap(clusterName, namespace, name); apierrors.IsNotFound(err) {
+		return nil // nothing left behind
+	} else if err != nil {
+		return err
+	}
+
+	if _, err := c.getNamespace(clusterName, namespace); err == nil {
+		return nil
+	} else if !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	// the informer might not have seen a new namespace yet. Only a live lookup tells us for sure.
+	if _, err := c.getLiveNamespace(ctx, clusterName, namespace); err == nil {
+		return nil
+	} else if !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	logger.Info("deleting root CA configmap of deleted namespace")
+	if err := c.deleteConfigMap(ctx, clusterName, namespace, name); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
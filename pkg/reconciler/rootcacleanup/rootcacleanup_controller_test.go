@@ -0,0 +1,88 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rootcacleanup
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+func TestProcess(t *testing.T) {
+	tests := map[string]struct {
+		configMapExists     bool
+		namespaceCached     bool
+		namespaceExistsLive bool
+
+		wantDeleted bool
+	}{
+		"configmap already gone": {},
+		"namespace exists": {
+			configMapExists:     true,
+			namespaceCached:     true,
+			namespaceExistsLive: true,
+		},
+		"namespace not yet in informer": {
+			configMapExists:     true,
+			namespaceExistsLive: true,
+		},
+		"namespace deleted": {
+			configMapExists: true,
+			wantDeleted:     true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var deleted bool
+			c := &controller{
+				getConfigMap: func(clusterName logicalcluster.Name, namespace, name string) (*corev1.ConfigMap, error) {
+					if !tt.configMapExists {
+						return nil, apierrors.NewNotFound(corev1.Resource("configmaps"), name)
+					}
+					return &corev1.ConfigMap{}, nil
+				},
+				getNamespace: func(clusterName logicalcluster.Name, name string) (*corev1.Namespace, error) {
+					if !tt.namespaceCached {
+						return nil, apierrors.NewNotFound(corev1.Resource("namespaces"), name)
+					}
+					return &corev1.Namespace{}, nil
+				},
+				getLiveNamespace: func(ctx context.Context, clusterName logicalcluster.Name, name string) (*corev1.Namespace, error) {
+					if !tt.namespaceExistsLive {
+						return nil, apierrors.NewNotFound(corev1.Resource("namespaces"), name)
+					}
+					return &corev1.Namespace{}, nil
+				},
+				deleteConfigMap: func(ctx context.Context, clusterName logicalcluster.Name, namespace, name string) error {
+					require.Equal(t, "foo", namespace)
+					require.Equal(t, "kube-root-ca.crt", name)
+					deleted = true
+					return nil
+				},
+			}
+
+			require.NoError(t, c.process(context.Background(), "root:org|foo/kube-root-ca.crt"))
+			require.Equal(t, tt.wantDeleted, deleted)
+		})
+	}
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package shutdown helps controllers to finish their work when the server shuts down.
+package shutdown
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const drainPollInterval = 10 * time.Millisecond
+
+// Queue is the part of a workqueue needed to drain it.
+type Queue interface {
+	ShutDownWithDrain()
+	Len() int
+}
+
+// DrainQueue stops the queue from accepting new items and waits until the workers have
+// processed all queued and in-flight items, or until ctx is done. The workers must keep
+// running while the queue drains. Items requeued with a delay during draining are dropped.
+func DrainQueue(ctx context.Context, queue Queue) error {
+	drained := make(chan struct{})
+	go func() {
+		defer close(drained)
+		for {
+			// ShutDownWithDrain returns once nothing is in flight, but items can still be
+			// queued, e.g. because they were added again while being processed.
+			queue.ShutDownWithDrain()
+			if queue.Len() == 0 || ctx.Err() != nil {
+				return
+			}
+			time.Sleep(drainPollInterval)
+		}
+	}()
+
+	select {
+	case <-drained:
+		if ctx.Err() != nil {
+			return fmt.Errorf("queue not drained, %d items left: %w", queue.Len(), ctx.Err())
+		}
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("queue not drained, %d items left: %w", queue.Len(), ctx.Err())
+	}
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shutdown
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/client-go/util/workqueue"
+)
+
+func TestDrainQueue(t *testing.T) {
+	tests := map[string]struct {
+		items     int
+		workDelay time.Duration
+		timeout   time.Duration
+		wantErr   bool
+	}{
+		"empty queue": {
+			timeout: time.Second,
+		},
+		"queued items are processed": {
+			items:     20,
+			workDelay: 5 * time.Millisecond,
+			timeout:   10 * time.Second,
+		},
+		"deadline exceeded": {
+			items:     20,
+			workDelay: 100 * time.Millisecond,
+			timeout:   50 * time.Millisecond,
+			wantErr:   true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			queue := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[string]())
+			for i := 0; i < tt.items; i++ {
+				queue.Add(fmt.Sprintf("item-%d", i))
+			}
+
+			var processed atomic.Int32
+			go func() {
+				for {
+					key, quit := queue.Get()
+					if quit {
+						return
+					}
+					time.Sleep(tt.workDelay)
+					processed.Add(1)
+					queue.Done(key)
+				}
+			}()
+
+			ctx, cancel := context.WithTimeout(context.Background(), tt.timeout)
+			defer cancel()
+
+			err := DrainQueue(ctx, queue)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, 0, queue.Len())
+			require.Equal(t, int32(tt.items), processed.Load())
+
+			queue.Add("late")
+			require.Equal(t, 0, queue.Len(), "drained queue must not accept new items")
+		})
+	}
+}
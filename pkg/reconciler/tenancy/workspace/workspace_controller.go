@@ -23,6 +23,7 @@ import (
 
 	kcpcache "github.com/kcp-dev/apimachinery/v2/pkg/cache"
 	"github.com/kcp-dev/client-go/kubernetes"
+	"github.com/kcp-dev/logicalcluster/v3"
 
 	"k8s.io/apimachinery/pkg/api/errors"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
@@ -37,6 +38,9 @@ import (
 	"github.com/kcp-dev/kcp/pkg/logging"
 	"github.com/kcp-dev/kcp/pkg/reconciler/committer"
 	"github.com/kcp-dev/kcp/pkg/reconciler/events"
+	"github.com/kcp-dev/kcp/pkg/reconciler/limits"
+	"github.com/kcp-dev/kcp/pkg/reconciler/shutdown"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
 	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
 	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
 	tenancyv1alpha1client "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/typed/tenancy/v1alpha1"
@@ -102,6 +106,11 @@ func NewController(
 		DeleteFunc: func(obj interface{}) { c.enqueueShard(obj) },
 	}))
 
+	_, _ = globalWorkspaceTypeInformer.Informer().AddEventHandler(events.WithoutSyncs(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { c.enqueueWorkspaceType(obj) },
+		UpdateFunc: func(_, obj interface{}) { c.enqueueWorkspaceType(obj) },
+	}))
+
 	return c, nil
 }
 
@@ -179,6 +188,39 @@ func (c *Controller) enqueueShard(obj interface{}) {
 	}
 }
 
+// enqueueWorkspaceType enqueues the Workspaces of the given type on this shard, such that
+// they pick up changes of the additional workspace labels.
+func (c *Controller) enqueueWorkspaceType(obj interface{}) {
+	logger := logging.WithReconciler(klog.Background(), ControllerName)
+	wt, ok := obj.(*tenancyv1alpha1.WorkspaceType)
+	if !ok {
+		utilruntime.HandleError(fmt.Errorf("obj is supposed to be a WorkspaceType, but is %T", obj))
+		return
+	}
+
+	paths := []logicalcluster.Path{logicalcluster.From(wt).Path()}
+	if path := logicalcluster.NewPath(wt.Annotations[core.LogicalClusterPathAnnotationKey]); !path.Empty() {
+		paths = append(paths, path)
+	}
+
+	for _, path := range paths {
+		workspaces, err := c.workspaceIndexer.ByIndex(byWorkspaceType, path.Join(wt.Name).String())
+		if err != nil {
+			utilruntime.HandleError(err)
+			return
+		}
+		for _, workspace := range workspaces {
+			key, err := kcpcache.MetaClusterNamespaceKeyFunc(workspace)
+			if err != nil {
+				utilruntime.HandleError(err)
+				return
+			}
+			logging.WithQueueKey(logger, key).V(3).Info("queueing Workspace because of WorkspaceType update", "workspacetype", wt.Name)
+			c.queue.Add(key)
+		}
+	}
+}
+
 func (c *Controller) Start(ctx context.Context, numThreads int) {
 	defer utilruntime.HandleCrash()
 	defer c.queue.ShutDown()
@@ -204,6 +246,10 @@ func (c *Controller) Start(ctx context.Context, numThreads int) {
 	<-ctx.Done()
 }
 
+func (c *Controller) Drain(ctx context.Context) error {
+	return shutdown.DrainQueue(ctx, c.queue)
+}
+
 func (c *Controller) startWorker(ctx context.Context) {
 	for c.processNextWorkItem(ctx) {
 	}
@@ -225,9 +271,15 @@ func (c *Controller) processNextWorkItem(ctx context.Context) bool {
 	// other workers.
 	defer c.queue.Done(key)
 
+	ctx, done, ok := limits.BeginReconcile(ctx, ControllerName)
+	if !ok {
+		return false // shutting down
+	}
+	defer done()
+
 	if requeue, err := c.process(ctx, key); err != nil {
 		utilruntime.HandleError(fmt.Errorf("%q controller failed to sync %q, err: %w", ControllerName, key, err))
-		c.queue.AddRateLimited(key)
+		limits.Requeue(ctx, c.queue, key)
 		return true
 	} else if requeue {
 		// only requeue if we didn't error, but we still want to requeue
@@ -280,7 +332,8 @@ func InstallIndexers(workspaceInformer tenancyv1alpha1informers.WorkspaceCluster
 	globalWorkspaceTypeInformer tenancyv1alpha1informers.WorkspaceTypeClusterInformer,
 ) {
 	indexers.AddIfNotPresentOrDie(workspaceInformer.Informer().GetIndexer(), cache.Indexers{
-		unschedulable: indexUnschedulable,
+		unschedulable:   indexUnschedulable,
+		byWorkspaceType: indexByWorkspaceType,
 	})
 	indexers.AddIfNotPresentOrDie(globalShardInformer.Informer().GetIndexer(), cache.Indexers{
 		byBase36Sha224Name: indexByBase36Sha224Name,
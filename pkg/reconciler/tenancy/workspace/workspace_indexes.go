@@ -20,6 +20,7 @@ import (
 	"crypto/sha256"
 	"strings"
 
+	"github.com/kcp-dev/logicalcluster/v3"
 	"github.com/martinlindhe/base36"
 
 	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
@@ -30,6 +31,7 @@ import (
 const (
 	byBase36Sha224Name = "byBase36Sha224Name"
 	unschedulable      = "unschedulable"
+	byWorkspaceType    = "byWorkspaceType"
 )
 
 func indexUnschedulable(obj interface{}) ([]string, error) {
@@ -40,6 +42,19 @@ func indexUnschedulable(obj interface{}) ([]string, error) {
 	return []string{}, nil
 }
 
+// indexByWorkspaceType indexes Workspaces by the path and name of their type.
+func indexByWorkspaceType(obj interface{}) ([]string, error) {
+	workspace := obj.(*tenancyv1alpha1.Workspace)
+	if workspace.Spec.Type.Name == "" {
+		return []string{}, nil
+	}
+	path := logicalcluster.NewPath(workspace.Spec.Type.Path)
+	if path.Empty() {
+		path = logicalcluster.From(workspace).Path()
+	}
+	return []string{path.Join(tenancyv1alpha1.ObjectName(workspace.Spec.Type.Name)).String()}, nil
+}
+
 func indexByBase36Sha224Name(obj interface{}) ([]string, error) {
 	s := obj.(*corev1alpha1.Shard)
 	return []string{ByBase36Sha224NameValue(s.Name)}, nil
@@ -99,6 +99,12 @@ func (c *Controller) reconcile(ctx context.Context, ws *tenancyv1alpha1.Workspac
 
 	reconcilers := []reconciler{
 		&metaDataReconciler{},
+		&typeLabelsReconciler{
+			getWorkspaceType: getType,
+		},
+		&typeAnnotationsReconciler{
+			getWorkspaceType: getType,
+		},
 		&deletionReconciler{
 			getLogicalCluster: func(ctx context.Context, cluster logicalcluster.Path) (*corev1alpha1.LogicalCluster, error) {
 				return c.kcpExternalClient.Cluster(cluster).CoreV1alpha1().LogicalClusters().Get(ctx, corev1alpha1.LogicalClusterName, metav1.GetOptions{})
@@ -132,6 +138,16 @@ func (c *Controller) reconcile(ctx context.Context, ws *tenancyv1alpha1.Workspac
 				c.queue.AddAfter(kcpcache.ToClusterAwareKey(logicalcluster.From(workspace).String(), "", workspace.Name), after)
 			},
 		},
+		&archiveReconciler{
+			getLogicalCluster: func(ctx context.Context, cluster logicalcluster.Path) (*corev1alpha1.LogicalCluster, error) {
+				return c.kcpExternalClient.Cluster(cluster).CoreV1alpha1().LogicalClusters().Get(ctx, corev1alpha1.LogicalClusterName, metav1.GetOptions{})
+			},
+			updateLogicalCluster: func(ctx context.Context, cluster logicalcluster.Path, logicalCluster *corev1alpha1.LogicalCluster) error {
+				_, err := c.kcpExternalClient.Cluster(cluster).CoreV1alpha1().LogicalClusters().Update(ctx, logicalCluster, metav1.UpdateOptions{})
+				return err
+			},
+		},
+		&summaryReconciler{},
 	}
 
 	var errs []error
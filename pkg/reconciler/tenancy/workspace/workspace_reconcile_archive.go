@@ -0,0 +1,75 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspace
+
+import (
+	"context"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+)
+
+// archiveReconciler propagates the archived annotation of a ready workspace to its
+// LogicalCluster, where admission makes the workspace content read-only. Workspaces without
+// the annotation are skipped, such that the LogicalCluster, which might live on another shard,
+// is only fetched for archived or unarchived workspaces.
+type archiveReconciler struct {
+	getLogicalCluster    func(ctx context.Context, cluster logicalcluster.Path) (*corev1alpha1.LogicalCluster, error)
+	updateLogicalCluster func(ctx context.Context, cluster logicalcluster.Path, logicalCluster *corev1alpha1.LogicalCluster) error
+}
+
+func (r *archiveReconciler) reconcile(ctx context.Context, workspace *tenancyv1alpha1.Workspace) (reconcileStatus, error) {
+	if workspace.Status.Phase != corev1alpha1.LogicalClusterPhaseReady || !workspace.DeletionTimestamp.IsZero() {
+		return reconcileStatusContinue, nil
+	}
+	expected, ok := workspace.Annotations[tenancyv1alpha1.ExperimentalWorkspaceArchivedAnnotationKey]
+	if !ok {
+		return reconcileStatusContinue, nil
+	}
+
+	logger := klog.FromContext(ctx).WithValues("reconciler", "archive", "cluster", workspace.Spec.Cluster)
+
+	clusterPath := logicalcluster.NewPath(workspace.Spec.Cluster)
+	logicalCluster, err := r.getLogicalCluster(ctx, clusterPath)
+	if apierrors.IsNotFound(err) {
+		return reconcileStatusContinue, nil
+	} else if err != nil {
+		return reconcileStatusStopAndRequeue, err
+	}
+
+	if got, ok := logicalCluster.Annotations[tenancyv1alpha1.ExperimentalWorkspaceArchivedAnnotationKey]; ok && got == expected {
+		return reconcileStatusContinue, nil
+	}
+
+	logicalCluster = logicalCluster.DeepCopy()
+	if logicalCluster.Annotations == nil {
+		logicalCluster.Annotations = map[string]string{}
+	}
+	logicalCluster.Annotations[tenancyv1alpha1.ExperimentalWorkspaceArchivedAnnotationKey] = expected
+
+	logger.Info("updating archived annotation of LogicalCluster", "archived", expected)
+	if err := r.updateLogicalCluster(ctx, clusterPath, logicalCluster); err != nil {
+		return reconcileStatusStopAndRequeue, err
+	}
+
+	return reconcileStatusContinue, nil
+}
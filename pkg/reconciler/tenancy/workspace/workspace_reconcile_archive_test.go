@@ -0,0 +1,107 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspace
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+)
+
+func TestArchiveReconciler(t *testing.T) {
+	tests := map[string]struct {
+		phase                 corev1alpha1.LogicalClusterPhaseType
+		workspaceAnnotations  map[string]string
+		clusterAnnotations    map[string]string
+		wantGet               bool
+		wantClusterAnnotation *string
+	}{
+		"not archived, no lookup": {
+			phase: corev1alpha1.LogicalClusterPhaseReady,
+		},
+		"not ready, no lookup": {
+			phase:                corev1alpha1.LogicalClusterPhaseInitializing,
+			workspaceAnnotations: map[string]string{tenancyv1alpha1.ExperimentalWorkspaceArchivedAnnotationKey: "true"},
+		},
+		"archived, propagated": {
+			phase:                 corev1alpha1.LogicalClusterPhaseReady,
+			workspaceAnnotations:  map[string]string{tenancyv1alpha1.ExperimentalWorkspaceArchivedAnnotationKey: "true"},
+			wantGet:               true,
+			wantClusterAnnotation: ptr.To("true"),
+		},
+		"archived, already propagated": {
+			phase:                corev1alpha1.LogicalClusterPhaseReady,
+			workspaceAnnotations: map[string]string{tenancyv1alpha1.ExperimentalWorkspaceArchivedAnnotationKey: "true"},
+			clusterAnnotations:   map[string]string{tenancyv1alpha1.ExperimentalWorkspaceArchivedAnnotationKey: "true"},
+			wantGet:              true,
+		},
+		"unarchived, propagated": {
+			phase:                 corev1alpha1.LogicalClusterPhaseReady,
+			workspaceAnnotations:  map[string]string{tenancyv1alpha1.ExperimentalWorkspaceArchivedAnnotationKey: "false"},
+			clusterAnnotations:    map[string]string{tenancyv1alpha1.ExperimentalWorkspaceArchivedAnnotationKey: "true"},
+			wantGet:               true,
+			wantClusterAnnotation: ptr.To("false"),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			gotGet := false
+			var updated *corev1alpha1.LogicalCluster
+			r := &archiveReconciler{
+				getLogicalCluster: func(ctx context.Context, cluster logicalcluster.Path) (*corev1alpha1.LogicalCluster, error) {
+					require.Equal(t, "abc", cluster.String())
+					gotGet = true
+					return &corev1alpha1.LogicalCluster{ObjectMeta: metav1.ObjectMeta{Name: corev1alpha1.LogicalClusterName, Annotations: tc.clusterAnnotations}}, nil
+				},
+				updateLogicalCluster: func(ctx context.Context, cluster logicalcluster.Path, logicalCluster *corev1alpha1.LogicalCluster) error {
+					updated = logicalCluster
+					return nil
+				},
+			}
+
+			ws := &tenancyv1alpha1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{Name: "ws", Annotations: tc.workspaceAnnotations},
+				Spec:       tenancyv1alpha1.WorkspaceSpec{Cluster: "abc"},
+				Status:     tenancyv1alpha1.WorkspaceStatus{Phase: tc.phase},
+			}
+			status, err := r.reconcile(context.Background(), ws)
+			require.NoError(t, err)
+			require.Equal(t, reconcileStatusContinue, status)
+			require.Equal(t, tc.wantGet, gotGet)
+
+			if tc.wantClusterAnnotation == nil {
+				require.Nil(t, updated)
+				return
+			}
+			require.NotNil(t, updated)
+			require.Equal(t, *tc.wantClusterAnnotation, updated.Annotations[tenancyv1alpha1.ExperimentalWorkspaceArchivedAnnotationKey])
+		})
+	}
+}
+
+func ptrTo[T any](v T) *T {
+	return &v
+}
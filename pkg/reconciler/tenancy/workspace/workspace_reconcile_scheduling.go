@@ -41,6 +41,7 @@ import (
 	"github.com/kcp-dev/kcp/pkg/admission/workspacetypeexists"
 	"github.com/kcp-dev/kcp/pkg/authorization"
 	"github.com/kcp-dev/kcp/pkg/logging"
+	"github.com/kcp-dev/kcp/pkg/reconciler/tenancy/workspacedefaults"
 	"github.com/kcp-dev/kcp/sdk/apis/core"
 	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
 	"github.com/kcp-dev/kcp/sdk/apis/tenancy/initialization"
@@ -246,6 +247,7 @@ func (r *schedulingReconciler) createLogicalCluster(ctx context.Context, shard *
 				tenancyv1alpha1.ExperimentalWorkspaceOwnerAnnotationKey: workspace.Annotations[tenancyv1alpha1.ExperimentalWorkspaceOwnerAnnotationKey],
 				tenancyv1alpha1.LogicalClusterTypeAnnotationKey:         logicalcluster.NewPath(workspace.Spec.Type.Path).Join(string(workspace.Spec.Type.Name)).String(),
 				core.LogicalClusterPathAnnotationKey:                    canonicalPath.String(),
+				workspacedefaults.DefaultsPendingAnnotationKey:          "true",
 			},
 		},
 		Spec: corev1alpha1.LogicalClusterSpec{
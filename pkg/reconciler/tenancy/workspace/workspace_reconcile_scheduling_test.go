@@ -414,6 +414,7 @@ func wellKnownLogicalClusterForFooWS() *corev1alpha1.LogicalCluster {
 				tenancyv1alpha1.ExperimentalWorkspaceOwnerAnnotationKey: `{"username":"kcp-admin"}`,
 				tenancyv1alpha1.LogicalClusterTypeAnnotationKey:         "root:universal",
 				core.LogicalClusterPathAnnotationKey:                    "root:foo",
+				"internal.tenancy.kcp.io/workspace-defaults-pending":    "true",
 			},
 		},
 		Spec: corev1alpha1.LogicalClusterSpec{
@@ -0,0 +1,41 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspace
+
+import (
+	"context"
+
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+)
+
+// summaryReconciler aggregates the scheduling, initialization and mount conditions
+// of a workspace into a single Ready condition.
+type summaryReconciler struct{}
+
+func (r *summaryReconciler) reconcile(ctx context.Context, workspace *tenancyv1alpha1.Workspace) (reconcileStatus, error) {
+	conditions.SetSummary(
+		workspace,
+		conditions.WithConditions(
+			tenancyv1alpha1.WorkspaceScheduled,
+			tenancyv1alpha1.WorkspaceInitialized,
+			tenancyv1alpha1.MountConditionReady,
+		),
+	)
+
+	return reconcileStatusContinue, nil
+}
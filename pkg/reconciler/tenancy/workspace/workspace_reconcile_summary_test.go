@@ -0,0 +1,89 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspace
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+)
+
+func TestReconcileSummary(t *testing.T) {
+	for _, testCase := range []struct {
+		name       string
+		conditions conditionsv1alpha1.Conditions
+		wantReady  corev1.ConditionStatus
+		wantReason string
+	}{
+		{
+			name: "no conditions yet",
+		},
+		{
+			name: "scheduled and initialized",
+			conditions: conditionsv1alpha1.Conditions{
+				*conditions.TrueCondition(tenancyv1alpha1.WorkspaceScheduled),
+				*conditions.TrueCondition(tenancyv1alpha1.WorkspaceInitialized),
+			},
+			wantReady: corev1.ConditionTrue,
+		},
+		{
+			name: "initializers remaining",
+			conditions: conditionsv1alpha1.Conditions{
+				*conditions.TrueCondition(tenancyv1alpha1.WorkspaceScheduled),
+				*conditions.FalseCondition(tenancyv1alpha1.WorkspaceInitialized, tenancyv1alpha1.WorkspaceInitializedInitializerExists, conditionsv1alpha1.ConditionSeverityInfo, "Initializers still exist"),
+			},
+			wantReady:  corev1.ConditionFalse,
+			wantReason: tenancyv1alpha1.WorkspaceInitializedInitializerExists,
+		},
+		{
+			name: "mount not ready",
+			conditions: conditionsv1alpha1.Conditions{
+				*conditions.TrueCondition(tenancyv1alpha1.WorkspaceScheduled),
+				*conditions.TrueCondition(tenancyv1alpha1.WorkspaceInitialized),
+				*conditions.FalseCondition(tenancyv1alpha1.MountConditionReady, tenancyv1alpha1.MountAnnotationInvalidReason, conditionsv1alpha1.ConditionSeverityError, "Annotation is invalid"),
+			},
+			wantReady:  corev1.ConditionFalse,
+			wantReason: tenancyv1alpha1.MountAnnotationInvalidReason,
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			workspace := &tenancyv1alpha1.Workspace{
+				Status: tenancyv1alpha1.WorkspaceStatus{Conditions: testCase.conditions},
+			}
+
+			status, err := (&summaryReconciler{}).reconcile(context.Background(), workspace)
+			require.NoError(t, err)
+			require.Equal(t, reconcileStatusContinue, status)
+
+			ready := conditions.Get(workspace, conditionsv1alpha1.ReadyCondition)
+			if testCase.wantReady == "" {
+				require.Nil(t, ready)
+				return
+			}
+			require.NotNil(t, ready)
+			require.Equal(t, testCase.wantReady, ready.Status)
+			require.Equal(t, testCase.wantReason, ready.Reason)
+		})
+	}
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspace
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+)
+
+func TestReconcileTypeAnnotations(t *testing.T) {
+	wt := &tenancyv1alpha1.WorkspaceType{
+		ObjectMeta: metav1.ObjectMeta{Name: "team"},
+		Spec: tenancyv1alpha1.WorkspaceTypeSpec{
+			AdditionalWorkspaceAnnotations: map[string]string{"example.io/owner": "team-a"},
+		},
+	}
+
+	for _, testCase := range []struct {
+		name            string
+		annotations     map[string]string
+		typeFound       bool
+		wantAnnotations map[string]string
+		wantStatus      reconcileStatus
+	}{
+		{
+			name:            "annotation present",
+			annotations:     map[string]string{"example.io/owner": "team-a", "other": "x"},
+			typeFound:       true,
+			wantAnnotations: map[string]string{"example.io/owner": "team-a", "other": "x"},
+			wantStatus:      reconcileStatusContinue,
+		},
+		{
+			name:            "annotation removed",
+			annotations:     map[string]string{"other": "x"},
+			typeFound:       true,
+			wantAnnotations: map[string]string{"example.io/owner": "team-a", "other": "x"},
+			wantStatus:      reconcileStatusStopAndRequeue,
+		},
+		{
+			name:            "annotation changed",
+			annotations:     map[string]string{"example.io/owner": "team-b"},
+			typeFound:       true,
+			wantAnnotations: map[string]string{"example.io/owner": "team-a"},
+			wantStatus:      reconcileStatusStopAndRequeue,
+		},
+		{
+			name:            "type not found",
+			annotations:     map[string]string{"other": "x"},
+			wantAnnotations: map[string]string{"other": "x"},
+			wantStatus:      reconcileStatusContinue,
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			r := &typeAnnotationsReconciler{
+				getWorkspaceType: func(path logicalcluster.Path, name string) (*tenancyv1alpha1.WorkspaceType, error) {
+					require.Equal(t, "root:org", path.String())
+					require.Equal(t, "team", name)
+					if !testCase.typeFound {
+						return nil, apierrors.NewNotFound(tenancyv1alpha1.Resource("workspacetypes"), name)
+					}
+					return wt, nil
+				},
+			}
+			ws := &tenancyv1alpha1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "foo",
+					Annotations: testCase.annotations,
+				},
+				Spec: tenancyv1alpha1.WorkspaceSpec{
+					Type: tenancyv1alpha1.WorkspaceTypeReference{Name: "team", Path: "root:org"},
+				},
+			}
+
+			status, err := r.reconcile(context.Background(), ws)
+			require.NoError(t, err)
+			require.Equal(t, testCase.wantStatus, status)
+			require.Equal(t, testCase.wantAnnotations, ws.Annotations)
+		})
+	}
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspace
+
+import (
+	"context"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
+
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+)
+
+// typeLabelsReconciler keeps the additional workspace labels of the workspace type on the
+// workspace. Admission only adds them on creation.
+type typeLabelsReconciler struct {
+	getWorkspaceType func(path logicalcluster.Path, name string) (*tenancyv1alpha1.WorkspaceType, error)
+}
+
+func (r *typeLabelsReconciler) reconcile(ctx context.Context, workspace *tenancyv1alpha1.Workspace) (reconcileStatus, error) {
+	logger := klog.FromContext(ctx).WithValues("reconciler", "typelabels")
+
+	if !workspace.DeletionTimestamp.IsZero() || workspace.Spec.Type.Name == "" {
+		return reconcileStatusContinue, nil
+	}
+
+	typePath := logicalcluster.NewPath(workspace.Spec.Type.Path)
+	if typePath.Empty() {
+		typePath = logicalcluster.From(workspace).Path()
+	}
+	wt, err := r.getWorkspaceType(typePath, tenancyv1alpha1.ObjectName(workspace.Spec.Type.Name))
+	if apierrors.IsNotFound(err) {
+		// the type might not be replicated yet, or got deleted. Nothing to keep in sync then.
+		return reconcileStatusContinue, nil
+	} else if err != nil {
+		return reconcileStatusContinue, err
+	}
+
+	changed := false
+	for key, value := range wt.Spec.AdditionalWorkspaceLabels {
+		if got, found := workspace.Labels[key]; found && got == value {
+			continue
+		}
+		if workspace.Labels == nil {
+			workspace.Labels = map[string]string{}
+		}
+		logger.V(2).Info("restoring label of workspace type", "key", key, "value", value)
+		workspace.Labels[key] = value
+		changed = true
+	}
+
+	if changed {
+		// first update ObjectMeta before status
+		return reconcileStatusStopAndRequeue, nil
+	}
+
+	return reconcileStatusContinue, nil
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspacedefaults
+
+import (
+	"fmt"
+	"os"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/yaml"
+)
+
+var (
+	networkPolicyGVK = schema.GroupVersionKind{Group: "networking.k8s.io", Version: "v1", Kind: "NetworkPolicy"}
+	limitRangeGVK    = schema.GroupVersionKind{Version: "v1", Kind: "LimitRange"}
+
+	// NetworkPolicyResource is the resource of the defaults returned by NetworkPolicy.
+	NetworkPolicyResource = networkPolicyGVK.GroupVersion().WithResource("networkpolicies")
+	// LimitRangeResource is the resource of the defaults returned by LimitRange.
+	LimitRangeResource = limitRangeGVK.GroupVersion().WithResource("limitranges")
+)
+
+// NetworkPolicy returns the default NetworkPolicy for the given battery. It is read from the given
+// file, or denies all ingress and egress traffic in the default namespace if file is empty.
+func NetworkPolicy(battery, file string) (Default, error) {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"podSelector": map[string]interface{}{},
+			"policyTypes": []interface{}{"Ingress", "Egress"},
+		},
+	}}
+	obj.SetGroupVersionKind(networkPolicyGVK)
+	obj.SetNamespace(metav1.NamespaceDefault)
+	obj.SetName("default-deny-all")
+
+	if file != "" {
+		var err error
+		if obj, err = readObject(file, networkPolicyGVK); err != nil {
+			return Default{}, err
+		}
+	}
+
+	return Default{
+		Battery:  battery,
+		Resource: NetworkPolicyResource,
+		Object:   obj,
+	}, nil
+}
+
+// LimitRange returns the default LimitRange for the given battery. It is read from the given file,
+// or sets default container requests and limits in the default namespace if file is empty.
+func LimitRange(battery, file string) (Default, error) {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"limits": []interface{}{
+				map[string]interface{}{
+					"type": "Container",
+					"defaultRequest": map[string]interface{}{
+						"cpu":    "100m",
+						"memory": "128Mi",
+					},
+					"default": map[string]interface{}{
+						"cpu":    "500m",
+						"memory": "512Mi",
+					},
+				},
+			},
+		},
+	}}
+	obj.SetGroupVersionKind(limitRangeGVK)
+	obj.SetNamespace(metav1.NamespaceDefault)
+	obj.SetName("default-limits")
+
+	if file != "" {
+		var err error
+		if obj, err = readObject(file, limitRangeGVK); err != nil {
+			return Default{}, err
+		}
+	}
+
+	return Default{
+		Battery:  battery,
+		Resource: LimitRangeResource,
+		Object:   obj,
+	}, nil
+}
+
+// readObject reads a single object of the given kind from a YAML or JSON file. The namespace
+// defaults to "default".
+func readObject(file string, gvk schema.GroupVersionKind) (*unstructured.Unstructured, error) {
+	bs, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	obj := &unstructured.Unstructured{}
+	if err := yaml.Unmarshal(bs, &obj.Object); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", file, err)
+	}
+	if obj.GroupVersionKind() != gvk {
+		return nil, fmt.Errorf("%s must contain a %s, got %s", file, gvk, obj.GroupVersionKind())
+	}
+	if obj.GetName() == "" {
+		return nil, fmt.Errorf("%s must contain an object with a name", file)
+	}
+	if obj.GetNamespace() == "" {
+		obj.SetNamespace(metav1.NamespaceDefault)
+	}
+
+	return obj, nil
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspacedefaults
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetworkPolicy(t *testing.T) {
+	tests := map[string]struct {
+		file string
+
+		wantName      string
+		wantNamespace string
+		wantErr       bool
+	}{
+		"built-in": {
+			wantName:      "default-deny-all",
+			wantNamespace: "default",
+		},
+		"from file": {
+			file: `apiVersion: networking.k8s.io/v1
+kind: NetworkPolicy
+metadata:
+  name: allow-same-namespace
+spec:
+  podSelector: {}
+  ingress:
+  - from:
+    - podSelector: {}
+`,
+			wantName:      "allow-same-namespace",
+			wantNamespace: "default",
+		},
+		"wrong kind": {
+			file: `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: foo
+`,
+			wantErr: true,
+		},
+		"no name": {
+			file: `apiVersion: networking.k8s.io/v1
+kind: NetworkPolicy
+spec: {}
+`,
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var file string
+			if tc.file != "" {
+				file = filepath.Join(t.TempDir(), "policy.yaml")
+				require.NoError(t, os.WriteFile(file, []byte(tc.file), 0600))
+			}
+
+			d, err := NetworkPolicy("default-network-policy", file)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "networkpolicies", d.Resource.Resource)
+			require.Equal(t, tc.wantName, d.Object.GetName())
+			require.Equal(t, tc.wantNamespace, d.Object.GetNamespace())
+		})
+	}
+}
+
+func TestLimitRange(t *testing.T) {
+	tests := map[string]struct {
+		file string
+
+		wantName      string
+		wantNamespace string
+		wantErr       bool
+	}{
+		"built-in": {
+			wantName:      "default-limits",
+			wantNamespace: "default",
+		},
+		"from file": {
+			file: `apiVersion: v1
+kind: LimitRange
+metadata:
+  name: small
+  namespace: apps
+spec:
+  limits:
+  - type: Container
+    max:
+      cpu: "1"
+`,
+			wantName:      "small",
+			wantNamespace: "apps",
+		},
+		"wrong kind": {
+			file: `apiVersion: networking.k8s.io/v1
+kind: NetworkPolicy
+metadata:
+  name: foo
+`,
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var file string
+			if tc.file != "" {
+				file = filepath.Join(t.TempDir(), "limitrange.yaml")
+				require.NoError(t, os.WriteFile(file, []byte(tc.file), 0600))
+			}
+
+			d, err := LimitRange("default-limit-range", file)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "limitranges", d.Resource.Resource)
+			require.Equal(t, tc.wantName, d.Object.GetName())
+			require.Equal(t, tc.wantNamespace, d.Object.GetNamespace())
+		})
+	}
+}
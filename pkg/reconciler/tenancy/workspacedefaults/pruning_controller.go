@@ -0,0 +1,245 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspacedefaults
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	kcpcache "github.com/kcp-dev/apimachinery/v2/pkg/cache"
+	kcpdynamic "github.com/kcp-dev/client-go/dynamic"
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/selection"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+
+	"github.com/kcp-dev/kcp/pkg/logging"
+	"github.com/kcp-dev/kcp/pkg/reconciler/limits"
+	"github.com/kcp-dev/kcp/pkg/reconciler/shutdown"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	corev1alpha1informers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/core/v1alpha1"
+)
+
+const PruningControllerName = "kcp-workspacedefaults-pruning"
+
+// NewPruningController returns a new controller that deletes the defaults of the given disabled
+// batteries from all ready logical clusters. The map holds the resources of the defaults per
+// battery.
+func NewPruningController(
+	disabled map[string][]schema.GroupVersionResource,
+	dynamicClusterClient kcpdynamic.ClusterInterface,
+	logicalClusterInformer corev1alpha1informers.LogicalClusterClusterInformer,
+) (*pruningController, error) {
+	c := &pruningController{
+		queue: workqueue.NewTypedRateLimitingQueueWithConfig(
+			workqueue.DefaultTypedControllerRateLimiter[string](),
+			workqueue.TypedRateLimitingQueueConfig[string]{
+				Name: PruningControllerName,
+			},
+		),
+		disabled: disabled,
+
+		getLogicalCluster: func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error) {
+			return logicalClusterInformer.Lister().Cluster(clusterName).Get(corev1alpha1.LogicalClusterName)
+		},
+		listObjects: func(ctx context.Context, clusterName logicalcluster.Name, gvr schema.GroupVersionResource, selector labels.Selector) (*unstructured.UnstructuredList, error) {
+			return dynamicClusterClient.Cluster(clusterName.Path()).Resource(gvr).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+		},
+		deleteObject: func(ctx context.Context, clusterName logicalcluster.Name, gvr schema.GroupVersionResource, namespace, name string) error {
+			return dynamicClusterClient.Cluster(clusterName.Path()).Resource(gvr).Namespace(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+		},
+	}
+
+	logger := logging.WithReconciler(klog.Background(), PruningControllerName)
+
+	// Unlike the creating controller, all ready logical clusters are visited on startup, such
+	// that defaults created before the battery was disabled are found.
+	_, _ = logicalClusterInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if lc, ok := obj.(*corev1alpha1.LogicalCluster); ok && lc.Status.Phase == corev1alpha1.LogicalClusterPhaseReady {
+				c.enqueue(lc, logger)
+			}
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldLC, ok := oldObj.(*corev1alpha1.LogicalCluster)
+			if !ok {
+				return
+			}
+			newLC, ok := newObj.(*corev1alpha1.LogicalCluster)
+			if !ok {
+				return
+			}
+			if oldLC.Status.Phase != corev1alpha1.LogicalClusterPhaseReady && newLC.Status.Phase == corev1alpha1.LogicalClusterPhaseReady {
+				c.enqueue(newLC, logger)
+			}
+		},
+	})
+
+	return c, nil
+}
+
+// pruningController deletes the objects created by the workspace defaults controller for
+// batteries which are not included anymore. Objects are found by their battery label, hence
+// objects created by the owner of a workspace are not touched.
+type pruningController struct {
+	queue workqueue.TypedRateLimitingInterface[string]
+
+	disabled map[string][]schema.GroupVersionResource
+
+	getLogicalCluster func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error)
+	listObjects       func(ctx context.Context, clusterName logicalcluster.Name, gvr schema.GroupVersionResource, selector labels.Selector) (*unstructured.UnstructuredList, error)
+	deleteObject      func(ctx context.Context, clusterName logicalcluster.Name, gvr schema.GroupVersionResource, namespace, name string) error
+}
+
+func (c *pruningController) enqueue(logicalCluster *corev1alpha1.LogicalCluster, logger klog.Logger) {
+	key, err := kcpcache.MetaClusterNamespaceKeyFunc(logicalCluster)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+
+	logging.WithQueueKey(logger, key).V(4).Info("queueing LogicalCluster")
+	c.queue.Add(key)
+}
+
+// Start starts the controller, which stops when ctx.Done() is closed.
+func (c *pruningController) Start(ctx context.Context, numThreads int) {
+	defer utilruntime.HandleCrash()
+	defer c.queue.ShutDown()
+
+	logger := logging.WithReconciler(klog.FromContext(ctx), PruningControllerName)
+	ctx = klog.NewContext(ctx, logger)
+	logger.Info("Starting controller")
+	defer logger.Info("Shutting down controller")
+
+	for i := 0; i < numThreads; i++ {
+		go wait.UntilWithContext(ctx, c.startWorker, time.Second)
+	}
+
+	<-ctx.Done()
+}
+
+func (c *pruningController) Drain(ctx context.Context) error {
+	return shutdown.DrainQueue(ctx, c.queue)
+}
+
+func (c *pruningController) startWorker(ctx context.Context) {
+	for c.processNextWorkItem(ctx) {
+	}
+}
+
+func (c *pruningController) processNextWorkItem(ctx context.Context) bool {
+	// Wait until there is a new item in the working queue
+	k, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	key := k
+
+	logger := logging.WithQueueKey(klog.FromContext(ctx), key)
+	ctx = klog.NewContext(ctx, logger)
+	logger.V(4).Info("processing key")
+
+	// No matter what, tell the queue we're done with this key, to unblock
+	// other workers.
+	defer c.queue.Done(key)
+
+	ctx, done, ok := limits.BeginReconcile(ctx, PruningControllerName)
+	if !ok {
+		return false // shutting down
+	}
+	defer done()
+
+	if err := c.process(ctx, key); err != nil {
+		utilruntime.HandleError(fmt.Errorf("%q controller failed to sync %q, err: %w", PruningControllerName, key, err))
+		limits.Requeue(ctx, c.queue, key)
+		return true
+	}
+	c.queue.Forget(key)
+	return true
+}
+
+func (c *pruningController) process(ctx context.Context, key string) error {
+	clusterName, _, _, err := kcpcache.SplitMetaClusterNamespaceKey(key)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return nil
+	}
+
+	logicalCluster, err := c.getLogicalCluster(clusterName)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil // object deleted before we handled it
+		}
+		return err
+	}
+	if !logicalCluster.DeletionTimestamp.IsZero() {
+		return nil
+	}
+
+	logger := logging.WithObject(klog.FromContext(ctx), logicalCluster)
+
+	// group the disabled batteries by resource to list every resource once
+	batteriesByResource := map[schema.GroupVersionResource]sets.Set[string]{}
+	for battery, resources := range c.disabled {
+		for _, gvr := range resources {
+			if batteriesByResource[gvr] == nil {
+				batteriesByResource[gvr] = sets.New[string]()
+			}
+			batteriesByResource[gvr].Insert(battery)
+		}
+	}
+
+	var errs []error
+	for gvr, batteries := range batteriesByResource {
+		req, err := labels.NewRequirement(BatteryLabelKey, selection.In, sets.List(batteries))
+		if err != nil {
+			return err
+		}
+		objs, err := c.listObjects(ctx, clusterName, gvr, labels.NewSelector().Add(*req))
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue // API not available in workspace
+			}
+			errs = append(errs, err)
+			continue
+		}
+
+		for _, obj := range objs.Items {
+			logger := logger.WithValues("resource", gvr.String(), "namespace", obj.GetNamespace(), "name", obj.GetName(), "battery", obj.GetLabels()[BatteryLabelKey])
+			if err := c.deleteObject(ctx, clusterName, gvr, obj.GetNamespace(), obj.GetName()); err != nil && !apierrors.IsNotFound(err) {
+				errs = append(errs, err)
+				continue
+			}
+			logger.V(2).Info("deleted workspace default of disabled battery")
+		}
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
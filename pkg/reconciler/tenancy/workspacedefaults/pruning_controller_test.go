@@ -0,0 +1,110 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspacedefaults
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+)
+
+func TestPruningControllerProcess(t *testing.T) {
+	newObject := func(name, battery string) unstructured.Unstructured {
+		obj := unstructured.Unstructured{}
+		obj.SetNamespace("default")
+		obj.SetName(name)
+		if battery != "" {
+			obj.SetLabels(map[string]string{BatteryLabelKey: battery})
+		}
+		return obj
+	}
+	existing := map[schema.GroupVersionResource][]unstructured.Unstructured{
+		NetworkPolicyResource: {
+			newObject("default-deny-all", "default-network-policy"),
+			newObject("allow-same-namespace", ""),
+		},
+		LimitRangeResource: {
+			newObject("default-limits", "default-limit-range"),
+		},
+	}
+
+	tests := map[string]struct {
+		disabled    map[string][]schema.GroupVersionResource
+		terminating bool
+
+		wantDeleted []string
+	}{
+		"network policy battery disabled": {
+			disabled:    map[string][]schema.GroupVersionResource{"default-network-policy": {NetworkPolicyResource}},
+			wantDeleted: []string{"networkpolicies/default-deny-all"},
+		},
+		"both batteries disabled": {
+			disabled: map[string][]schema.GroupVersionResource{
+				"default-network-policy": {NetworkPolicyResource},
+				"default-limit-range":    {LimitRangeResource},
+			},
+			wantDeleted: []string{"limitranges/default-limits", "networkpolicies/default-deny-all"},
+		},
+		"logical cluster terminating": {
+			disabled:    map[string][]schema.GroupVersionResource{"default-network-policy": {NetworkPolicyResource}},
+			terminating: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var deleted []string
+			c := &pruningController{
+				disabled: tc.disabled,
+				getLogicalCluster: func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error) {
+					lc := &corev1alpha1.LogicalCluster{ObjectMeta: metav1.ObjectMeta{Name: corev1alpha1.LogicalClusterName}}
+					if tc.terminating {
+						now := metav1.Now()
+						lc.DeletionTimestamp = &now
+					}
+					return lc, nil
+				},
+				listObjects: func(ctx context.Context, clusterName logicalcluster.Name, gvr schema.GroupVersionResource, selector labels.Selector) (*unstructured.UnstructuredList, error) {
+					list := &unstructured.UnstructuredList{}
+					for _, obj := range existing[gvr] {
+						if selector.Matches(labels.Set(obj.GetLabels())) {
+							list.Items = append(list.Items, obj)
+						}
+					}
+					return list, nil
+				},
+				deleteObject: func(ctx context.Context, clusterName logicalcluster.Name, gvr schema.GroupVersionResource, namespace, name string) error {
+					deleted = append(deleted, gvr.Resource+"/"+name)
+					return nil
+				},
+			}
+
+			err := c.process(context.Background(), "root:org|cluster")
+			require.NoError(t, err)
+			require.ElementsMatch(t, tc.wantDeleted, deleted)
+		})
+	}
+}
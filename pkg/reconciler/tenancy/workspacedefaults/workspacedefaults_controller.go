@@ -0,0 +1,266 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspacedefaults
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	kcpcache "github.com/kcp-dev/apimachinery/v2/pkg/cache"
+	kcpdynamic "github.com/kcp-dev/client-go/dynamic"
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+
+	"github.com/kcp-dev/kcp/pkg/logging"
+	"github.com/kcp-dev/kcp/pkg/reconciler/limits"
+	"github.com/kcp-dev/kcp/pkg/reconciler/shutdown"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	corev1alpha1informers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions/core/v1alpha1"
+)
+
+const (
+	ControllerName = "kcp-workspacedefaults"
+
+	// BatteryLabelKey is set on every object created by this controller, with the battery
+	// the object belongs to as value.
+	BatteryLabelKey = "bootstrap.kcp.io/battery"
+
+	// DefaultsPendingAnnotationKey is set on the LogicalCluster of a new workspace when it is
+	// scheduled, and removed once its defaults have been created. Logical clusters without it,
+	// e.g. those existing before the battery was included, never get defaults. Defaults deleted
+	// afterwards by the owner are not created again.
+	DefaultsPendingAnnotationKey = "internal.tenancy.kcp.io/workspace-defaults-pending"
+)
+
+// Default is an object that is created in every newly initialized workspace if its battery is included.
+type Default struct {
+	Battery  string
+	Resource schema.GroupVersionResource
+	Object   *unstructured.Unstructured
+}
+
+// NewController returns a new controller that creates the given defaults in the logical clusters
+// of new workspaces once they are ready.
+func NewController(
+	defaults []Default,
+	kcpClusterClient kcpclientset.ClusterInterface,
+	dynamicClusterClient kcpdynamic.ClusterInterface,
+	logicalClusterInformer corev1alpha1informers.LogicalClusterClusterInformer,
+) (*controller, error) {
+	c := &controller{
+		queue: workqueue.NewTypedRateLimitingQueueWithConfig(
+			workqueue.DefaultTypedControllerRateLimiter[string](),
+			workqueue.TypedRateLimitingQueueConfig[string]{
+				Name: ControllerName,
+			},
+		),
+		defaults: defaults,
+
+		getLogicalCluster: func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error) {
+			return logicalClusterInformer.Lister().Cluster(clusterName).Get(corev1alpha1.LogicalClusterName)
+		},
+		createObject: func(ctx context.Context, clusterName logicalcluster.Name, gvr schema.GroupVersionResource, obj *unstructured.Unstructured) error {
+			_, err := dynamicClusterClient.Cluster(clusterName.Path()).Resource(gvr).Namespace(obj.GetNamespace()).Create(ctx, obj, metav1.CreateOptions{})
+			return err
+		},
+		patchLogicalCluster: func(ctx context.Context, clusterName logicalcluster.Name, patch []byte) error {
+			_, err := kcpClusterClient.Cluster(clusterName.Path()).CoreV1alpha1().LogicalClusters().Patch(ctx, corev1alpha1.LogicalClusterName, types.MergePatchType, patch, metav1.PatchOptions{})
+			return err
+		},
+	}
+
+	logger := logging.WithReconciler(klog.Background(), ControllerName)
+
+	// Ready logical clusters with the pending marker are enqueued on add, on update and on
+	// resync, such that logical clusters becoming ready while the controller was not running
+	// get their defaults too.
+	_, _ = logicalClusterInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: func(obj interface{}) bool {
+			lc, ok := obj.(*corev1alpha1.LogicalCluster)
+			return ok && needsDefaults(lc)
+		},
+		Handler: cache.ResourceEventHandlerFuncs{
+			AddFunc:    func(obj interface{}) { c.enqueue(obj.(*corev1alpha1.LogicalCluster), logger) },
+			UpdateFunc: func(_, obj interface{}) { c.enqueue(obj.(*corev1alpha1.LogicalCluster), logger) },
+		},
+	})
+
+	return c, nil
+}
+
+// controller creates battery-gated default objects, e.g. a deny-all NetworkPolicy, once in
+// every ready workspace marked with DefaultsPendingAnnotationKey, and removes the marker
+// afterwards. Existing objects are not touched. If the API of a default is not available in a workspace, the
+// default is skipped.
+type controller struct {
+	queue workqueue.TypedRateLimitingInterface[string]
+
+	defaults []Default
+
+	getLogicalCluster   func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error)
+	createObject        func(ctx context.Context, clusterName logicalcluster.Name, gvr schema.GroupVersionResource, obj *unstructured.Unstructured) error
+	patchLogicalCluster func(ctx context.Context, clusterName logicalcluster.Name, patch []byte) error
+}
+
+// needsDefaults returns true if the logical cluster is ready and its defaults are pending.
+func needsDefaults(logicalCluster *corev1alpha1.LogicalCluster) bool {
+	if logicalCluster.Status.Phase != corev1alpha1.LogicalClusterPhaseReady || !logicalCluster.DeletionTimestamp.IsZero() {
+		return false
+	}
+	_, pending := logicalCluster.Annotations[DefaultsPendingAnnotationKey]
+	return pending
+}
+
+func (c *controller) enqueue(logicalCluster *corev1alpha1.LogicalCluster, logger klog.Logger) {
+	key, err := kcpcache.MetaClusterNamespaceKeyFunc(logicalCluster)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+
+	logging.WithQueueKey(logger, key).V(4).Info("queueing LogicalCluster")
+	c.queue.Add(key)
+}
+
+// Start starts the controller, which stops when ctx.Done() is closed.
+func (c *controller) Start(ctx context.Context, numThreads int) {
+	defer utilruntime.HandleCrash()
+	defer c.queue.ShutDown()
+
+	logger := logging.WithReconciler(klog.FromContext(ctx), ControllerName)
+	ctx = klog.NewContext(ctx, logger)
+	logger.Info("Starting controller")
+	defer logger.Info("Shutting down controller")
+
+	for i := 0; i < numThreads; i++ {
+		go wait.UntilWithContext(ctx, c.startWorker, time.Second)
+	}
+
+	<-ctx.Done()
+}
+
+func (c *controller) Drain(ctx context.Context) error {
+	return shutdown.DrainQueue(ctx, c.queue)
+}
+
+func (c *controller) startWorker(ctx context.Context) {
+	for c.processNextWorkItem(ctx) {
+	}
+}
+
+func (c *controller) processNextWorkItem(ctx context.Context) bool {
+	// Wait until there is a new item in the working queue
+	k, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	key := k
+
+	logger := logging.WithQueueKey(klog.FromContext(ctx), key)
+	ctx = klog.NewContext(ctx, logger)
+	logger.V(4).Info("processing key")
+
+	// No matter what, tell the queue we're done with this key, to unblock
+	// other workers.
+	defer c.queue.Done(key)
+
+	ctx, done, ok := limits.BeginReconcile(ctx, ControllerName)
+	if !ok {
+		return false // shutting down
+	}
+	defer done()
+
+	if err := c.process(ctx, key); err != nil {
+		utilruntime.HandleError(fmt.Errorf("%q controller failed to sync %q, err: %w", ControllerName, key, err))
+		limits.Requeue(ctx, c.queue, key)
+		return true
+	}
+	c.queue.Forget(key)
+	return true
+}
+
+func (c *controller) process(ctx context.Context, key string) error {
+	clusterName, _, _, err := kcpcache.SplitMetaClusterNamespaceKey(key)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return nil
+	}
+
+	logicalCluster, err := c.getLogicalCluster(clusterName)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil // object deleted before we handled it
+		}
+		return err
+	}
+	if !needsDefaults(logicalCluster) {
+		return nil
+	}
+
+	logger := logging.WithObject(klog.FromContext(ctx), logicalCluster)
+
+	var errs []error
+	for _, d := range c.defaults {
+		obj := d.Object.DeepCopy()
+		labels := obj.GetLabels()
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		labels[BatteryLabelKey] = d.Battery
+		obj.SetLabels(labels)
+
+		logger := logger.WithValues("resource", d.Resource.String(), "namespace", obj.GetNamespace(), "name", obj.GetName())
+		err := c.createObject(ctx, clusterName, d.Resource, obj)
+		switch {
+		case err == nil:
+			logger.V(2).Info("created workspace default")
+		case apierrors.IsAlreadyExists(err):
+		case apierrors.IsNotFound(err):
+			logger.V(2).Info("skipping workspace default, API or namespace not available in workspace")
+		default:
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return utilerrors.NewAggregate(errs)
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{DefaultsPendingAnnotationKey: nil},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	logger.V(2).Info("marking workspace defaults as applied")
+	return c.patchLogicalCluster(ctx, clusterName, patch)
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspacedefaults
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	kcpfakeclient "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster/fake"
+	kcpinformers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions"
+)
+
+func TestControllerProcess(t *testing.T) {
+	obj := &unstructured.Unstructured{}
+	obj.SetNamespace("default")
+	obj.SetName("default-deny-all")
+	defaults := []Default{{Battery: "default-network-policy", Resource: NetworkPolicyResource, Object: obj}}
+
+	tests := map[string]struct {
+		phase       corev1alpha1.LogicalClusterPhaseType
+		pending     bool
+		createError error
+
+		wantCreated []string
+		wantPatched bool
+		wantError   bool
+	}{
+		"ready, pending": {
+			phase:       corev1alpha1.LogicalClusterPhaseReady,
+			pending:     true,
+			wantCreated: []string{"networkpolicies/default-deny-all"},
+			wantPatched: true,
+		},
+		"ready, applied or existing before the battery was included": {
+			phase: corev1alpha1.LogicalClusterPhaseReady,
+		},
+		"initializing": {
+			phase:   corev1alpha1.LogicalClusterPhaseInitializing,
+			pending: true,
+		},
+		"create fails, still pending": {
+			phase:       corev1alpha1.LogicalClusterPhaseReady,
+			pending:     true,
+			createError: errors.New("boom"),
+			wantCreated: []string{"networkpolicies/default-deny-all"},
+			wantError:   true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var created []string
+			patched := false
+			c := &controller{
+				defaults: defaults,
+				getLogicalCluster: func(clusterName logicalcluster.Name) (*corev1alpha1.LogicalCluster, error) {
+					lc := &corev1alpha1.LogicalCluster{
+						ObjectMeta: metav1.ObjectMeta{Name: corev1alpha1.LogicalClusterName},
+						Status:     corev1alpha1.LogicalClusterStatus{Phase: tc.phase},
+					}
+					if tc.pending {
+						lc.Annotations = map[string]string{DefaultsPendingAnnotationKey: "true"}
+					}
+					return lc, nil
+				},
+				createObject: func(ctx context.Context, clusterName logicalcluster.Name, gvr schema.GroupVersionResource, obj *unstructured.Unstructured) error {
+					require.Equal(t, "default-network-policy", obj.GetLabels()[BatteryLabelKey])
+					created = append(created, gvr.Resource+"/"+obj.GetName())
+					return tc.createError
+				},
+				patchLogicalCluster: func(ctx context.Context, clusterName logicalcluster.Name, patch []byte) error {
+					require.JSONEq(t, `{"metadata":{"annotations":{"internal.tenancy.kcp.io/workspace-defaults-pending":null}}}`, string(patch))
+					patched = true
+					return nil
+				},
+			}
+
+			err := c.process(context.Background(), "root:org|cluster")
+			if tc.wantError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tc.wantCreated, created)
+			require.Equal(t, tc.wantPatched, patched)
+		})
+	}
+}
+
+func TestEnqueueOnlyPendingLogicalClusters(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	newLogicalCluster := func(clusterName string, annotations map[string]string) *corev1alpha1.LogicalCluster {
+		annotations[logicalcluster.AnnotationKey] = clusterName
+		return &corev1alpha1.LogicalCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: corev1alpha1.LogicalClusterName, Annotations: annotations},
+			Status:     corev1alpha1.LogicalClusterStatus{Phase: corev1alpha1.LogicalClusterPhaseReady},
+		}
+	}
+
+	kcpClient := kcpfakeclient.NewSimpleClientset(
+		newLogicalCluster("root", map[string]string{}),
+		newLogicalCluster("existing", map[string]string{}),
+		newLogicalCluster("new", map[string]string{DefaultsPendingAnnotationKey: "true"}),
+	)
+	informers := kcpinformers.NewSharedInformerFactory(kcpClient, 0)
+	c, err := NewController(nil, kcpClient, nil, informers.Core().V1alpha1().LogicalClusters())
+	require.NoError(t, err)
+	defer c.queue.ShutDown()
+
+	informers.Start(ctx.Done())
+	informers.WaitForCacheSync(ctx.Done())
+
+	require.Eventually(t, func() bool {
+		return c.queue.Len() > 0
+	}, wait.ForeverTestTimeout, 10*time.Millisecond)
+	key, _ := c.queue.Get()
+	require.Equal(t, "new|cluster", key)
+	require.Zero(t, c.queue.Len(), "expected ready logical clusters without the pending marker to be left alone")
+}
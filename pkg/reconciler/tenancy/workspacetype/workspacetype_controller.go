@@ -19,6 +19,7 @@ package workspacetype
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	kcpcache "github.com/kcp-dev/apimachinery/v2/pkg/cache"
@@ -36,6 +37,8 @@ import (
 	"github.com/kcp-dev/kcp/pkg/logging"
 	"github.com/kcp-dev/kcp/pkg/reconciler/committer"
 	"github.com/kcp-dev/kcp/pkg/reconciler/events"
+	"github.com/kcp-dev/kcp/pkg/reconciler/limits"
+	"github.com/kcp-dev/kcp/pkg/reconciler/shutdown"
 	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
 	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
 	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
@@ -74,6 +77,7 @@ func NewController(
 			name := string(reference.Name)
 			return indexers.ByPathAndName[*tenancyv1alpha1.WorkspaceType](tenancyv1alpha1.Resource("workspacetypes"), workspaceTypeInformer.Informer().GetIndexer(), path, name)
 		},
+		probeVirtualWorkspace: probeVirtualWorkspaceURL,
 
 		commit: committer.NewCommitter[*WorkspaceType, Patcher, *WorkspaceTypeSpec, *WorkspaceTypeStatus](kcpClusterClient.TenancyV1alpha1().WorkspaceTypes()),
 	}
@@ -116,7 +120,8 @@ type Patcher = tenancyv1alpha1client.WorkspaceTypeInterface
 type Resource = committer.Resource[*WorkspaceTypeSpec, *WorkspaceTypeStatus]
 type CommitFunc = func(context.Context, *Resource, *Resource) error
 
-// controller reconciles WorkspaceTypes. It ensures a WorkspaceType has assigned a virtual workspace URL address.
+// controller reconciles WorkspaceTypes. It ensures a WorkspaceType has assigned a virtual workspace URL address,
+// and reports whether the virtual workspaces behind those URLs are reachable.
 type controller struct {
 	queue workqueue.TypedRateLimitingInterface[string]
 
@@ -124,7 +129,12 @@ type controller struct {
 	workspacetypeLister   tenancyv1alpha1listers.WorkspaceTypeClusterLister
 	listShards            func() ([]*corev1alpha1.Shard, error)
 	resolveWorkspaceTypes func(reference tenancyv1alpha1.WorkspaceTypeReference) (*tenancyv1alpha1.WorkspaceType, error)
+	probeVirtualWorkspace func(ctx context.Context, url string) error
 	commit                CommitFunc
+
+	// probeResults holds the result of the last probe by shard virtual workspace URL.
+	probeLock    sync.RWMutex
+	probeResults map[string]error
 }
 
 // enqueueWorkspaceTypes enqueues a WorkspaceType.
@@ -141,13 +151,18 @@ func (c *controller) enqueueWorkspaceTypes(obj interface{}) {
 }
 
 func (c *controller) enqueueAllWorkspaceTypes(shard interface{}) {
+	logger := logging.WithObject(logging.WithReconciler(klog.Background(), ControllerName), shard.(*corev1alpha1.Shard))
+	c.enqueueAll(logger, "Shard changed")
+}
+
+// enqueueAll enqueues all WorkspaceTypes for the given reason.
+func (c *controller) enqueueAll(logger klog.Logger, reason string) {
 	list, err := c.workspacetypeLister.List(labels.Everything())
 	if err != nil {
 		utilruntime.HandleError(err)
 		return
 	}
 
-	logger := logging.WithObject(logging.WithReconciler(klog.Background(), ControllerName), shard.(*corev1alpha1.Shard))
 	for i := range list {
 		key, err := kcpcache.MetaClusterNamespaceKeyFunc(list[i])
 		if err != nil {
@@ -155,7 +170,7 @@ func (c *controller) enqueueAllWorkspaceTypes(shard interface{}) {
 			continue
 		}
 
-		logging.WithQueueKey(logger, key).V(4).Info("queuing WorkspaceType because Shard changed")
+		logging.WithQueueKey(logger, key).V(4).Info("queuing WorkspaceType because " + reason)
 
 		c.queue.Add(key)
 	}
@@ -174,10 +189,16 @@ func (c *controller) Start(ctx context.Context, numThreads int) {
 	for i := 0; i < numThreads; i++ {
 		go wait.UntilWithContext(ctx, c.startWorker, time.Second)
 	}
+	// virtual workspaces can become unreachable without any change to watch.
+	go wait.UntilWithContext(ctx, c.probeVirtualWorkspaces, virtualWorkspaceProbeInterval)
 
 	<-ctx.Done()
 }
 
+func (c *controller) Drain(ctx context.Context) error {
+	return shutdown.DrainQueue(ctx, c.queue)
+}
+
 func (c *controller) startWorker(ctx context.Context) {
 	for c.processNextWorkItem(ctx) {
 	}
@@ -195,13 +216,19 @@ func (c *controller) processNextWorkItem(ctx context.Context) bool {
 	// other workers.
 	defer c.queue.Done(key)
 
+	ctx, done, ok := limits.BeginReconcile(ctx, ControllerName)
+	if !ok {
+		return false // shutting down
+	}
+	defer done()
+
 	logger := logging.WithQueueKey(klog.FromContext(ctx), key)
 	ctx = klog.NewContext(ctx, logger)
 	logger.V(4).Info("processing key")
 
 	if err := c.process(ctx, key); err != nil {
 		utilruntime.HandleError(fmt.Errorf("%q controller failed to sync %q, err: %w", ControllerName, key, err))
-		c.queue.AddRateLimited(key)
+		limits.Requeue(ctx, c.queue, key)
 		return true
 	}
 	c.queue.Forget(key)
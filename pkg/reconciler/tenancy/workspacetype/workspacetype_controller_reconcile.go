@@ -19,8 +19,13 @@ package workspacetype
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/url"
 	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
@@ -48,11 +53,161 @@ func (c *controller) reconcile(ctx context.Context, wt *tenancyv1alpha1.Workspac
 			wt,
 			tenancyv1alpha1.WorkspaceTypeVirtualWorkspaceURLsReady,
 		)
+		c.updateVirtualWorkspacesReachable(ctx, wt)
 	}
 
 	conditions.SetSummary(wt)
 }
 
+// updateVirtualWorkspacesReachable reflects the shards whose virtual workspace server was
+// found unreachable by the last probe in the VirtualWorkspacesReachable condition. Shards not
+// probed yet are not taken into account.
+func (c *controller) updateVirtualWorkspacesReachable(ctx context.Context, wt *tenancyv1alpha1.WorkspaceType) {
+	logger := klog.FromContext(ctx)
+	shards, err := c.listShards()
+	if err != nil {
+		// listing worked just before, and the URLs are updated already.
+		logger.Error(err, "error listing Shards")
+		return
+	}
+
+	var unreachable []string
+	for _, shard := range shards {
+		if shard.Spec.VirtualWorkspaceURL == "" {
+			continue
+		}
+		if probed, err := c.probeResult(shard.Spec.VirtualWorkspaceURL); probed && err != nil {
+			u, uErr := virtualWorkspaceURL(shard.Spec.VirtualWorkspaceURL, wt)
+			if uErr != nil {
+				continue
+			}
+			unreachable = append(unreachable, fmt.Sprintf("%s: %v", u, err))
+		}
+	}
+	sort.Strings(unreachable)
+
+	if len(unreachable) > 0 {
+		conditions.MarkFalse(
+			wt,
+			tenancyv1alpha1.WorkspaceTypeVirtualWorkspacesReachable,
+			tenancyv1alpha1.VirtualWorkspaceUnreachableReason,
+			conditionsv1alpha1.ConditionSeverityWarning,
+			"unreachable virtual workspaces: %s",
+			strings.Join(unreachable, "; "),
+		)
+		return
+	}
+
+	conditions.MarkTrue(
+		wt,
+		tenancyv1alpha1.WorkspaceTypeVirtualWorkspacesReachable,
+	)
+}
+
+// probeResult returns whether the given shard virtual workspace URL has been probed yet, and
+// the error of the last probe.
+func (c *controller) probeResult(shardURL string) (probed bool, err error) {
+	c.probeLock.RLock()
+	defer c.probeLock.RUnlock()
+	err, probed = c.probeResults[shardURL]
+	return probed, err
+}
+
+// probeVirtualWorkspaces probes the virtual workspace URL of every shard once, concurrently,
+// and caches the results for reconcile. All WorkspaceTypes are enqueued if a result changed.
+func (c *controller) probeVirtualWorkspaces(ctx context.Context) {
+	logger := klog.FromContext(ctx)
+	shards, err := c.listShards()
+	if err != nil {
+		logger.Error(err, "error listing Shards")
+		return
+	}
+
+	urls := sets.New[string]()
+	for _, shard := range shards {
+		if shard.Spec.VirtualWorkspaceURL != "" {
+			urls.Insert(shard.Spec.VirtualWorkspaceURL)
+		}
+	}
+
+	var lock sync.Mutex
+	var wg sync.WaitGroup
+	results := make(map[string]error, urls.Len())
+	for u := range urls {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := c.probeVirtualWorkspace(ctx, u)
+			lock.Lock()
+			defer lock.Unlock()
+			results[u] = err
+		}()
+	}
+	wg.Wait()
+	if ctx.Err() != nil {
+		return // shutting down, the results are meaningless
+	}
+
+	c.probeLock.Lock()
+	changed := !equalProbeResults(c.probeResults, results)
+	c.probeResults = results
+	c.probeLock.Unlock()
+
+	if changed {
+		c.enqueueAll(logger, "virtual workspace reachability changed")
+	}
+}
+
+func equalProbeResults(a, b map[string]error) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for u, errA := range a {
+		errB, ok := b[u]
+		if !ok || (errA == nil) != (errB == nil) || (errA != nil && errA.Error() != errB.Error()) {
+			return false
+		}
+	}
+	return true
+}
+
+// probeVirtualWorkspaceURL checks that the host of the given virtual workspace URL accepts
+// TCP connections. It does not speak HTTP, because the controller has neither credentials
+// for nor trust in the virtual workspace server.
+func probeVirtualWorkspaceURL(ctx context.Context, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+
+	host := u.Host
+	if u.Port() == "" {
+		port := "443"
+		if u.Scheme == "http" {
+			port = "80"
+		}
+		host = net.JoinHostPort(u.Hostname(), port)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, virtualWorkspaceProbeTimeout)
+	defer cancel()
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", host)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+const (
+	// virtualWorkspaceProbeTimeout bounds a single probe of a virtual workspace URL.
+	virtualWorkspaceProbeTimeout = 5 * time.Second
+	// virtualWorkspaceProbeInterval is the interval in which the virtual workspace URLs
+	// of the shards are probed again.
+	virtualWorkspaceProbeInterval = time.Minute
+)
+
 func (c *controller) updateVirtualWorkspaceURLs(ctx context.Context, wt *tenancyv1alpha1.WorkspaceType) error {
 	logger := klog.FromContext(ctx)
 	shards, err := c.listShards()
@@ -66,21 +221,14 @@ func (c *controller) updateVirtualWorkspaceURLs(ctx context.Context, wt *tenancy
 			continue
 		}
 
-		u, err := url.Parse(shard.Spec.VirtualWorkspaceURL)
+		u, err := virtualWorkspaceURL(shard.Spec.VirtualWorkspaceURL, wt)
 		if err != nil {
 			// Should never happen
 			logger.Error(err, "error parsing shard.spec.virtualWorkspaceURL", "virtualWorkspaceURL", shard.Spec.VirtualWorkspaceURL)
 			continue
 		}
 
-		u.Path = path.Join(
-			u.Path,
-			virtualworkspacesoptions.DefaultRootPathPrefix,
-			initializingworkspaces.VirtualWorkspaceName,
-			string(initialization.InitializerForType(wt)),
-		)
-
-		desiredURLs.Insert(u.String())
+		desiredURLs.Insert(u)
 	}
 
 	wt.Status.VirtualWorkspaces = nil
@@ -93,3 +241,20 @@ func (c *controller) updateVirtualWorkspaceURLs(ctx context.Context, wt *tenancy
 
 	return nil
 }
+
+// virtualWorkspaceURL returns the URL of the initializing virtual workspace of the given
+// WorkspaceType on the shard with the given virtual workspace URL.
+func virtualWorkspaceURL(shardURL string, wt *tenancyv1alpha1.WorkspaceType) (string, error) {
+	u, err := url.Parse(shardURL)
+	if err != nil {
+		return "", err
+	}
+
+	u.Path = path.Join(
+		u.Path,
+		virtualworkspacesoptions.DefaultRootPathPrefix,
+		initializingworkspaces.VirtualWorkspaceName,
+		string(initialization.InitializerForType(wt)),
+	)
+	return u.String(), nil
+}
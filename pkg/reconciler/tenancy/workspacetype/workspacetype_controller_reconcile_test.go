@@ -19,29 +19,36 @@ package workspacetype
 import (
 	"context"
 	"fmt"
+	"sync"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	kcpcache "github.com/kcp-dev/apimachinery/v2/pkg/cache"
 	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
 
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
 
 	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
 	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
 	conditionsv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1"
+	tenancyv1alpha1listers "github.com/kcp-dev/kcp/sdk/client/listers/tenancy/v1alpha1"
 )
 
 func TestReconcile(t *testing.T) {
 	for _, testCase := range []struct {
-		name     string
-		shards   []*corev1alpha1.Shard
-		listErr  error
-		wts      []*tenancyv1alpha1.WorkspaceType
-		getErr   error
-		wt       *tenancyv1alpha1.WorkspaceType
-		expected *tenancyv1alpha1.WorkspaceType
+		name         string
+		shards       []*corev1alpha1.Shard
+		listErr      error
+		wts          []*tenancyv1alpha1.WorkspaceType
+		getErr       error
+		probeResults map[string]error
+		wt           *tenancyv1alpha1.WorkspaceType
+		expected     *tenancyv1alpha1.WorkspaceType
 	}{
 		{
 			name: "no shards, no URLs in status",
@@ -70,6 +77,10 @@ func TestReconcile(t *testing.T) {
 							Type:   "VirtualWorkspaceURLsReady",
 							Status: "True",
 						},
+						{
+							Type:   "VirtualWorkspacesReachable",
+							Status: "True",
+						},
 					},
 				},
 			},
@@ -150,6 +161,10 @@ func TestReconcile(t *testing.T) {
 							Type:   "VirtualWorkspaceURLsReady",
 							Status: "True",
 						},
+						{
+							Type:   "VirtualWorkspacesReachable",
+							Status: "True",
+						},
 					},
 				},
 			},
@@ -206,6 +221,63 @@ func TestReconcile(t *testing.T) {
 							Type:   "VirtualWorkspaceURLsReady",
 							Status: "True",
 						},
+						{
+							Type:   "VirtualWorkspacesReachable",
+							Status: "True",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "unreachable virtual workspace in status",
+			shards: []*corev1alpha1.Shard{
+				{Spec: corev1alpha1.ShardSpec{VirtualWorkspaceURL: "https://whatever.com"}},
+				{Spec: corev1alpha1.ShardSpec{VirtualWorkspaceURL: "https://something.com"}},
+			},
+			probeResults: map[string]error{
+				"https://whatever.com":  nil,
+				"https://something.com": fmt.Errorf("connection refused"),
+			},
+			wt: &tenancyv1alpha1.WorkspaceType{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "sometype",
+					Annotations: map[string]string{
+						logicalcluster.AnnotationKey: "root:org:team:ws",
+					},
+				},
+			},
+			expected: &tenancyv1alpha1.WorkspaceType{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "sometype",
+					Annotations: map[string]string{
+						logicalcluster.AnnotationKey: "root:org:team:ws",
+					},
+				},
+				Status: tenancyv1alpha1.WorkspaceTypeStatus{
+					VirtualWorkspaces: []tenancyv1alpha1.VirtualWorkspace{
+						{URL: "https://something.com/services/initializingworkspaces/root:org:team:ws:sometype"},
+						{URL: "https://whatever.com/services/initializingworkspaces/root:org:team:ws:sometype"},
+					},
+					Conditions: conditionsv1alpha1.Conditions{
+						{
+							Type:     "Ready",
+							Status:   "False",
+							Severity: "Warning",
+							Reason:   "VirtualWorkspaceUnreachable",
+							Message:  "unreachable virtual workspaces: https://something.com/services/initializingworkspaces/root:org:team:ws:sometype: connection refused",
+						},
+						{
+							Type:   "VirtualWorkspaceURLsReady",
+							Status: "True",
+						},
+						{
+							Type:     "VirtualWorkspacesReachable",
+							Status:   "False",
+							Severity: "Warning",
+							Reason:   "VirtualWorkspaceUnreachable",
+							Message:  "unreachable virtual workspaces: https://something.com/services/initializingworkspaces/root:org:team:ws:sometype: connection refused",
+						},
 					},
 				},
 			},
@@ -228,6 +300,7 @@ func TestReconcile(t *testing.T) {
 					}
 					return nil, errors.NewNotFound(tenancyv1alpha1.Resource("workspacetype"), string(reference.Name))
 				},
+				probeResults: testCase.probeResults,
 			}
 			c.reconcile(context.TODO(), testCase.wt)
 			c.reconcile(context.TODO(), testCase.wt) // relationships require resolved extensions
@@ -237,3 +310,59 @@ func TestReconcile(t *testing.T) {
 		})
 	}
 }
+
+func TestProbeVirtualWorkspaces(t *testing.T) {
+	indexer := cache.NewIndexer(kcpcache.MetaClusterNamespaceKeyFunc, cache.Indexers{})
+	require.NoError(t, indexer.Add(&tenancyv1alpha1.WorkspaceType{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "sometype",
+			Annotations: map[string]string{
+				logicalcluster.AnnotationKey: "root:org",
+			},
+		},
+	}))
+
+	unreachable := map[string]error{"https://something.com": fmt.Errorf("connection refused")}
+	var lock sync.Mutex
+	var probed []string
+	c := &controller{
+		queue:               workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[string]()),
+		workspacetypeLister: tenancyv1alpha1listers.NewWorkspaceTypeClusterLister(indexer),
+		listShards: func() ([]*corev1alpha1.Shard, error) {
+			return []*corev1alpha1.Shard{
+				{Spec: corev1alpha1.ShardSpec{VirtualWorkspaceURL: "https://whatever.com"}},
+				{Spec: corev1alpha1.ShardSpec{VirtualWorkspaceURL: "https://something.com"}},
+				{Spec: corev1alpha1.ShardSpec{}},
+			}, nil
+		},
+	}
+	c.probeVirtualWorkspace = func(ctx context.Context, url string) error {
+		lock.Lock() // probes run concurrently
+		defer lock.Unlock()
+		probed = append(probed, url)
+		return unreachable[url]
+	}
+	defer c.queue.ShutDown()
+
+	c.probeVirtualWorkspaces(context.Background())
+	require.ElementsMatch(t, []string{"https://whatever.com", "https://something.com"}, probed)
+	require.Equal(t, 1, c.queue.Len(), "expected WorkspaceTypes to be queued after the first probe")
+	p, err := c.probeResult("https://something.com")
+	require.True(t, p)
+	require.EqualError(t, err, "connection refused")
+	p, err = c.probeResult("https://whatever.com")
+	require.True(t, p)
+	require.NoError(t, err)
+
+	key, _ := c.queue.Get()
+	c.queue.Done(key)
+	c.probeVirtualWorkspaces(context.Background())
+	require.Zero(t, c.queue.Len(), "expected no WorkspaceTypes to be queued without changes")
+
+	delete(unreachable, "https://something.com")
+	c.probeVirtualWorkspaces(context.Background())
+	require.Equal(t, 1, c.queue.Len(), "expected WorkspaceTypes to be queued after a change")
+	p, err = c.probeResult("https://something.com")
+	require.True(t, p)
+	require.NoError(t, err)
+}
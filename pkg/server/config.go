@@ -26,6 +26,8 @@ import (
 	_ "net/http/pprof"
 	"net/url"
 	"os"
+	"sync"
+	"time"
 
 	kcpapiextensionsclientset "github.com/kcp-dev/client-go/apiextensions/client"
 	kcpapiextensionsinformers "github.com/kcp-dev/client-go/apiextensions/informers"
@@ -131,6 +133,13 @@ type ExtraConfig struct {
 	DiscoveringDynamicSharedInformerFactory *informer.DiscoveringDynamicSharedInformerFactory
 	CacheKcpSharedInformerFactory           kcpinformers.SharedInformerFactory
 	CacheKubeSharedInformerFactory          kcpkubernetesinformers.SharedInformerFactory
+
+	// ControllerKcpSharedInformerFactory and ControllerApiExtensionsSharedInformerFactory feed the
+	// controllers. They list and watch from the read replica if one is configured, and are the same
+	// as KcpSharedInformerFactory and ApiExtensionsSharedInformerFactory otherwise. Admission and
+	// authorization always use the latter.
+	ControllerKcpSharedInformerFactory           kcpinformers.SharedInformerFactory
+	ControllerApiExtensionsSharedInformerFactory kcpapiextensionsinformers.SharedInformerFactory
 }
 
 type completedConfig struct {
@@ -219,21 +228,25 @@ func NewConfig(ctx context.Context, opts kcpserveroptions.CompletedOptions) (*Co
 	if err != nil {
 		return nil, err
 	}
-	cacheKcpClusterClient, err := kcpclientset.NewForConfig(cacheClientConfig)
+	// space the initial LISTs of the informers created here, to spread their load on a cold server.
+	informerStagger := newInformerListStagger(c.Options.Extra.InformerStartStagger)
+	cacheInformerConfig := rest.CopyConfig(cacheClientConfig)
+	cacheInformerConfig.Wrap(informerStagger.wrap)
+	cacheKcpClusterClient, err := kcpclientset.NewForConfig(cacheInformerConfig)
 	if err != nil {
 		return nil, err
 	}
-	cacheKubeClusterClient, err := kcpkubernetesclientset.NewForConfig(cacheClientConfig)
+	cacheKubeClusterClient, err := kcpkubernetesclientset.NewForConfig(cacheInformerConfig)
 	if err != nil {
 		return nil, err
 	}
 	c.CacheKcpSharedInformerFactory = kcpinformers.NewSharedInformerFactoryWithOptions(
 		cacheKcpClusterClient,
-		resyncPeriod,
+		c.Options.Controllers.InformerResyncPeriod,
 	)
 	c.CacheKubeSharedInformerFactory = kcpkubernetesinformers.NewSharedInformerFactoryWithOptions(
 		cacheKubeClusterClient,
-		resyncPeriod,
+		c.Options.Controllers.InformerResyncPeriod,
 	)
 	c.CacheDynamicClient, err = kcpdynamic.NewForConfig(cacheClientConfig)
 	if err != nil {
@@ -277,15 +290,24 @@ func NewConfig(ctx context.Context, opts kcpserveroptions.CompletedOptions) (*Co
 		c.RootShardKcpClusterClient = c.KcpClusterClient
 	}
 
+	var readReplicaConfig *rest.Config
+	if len(c.Options.Controllers.ReadReplicaKubeconfig) > 0 {
+		readReplicaConfig, err = clientcmd.NewNonInteractiveDeferredLoadingClientConfig(&clientcmd.ClientConfigLoadingRules{ExplicitPath: c.Options.Controllers.ReadReplicaKubeconfig}, nil).ClientConfig()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the read replica kubeconfig from %q: %w", c.Options.Controllers.ReadReplicaKubeconfig, err)
+		}
+	}
+
 	informerConfig := rest.CopyConfig(c.IdentityConfig)
 	informerConfig.UserAgent = "kcp-informers"
+	informerConfig.Wrap(informerStagger.wrap)
 	informerKcpClient, err := kcpclientset.NewForConfig(informerConfig)
 	if err != nil {
 		return nil, err
 	}
 	c.KcpSharedInformerFactory = kcpinformers.NewSharedInformerFactoryWithOptions(
 		informerKcpClient,
-		resyncPeriod,
+		c.Options.Controllers.InformerResyncPeriod,
 	)
 	c.DeepSARClient, err = kcpkubernetesclientset.NewForConfig(authorization.WithDeepSARConfig(rest.CopyConfig(c.GenericConfig.LoopbackClientConfig)))
 	if err != nil {
@@ -313,11 +335,28 @@ func NewConfig(ctx context.Context, opts kcpserveroptions.CompletedOptions) (*Co
 	if err != nil {
 		return nil, err
 	}
+	apiExtensionsInformerConfig := rest.CopyConfig(c.GenericConfig.LoopbackClientConfig)
+	apiExtensionsInformerConfig.Wrap(informerStagger.wrap)
+	apiExtensionsInformerClient, err := kcpapiextensionsclientset.NewForConfig(apiExtensionsInformerConfig)
+	if err != nil {
+		return nil, err
+	}
 	c.ApiExtensionsSharedInformerFactory = kcpapiextensionsinformers.NewSharedInformerFactoryWithOptions(
-		c.ApiExtensionsClusterClient,
-		resyncPeriod,
+		apiExtensionsInformerClient,
+		c.Options.Controllers.InformerResyncPeriod,
 	)
 
+	// Only the kcp and CRD informers of the controllers move to the read replica. The kube informers
+	// are shared with admission, authorization and the embedded kube apiserver, and the dynamic
+	// informers follow the resources served by this shard, hence both stay on the shard.
+	c.ControllerKcpSharedInformerFactory, c.ControllerApiExtensionsSharedInformerFactory = c.KcpSharedInformerFactory, c.ApiExtensionsSharedInformerFactory
+	if readReplicaConfig != nil {
+		c.ControllerKcpSharedInformerFactory, c.ControllerApiExtensionsSharedInformerFactory, err = newReadReplicaInformerFactories(informerConfig, apiExtensionsInformerConfig, readReplicaConfig, c.Options.Controllers.InformerResyncPeriod)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Setup dynamic client
 	c.DynamicClusterClient, err = kcpdynamic.NewForConfig(c.GenericConfig.LoopbackClientConfig)
 	if err != nil {
@@ -447,6 +486,7 @@ func NewConfig(ctx context.Context, opts kcpserveroptions.CompletedOptions) (*Co
 		// 2. Rest of the handlers up to Authz
 		// 3. Scoping handlers to ensure that the request is scoped to the user's clusters before authz is done.
 		// 4. Rest of the handlers.
+		apiHandler = kcpfilters.WithAuditEventControllerAnnotation(apiHandler)
 		apiHandler = kcpfilters.WithImpersonationScoping(apiHandler)
 		apiHandler = genericapiserver.DefaultBuildHandlerChainFromImpersonationToAuthz(apiHandler, genericConfig)
 		apiHandler = kcpfilters.WithImpersonationGatekeeper(apiHandler)
@@ -608,3 +648,103 @@ func NewConfig(ctx context.Context, opts kcpserveroptions.CompletedOptions) (*Co
 
 	return c, nil
 }
+
+// newReadReplicaInformerFactories returns kcp and apiextensions informer factories listing and
+// watching from the given read replica. The kcp informers keep the identity injection of kcpConfig.
+func newReadReplicaInformerFactories(kcpConfig, apiExtensionsConfig, replica *rest.Config, resyncPeriod time.Duration) (kcpinformers.SharedInformerFactory, kcpapiextensionsinformers.SharedInformerFactory, error) {
+	kcpClient, err := kcpclientset.NewForConfig(withReadReplica(rest.CopyConfig(kcpConfig), replica))
+	if err != nil {
+		return nil, nil, err
+	}
+	apiExtensionsClient, err := kcpapiextensionsclientset.NewForConfig(withReadReplica(rest.CopyConfig(apiExtensionsConfig), replica))
+	if err != nil {
+		return nil, nil, err
+	}
+	return kcpinformers.NewSharedInformerFactoryWithOptions(kcpClient, resyncPeriod),
+		kcpapiextensionsinformers.NewSharedInformerFactoryWithOptions(apiExtensionsClient, resyncPeriod),
+		nil
+}
+
+// informerListStagger spaces the initial LISTs of informers by --informer-start-stagger. Informers
+// list with resourceVersion=0 when they start. Their later relists are not delayed.
+type informerListStagger struct {
+	delay time.Duration
+
+	lock sync.Mutex
+	next time.Time
+}
+
+func newInformerListStagger(delay time.Duration) *informerListStagger {
+	return &informerListStagger{delay: delay}
+}
+
+// wrap is a transport wrapper delaying the initial informer LISTs sent through rt.
+func (s *informerListStagger) wrap(rt http.RoundTripper) http.RoundTripper {
+	if s.delay <= 0 {
+		return rt
+	}
+	return &informerListStaggerRoundTripper{stagger: s, delegate: rt}
+}
+
+// wait blocks until the next initial LIST may be sent.
+func (s *informerListStagger) wait(ctx context.Context) error {
+	s.lock.Lock()
+	at := s.next
+	if now := time.Now(); at.Before(now) {
+		at = now
+	}
+	s.next = at.Add(s.delay)
+	s.lock.Unlock()
+
+	timer := time.NewTimer(time.Until(at))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+type informerListStaggerRoundTripper struct {
+	stagger  *informerListStagger
+	delegate http.RoundTripper
+}
+
+func (rt *informerListStaggerRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	query := req.URL.Query()
+	if req.Method == http.MethodGet && query.Get("watch") != "true" && query.Get("resourceVersion") == "0" {
+		if err := rt.stagger.wait(req.Context()); err != nil {
+			return nil, err
+		}
+	}
+	return rt.delegate.RoundTrip(req)
+}
+
+func (rt *informerListStaggerRoundTripper) WrappedRoundTripper() http.RoundTripper {
+	return rt.delegate
+}
+
+// withReadReplica points config to the endpoint of the given read replica, with the credentials
+// of the replica. Transport wrappers of config, e.g. for identity injection, are kept. If replica
+// is nil, config is returned unchanged.
+func withReadReplica(config, replica *rest.Config) *rest.Config {
+	if replica == nil {
+		return config
+	}
+
+	config.Host = replica.Host
+	config.APIPath = replica.APIPath
+	config.TLSClientConfig = replica.TLSClientConfig
+	config.BearerToken = replica.BearerToken
+	config.BearerTokenFile = replica.BearerTokenFile
+	config.Username = replica.Username
+	config.Password = replica.Password
+	config.Impersonate = replica.Impersonate
+	config.AuthProvider = replica.AuthProvider
+	config.ExecProvider = replica.ExecProvider
+	config.Proxy = replica.Proxy
+	config.Dial = replica.Dial
+
+	return config
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	kcpapiextensionsclientset "github.com/kcp-dev/client-go/apiextensions/client"
+	kcpapiextensionsinformers "github.com/kcp-dev/client-go/apiextensions/informers"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/rest"
+
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	kcpinformers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions"
+)
+
+// recordingServer records the resources listed or watched through it.
+type recordingServer struct {
+	*httptest.Server
+
+	lock      sync.Mutex
+	resources sets.Set[string]
+}
+
+func newRecordingServer(t *testing.T) *recordingServer {
+	t.Helper()
+
+	s := &recordingServer{resources: sets.New[string]()}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+		parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+		s.resources.Insert(parts[len(parts)-1])
+		http.Error(w, "not implemented", http.StatusInternalServerError)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *recordingServer) listed() sets.Set[string] {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.resources.Clone()
+}
+
+func TestReadReplicaInformerFactories(t *testing.T) {
+	shard := newRecordingServer(t)
+	replica := newRecordingServer(t)
+
+	shardConfig := &rest.Config{Host: shard.URL}
+	kcpClient, err := kcpclientset.NewForConfig(shardConfig)
+	require.NoError(t, err)
+	apiExtensionsClient, err := kcpapiextensionsclientset.NewForConfig(shardConfig)
+	require.NoError(t, err)
+	kcpInformers := kcpinformers.NewSharedInformerFactoryWithOptions(kcpClient, time.Hour)
+	apiExtensionsInformers := kcpapiextensionsinformers.NewSharedInformerFactoryWithOptions(apiExtensionsClient, time.Hour)
+
+	controllerKcpInformers, controllerAPIExtensionsInformers, err := newReadReplicaInformerFactories(shardConfig, shardConfig, &rest.Config{Host: replica.URL}, time.Hour)
+	require.NoError(t, err)
+
+	// admission and authorization informers
+	kcpInformers.Apis().V1alpha1().APIBindings().Informer()
+	apiExtensionsInformers.Apiextensions().V1().CustomResourceDefinitions().Informer()
+	// controller informers
+	controllerKcpInformers.Apis().V1alpha1().APIExports().Informer()
+	controllerAPIExtensionsInformers.Apiextensions().V1().CustomResourceDefinitions().Informer()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	kcpInformers.Start(ctx.Done())
+	apiExtensionsInformers.Start(ctx.Done())
+	controllerKcpInformers.Start(ctx.Done())
+	controllerAPIExtensionsInformers.Start(ctx.Done())
+
+	require.Eventually(t, func() bool {
+		return shard.listed().Equal(sets.New("apibindings", "customresourcedefinitions")) &&
+			replica.listed().Equal(sets.New("apiexports", "customresourcedefinitions"))
+	}, wait.ForeverTestTimeout, 100*time.Millisecond, "expected only the controller informers to use the replica")
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestInformerListStagger(t *testing.T) {
+	const delay = 100 * time.Millisecond
+
+	var lock sync.Mutex
+	sent := map[string]time.Time{}
+	rt := newInformerListStagger(delay).wrap(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		lock.Lock()
+		defer lock.Unlock()
+		sent[req.URL.Path+"?"+req.URL.RawQuery] = time.Now()
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+	}))
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for _, url := range []string{
+		"/api/v1/configmaps?resourceVersion=0",
+		"/api/v1/secrets?resourceVersion=0",
+		"/api/v1/namespaces?resourceVersion=0",
+		"/api/v1/pods?resourceVersion=0&watch=true",
+		"/api/v1/services?resourceVersion=",
+	} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "https://shard"+url, nil)
+			require.NoError(t, err)
+			_, err = rt.RoundTrip(req)
+			require.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	var lists []time.Duration
+	for url, at := range sent {
+		if strings.HasSuffix(url, "resourceVersion=0") {
+			lists = append(lists, at.Sub(start))
+			continue
+		}
+		require.Less(t, at.Sub(start), delay, "request %s was delayed", url)
+	}
+	require.Len(t, lists, 3)
+	slices.Sort(lists)
+	for i, at := range lists {
+		require.GreaterOrEqual(t, at, time.Duration(i)*delay, "list %d was not delayed", i)
+	}
+}
+
+func TestInformerListStaggerDisabled(t *testing.T) {
+	delegate := roundTripperFunc(func(req *http.Request) (*http.Response, error) { return nil, nil })
+	_, wrapped := newInformerListStagger(0).wrap(delegate).(*informerListStaggerRoundTripper)
+	require.False(t, wrapped)
+}
@@ -20,33 +20,42 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	_ "net/http/pprof"
 	"os"
+	"reflect"
+	"strings"
+	"sync"
 	"time"
 
 	kcpapiextensionsclientset "github.com/kcp-dev/client-go/apiextensions/client"
 	kcpdynamic "github.com/kcp-dev/client-go/dynamic"
+	kcpkubernetesinformers "github.com/kcp-dev/client-go/informers"
+	kcpcorev1informers "github.com/kcp-dev/client-go/informers/core/v1"
 	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
 	kcpmetadata "github.com/kcp-dev/client-go/metadata"
 	"github.com/kcp-dev/logicalcluster/v3"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsscheme "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/scheme"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
 	pluginvalidatingadmissionpolicy "k8s.io/apiserver/pkg/admission/plugin/policy/validating"
 	"k8s.io/apiserver/pkg/cel/openapi/resolver"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
 	k8sscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/restmapper"
 	certutil "k8s.io/client-go/util/cert"
-	"k8s.io/client-go/util/keyutil"
 	"k8s.io/klog/v2"
-	"k8s.io/kubernetes/pkg/controller/certificates/rootcacertpublisher"
 	"k8s.io/kubernetes/pkg/controller/clusterroleaggregation"
 	"k8s.io/kubernetes/pkg/controller/namespace"
 	serviceaccountcontroller "k8s.io/kubernetes/pkg/controller/serviceaccount"
@@ -54,6 +63,7 @@ import (
 	"k8s.io/kubernetes/pkg/generated/openapi"
 	"k8s.io/kubernetes/pkg/serviceaccount"
 
+	confighelpers "github.com/kcp-dev/kcp/config/helpers"
 	configuniversal "github.com/kcp-dev/kcp/config/universal"
 	bootstrappolicy "github.com/kcp-dev/kcp/pkg/authorization/bootstrap"
 	kcpfeatures "github.com/kcp-dev/kcp/pkg/features"
@@ -62,16 +72,23 @@ import (
 	"github.com/kcp-dev/kcp/pkg/reconciler/apis/apibinding"
 	"github.com/kcp-dev/kcp/pkg/reconciler/apis/apibindingdeletion"
 	"github.com/kcp-dev/kcp/pkg/reconciler/apis/apiexport"
+	"github.com/kcp-dev/kcp/pkg/reconciler/apis/apiexportbindingcount"
+	"github.com/kcp-dev/kcp/pkg/reconciler/apis/apiexportdeletion"
 	"github.com/kcp-dev/kcp/pkg/reconciler/apis/apiexportendpointslice"
 	"github.com/kcp-dev/kcp/pkg/reconciler/apis/apiexportendpointsliceurls"
+	"github.com/kcp-dev/kcp/pkg/reconciler/apis/apiexportexpiry"
 	"github.com/kcp-dev/kcp/pkg/reconciler/apis/crdcleanup"
 	"github.com/kcp-dev/kcp/pkg/reconciler/apis/extraannotationsync"
 	"github.com/kcp-dev/kcp/pkg/reconciler/apis/identitycache"
+	"github.com/kcp-dev/kcp/pkg/reconciler/apis/identityconflict"
 	"github.com/kcp-dev/kcp/pkg/reconciler/apis/logicalclustercleanup"
+	"github.com/kcp-dev/kcp/pkg/reconciler/apis/permissionclaimautoaccept"
 	"github.com/kcp-dev/kcp/pkg/reconciler/apis/permissionclaimlabel"
 	apisreplicateclusterrole "github.com/kcp-dev/kcp/pkg/reconciler/apis/replicateclusterrole"
 	apisreplicateclusterrolebinding "github.com/kcp-dev/kcp/pkg/reconciler/apis/replicateclusterrolebinding"
 	apisreplicatelogicalcluster "github.com/kcp-dev/kcp/pkg/reconciler/apis/replicatelogicalcluster"
+	"github.com/kcp-dev/kcp/pkg/reconciler/apis/schemacompatibility"
+	"github.com/kcp-dev/kcp/pkg/reconciler/apis/systemcrdrepair"
 	"github.com/kcp-dev/kcp/pkg/reconciler/cache/labelclusterrolebindings"
 	"github.com/kcp-dev/kcp/pkg/reconciler/cache/labelclusterroles"
 	"github.com/kcp-dev/kcp/pkg/reconciler/cache/replication"
@@ -82,6 +99,9 @@ import (
 	"github.com/kcp-dev/kcp/pkg/reconciler/core/shard"
 	"github.com/kcp-dev/kcp/pkg/reconciler/garbagecollector"
 	"github.com/kcp-dev/kcp/pkg/reconciler/kubequota"
+	"github.com/kcp-dev/kcp/pkg/reconciler/limits"
+	"github.com/kcp-dev/kcp/pkg/reconciler/rootcacertpublisher"
+	"github.com/kcp-dev/kcp/pkg/reconciler/rootcacleanup"
 	"github.com/kcp-dev/kcp/pkg/reconciler/tenancy/bootstrap"
 	"github.com/kcp-dev/kcp/pkg/reconciler/tenancy/initialization"
 	tenancylogicalcluster "github.com/kcp-dev/kcp/pkg/reconciler/tenancy/logicalcluster"
@@ -89,67 +109,241 @@ import (
 	tenancyreplicateclusterrolebinding "github.com/kcp-dev/kcp/pkg/reconciler/tenancy/replicateclusterrolebinding"
 	tenancyreplicatelogicalcluster "github.com/kcp-dev/kcp/pkg/reconciler/tenancy/replicatelogicalcluster"
 	"github.com/kcp-dev/kcp/pkg/reconciler/tenancy/workspace"
+	"github.com/kcp-dev/kcp/pkg/reconciler/tenancy/workspacedefaults"
 	"github.com/kcp-dev/kcp/pkg/reconciler/tenancy/workspacemounts"
 	"github.com/kcp-dev/kcp/pkg/reconciler/tenancy/workspacetype"
 	"github.com/kcp-dev/kcp/pkg/reconciler/topology/partitionset"
+	kcpfilters "github.com/kcp-dev/kcp/pkg/server/filters"
+	kcpserveroptions "github.com/kcp-dev/kcp/pkg/server/options"
+	"github.com/kcp-dev/kcp/pkg/server/options/batteries"
+	"github.com/kcp-dev/kcp/pkg/server/reloader"
 	initializingworkspacesbuilder "github.com/kcp-dev/kcp/pkg/virtual/initializingworkspaces/builder"
 	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
 	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	kcpclient "github.com/kcp-dev/kcp/sdk/client/clientset/versioned"
 	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
 	kcpinformers "github.com/kcp-dev/kcp/sdk/client/informers/externalversions"
 )
 
 type RunFunc func(ctx context.Context)
 type WaitFunc func(ctx context.Context, s *Server) error
+type DrainFunc func(ctx context.Context) error
 
 const (
 	waitPollInterval = time.Millisecond * 100
+
+	tracerName = "github.com/kcp-dev/kcp/pkg/server"
 )
 
 type controllerWrapper struct {
 	Name   string
 	Runner RunFunc
 	Wait   WaitFunc
+	// Drain, if set, stops the controller from accepting new work and waits until its
+	// queued work is done, or until ctx is done after --controller-shutdown-drain-timeout.
+	// It is called on shutdown while the runner and hence the workers are still running,
+	// and the runner is only stopped afterwards. Queue based controllers implement it
+	// with shutdown.DrainQueue.
+	Drain DrainFunc
 }
 
 func (s *Server) startControllers(ctx context.Context) {
+	// mark all controllers as pending before the post-start hook returns, so that
+	// the controllers readyz check does not pass before any of them started.
+	s.controllerStateLock.Lock()
+	for name := range s.controllers {
+		s.pendingControllers.Insert(name)
+	}
+	s.failedControllers = map[string]error{}
+	s.controllerStateLock.Unlock()
+
 	for _, controller := range s.controllers {
 		go s.runController(ctx, controller)
 	}
 }
 
+// checkControllersSynced is the controllers readyz check. It fails while started
+// controllers are still waiting for their informers to sync, and for good once a
+// controller failed to wait.
+func (s *Server) checkControllersSynced(_ *http.Request) error {
+	s.controllerStateLock.Lock()
+	defer s.controllerStateLock.Unlock()
+
+	if len(s.failedControllers) > 0 {
+		var errs []error
+		for _, name := range sets.List(sets.KeySet(s.failedControllers)) {
+			errs = append(errs, fmt.Errorf("controller %s failed to start: %w", name, s.failedControllers[name]))
+		}
+		return errors.Join(errs...)
+	}
+	if s.pendingControllers.Len() > 0 {
+		return fmt.Errorf("controllers waiting for sync: %s", strings.Join(sets.List(s.pendingControllers), ", "))
+	}
+	return nil
+}
+
 func (s *Server) runController(ctx context.Context, controller *controllerWrapper) {
 	log := klog.FromContext(ctx).WithValues("controller", controller.Name)
 	log.Info("waiting for sync")
+	start := time.Now()
+
+	waitCtx := ctx
+	if timeout := s.Options.Controllers.StartupTimeout; timeout > 0 {
+		var cancel context.CancelFunc
+		waitCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	// the span ends when the controller got past its post-start hook wait, successfully or not.
+	waitCtx, span := s.GenericConfig.TracerProvider.Tracer(tracerName).Start(waitCtx, "WaitForControllerSync",
+		trace.WithAttributes(attribute.String("controller", controller.Name)))
 
 	// controllers can define their own custom wait functions in case
 	// they need to start early. If they do not define one, we will wait
 	// for everything to sync.
 	var err error
 	if controller.Wait != nil {
-		err = controller.Wait(ctx, s)
+		err = controller.Wait(waitCtx, s)
 	} else {
-		err = s.WaitForSync(ctx.Done())
+		err = s.WaitForSync(waitCtx.Done())
 	}
 	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to wait for sync")
+	}
+	span.End()
+
+	// a controller that failed to wait will not start at all, hence it is not pending anymore.
+	// Unless the server is shutting down, it is failed instead, which fails readyz.
+	s.controllerStateLock.Lock()
+	s.pendingControllers.Delete(controller.Name)
+	if err != nil && ctx.Err() == nil {
+		s.failedControllers[controller.Name] = err
+	}
+	s.controllerStateLock.Unlock()
+
+	if err != nil {
+		if waitCtx.Err() != nil {
+			controllerStartCancelled.WithLabelValues(controller.Name).Inc()
+		}
+		if ctx.Err() == nil && waitCtx.Err() != nil {
+			// fail fast instead of running without this controller. Run returns the error.
+			log.Error(err, "controller did not start within the startup timeout", "timeout", s.Options.Controllers.StartupTimeout)
+			select {
+			case s.controllerStartupErrCh <- fmt.Errorf("controller %s did not start within the startup timeout of %s: %w", controller.Name, s.Options.Controllers.StartupTimeout, err):
+			default: // another controller failed already
+			}
+			return
+		}
 		log.Error(err, "failed to wait for sync")
 		return
 	}
 
+	controllerStartDuration.WithLabelValues(controller.Name).Observe(time.Since(start).Seconds())
 	log.Info("starting registered controller")
+
+	s.controllerStateLock.Lock()
+	s.runningControllers[controller.Name] = controller
+	s.controllerStateLock.Unlock()
+	defer func() {
+		s.controllerStateLock.Lock()
+		delete(s.runningControllers, controller.Name)
+		s.controllerStateLock.Unlock()
+	}()
+
 	controller.Runner(ctx)
 }
 
+// controllerHeaderRoundTripper sends the name of the reconciling controller and the reconcile
+// ID with the requests of a reconcile. The server adds them to the audit events of the requests.
+type controllerHeaderRoundTripper struct {
+	delegate http.RoundTripper
+}
+
+func (rt *controllerHeaderRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if controller := limits.ControllerFrom(req.Context()); controller != "" {
+		req = utilnet.CloneRequest(req)
+		req.Header.Set(kcpfilters.ControllerHeader, controller)
+		if reconcileID := limits.ReconcileIDFrom(req.Context()); reconcileID != "" {
+			req.Header.Set(kcpfilters.ReconcileIDHeader, reconcileID)
+		}
+	}
+	return rt.delegate.RoundTrip(req)
+}
+
+func (rt *controllerHeaderRoundTripper) WrappedRoundTripper() http.RoundTripper {
+	return rt.delegate
+}
+
+// drainControllers drains all running controllers in parallel, bounded by
+// --controller-shutdown-drain-timeout.
+func (s *Server) drainControllers(ctx context.Context) {
+	timeout := s.Options.Controllers.ShutdownDrainTimeout
+	if timeout <= 0 {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	s.controllerStateLock.Lock()
+	var controllers []*controllerWrapper
+	for _, controller := range s.runningControllers {
+		if controller.Drain != nil {
+			controllers = append(controllers, controller)
+		}
+	}
+	s.controllerStateLock.Unlock()
+
+	logger := klog.FromContext(ctx)
+	var wg sync.WaitGroup
+	for _, controller := range controllers {
+		wg.Add(1)
+		go func(controller *controllerWrapper) {
+			defer wg.Done()
+			if err := controller.Drain(ctx); err != nil {
+				logger.Error(err, "failed to drain controller", "controller", controller.Name)
+				return
+			}
+			logger.V(2).Info("drained controller", "controller", controller.Name)
+		}(controller)
+	}
+	wg.Wait()
+}
+
+// controllerWorkers returns the number of workers configured for the named controller
+// with --controller-workers, or the given default.
+func (s *Server) controllerWorkers(name string, defaultWorkers int) int {
+	if workers, ok := s.Options.Controllers.Workers[name]; ok {
+		return workers
+	}
+	return defaultWorkers
+}
+
 func (s *Server) registerController(controller *controllerWrapper) error {
 	if s.controllers[controller.Name] != nil {
 		return fmt.Errorf("controller %s is already registered", controller.Name)
 	}
 
+	if !kcpserveroptions.KnownControllers.Has(controller.Name) {
+		return fmt.Errorf("controller %s is missing from the known controllers", controller.Name)
+	}
+	if !s.Options.Controllers.IsControllerEnabled(controller.Name) {
+		klog.Background().WithValues("controller", controller.Name).Info("controller is disabled by --controllers")
+		return nil
+	}
+
 	s.controllers[controller.Name] = controller
 
 	return nil
 }
 
+// InstalledControllers returns the sorted names of the controllers the server installed.
+// Controllers disabled with --controllers are not included. It must not be called
+// before the controllers are installed in Run.
+func (s *Server) InstalledControllers() []string {
+	return sets.List(sets.KeySet(s.controllers))
+}
+
 func (s *Server) installClusterRoleAggregationController(ctx context.Context, config *rest.Config) error {
 	controllerName := "kube-cluster-role-aggregation-controller"
 	config = rest.AddUserAgent(rest.CopyConfig(config), controllerName)
@@ -169,7 +363,7 @@ func (s *Server) installClusterRoleAggregationController(ctx context.Context, co
 			})
 		},
 		Runner: func(ctx context.Context) {
-			c.Run(ctx, 5)
+			c.Run(ctx, s.controllerWorkers(controllerName, 5))
 		},
 	})
 }
@@ -196,6 +390,17 @@ func (s *Server) installKubeNamespaceController(ctx context.Context, config *res
 		return discoveryClient.ServerPreferredNamespacedResources()
 	}
 
+	// namespace deletion discovers the resources of a logical cluster on every attempt. Cache them, and
+	// drop the cache of a logical cluster as soon as the resources served in it might have changed.
+	namespaceDiscovery := newCachedDiscovery(discoverResourcesFn, namespaceDiscoveryTTL)
+	_, _ = s.ControllerApiExtensionsSharedInformerFactory.Apiextensions().V1().CustomResourceDefinitions().Informer().AddEventHandler(namespaceDiscovery.EventHandler())
+	_, _ = s.ControllerKcpSharedInformerFactory.Apis().V1alpha1().APIBindings().Informer().AddEventHandler(namespaceDiscovery.EventHandler())
+
+	namespaceInformer := s.KubeSharedInformerFactory.Core().V1().Namespaces()
+	if grace := s.Options.Controllers.NamespaceDeletionGracePeriod; grace > 0 {
+		namespaceInformer = &namespaceGraceInformer{NamespaceClusterInformer: namespaceInformer, grace: grace}
+	}
+
 	// We have to construct this outside of / before any post-start hooks are invoked, because
 	// the constructor sets up event handlers on shared informers, which instructs the factory
 	// which informers need to be started. The shared informer factories are started in their
@@ -204,8 +409,8 @@ func (s *Server) installKubeNamespaceController(ctx context.Context, config *res
 		ctx,
 		kubeClient,
 		metadata,
-		discoverResourcesFn,
-		s.KubeSharedInformerFactory.Core().V1().Namespaces(),
+		namespaceDiscovery.Discover,
+		namespaceInformer,
 		time.Duration(5)*time.Minute,
 		corev1.FinalizerKubernetes,
 	)
@@ -218,7 +423,7 @@ func (s *Server) installKubeNamespaceController(ctx context.Context, config *res
 			})
 		},
 		Runner: func(ctx context.Context) {
-			c.Run(ctx, 10)
+			c.Run(ctx, s.controllerWorkers(controllerName, 10))
 		},
 	})
 }
@@ -231,11 +436,18 @@ func (s *Server) installKubeServiceAccountController(ctx context.Context, config
 		return err
 	}
 
+	options := serviceaccountcontroller.DefaultServiceAccountsControllerOptions()
+	options.ServiceAccounts = make([]corev1.ServiceAccount, 0, len(s.Options.Controllers.ServiceAccountsToEnsure))
+	for _, name := range s.Options.Controllers.ServiceAccountsToEnsure {
+		options.ServiceAccounts = append(options.ServiceAccounts, corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: name}})
+	}
+
+	namespaceInformer, startNamespaceInformer := s.controllerNamespaceInformer(controllerName, kubeClient)
 	c, err := serviceaccountcontroller.NewServiceAccountsController(
 		s.KubeSharedInformerFactory.Core().V1().ServiceAccounts(),
-		s.KubeSharedInformerFactory.Core().V1().Namespaces(),
+		namespaceInformer,
 		kubeClient,
-		serviceaccountcontroller.DefaultServiceAccountsControllerOptions(),
+		options,
 	)
 	if err != nil {
 		return fmt.Errorf("error creating ServiceAccount controller: %w", err)
@@ -250,7 +462,8 @@ func (s *Server) installKubeServiceAccountController(ctx context.Context, config
 			})
 		},
 		Runner: func(ctx context.Context) {
-			c.Run(ctx, 1)
+			startNamespaceInformer(ctx)
+			c.Run(ctx, s.controllerWorkers(controllerName, 1))
 		},
 	})
 }
@@ -267,9 +480,9 @@ func (s *Server) installKubeServiceAccountTokenController(ctx context.Context, c
 	if len(serviceAccountKeyFile) == 0 {
 		return fmt.Errorf("service account controller requires a private key")
 	}
-	privateKey, err := keyutil.PrivateKeyFromFile(serviceAccountKeyFile)
+	tokenGenerator, err := reloader.NewTokenGenerator(serviceaccount.LegacyIssuer, serviceAccountKeyFile, s.Options.GenericControlPlane.Authentication.ServiceAccounts.KeyFiles)
 	if err != nil {
-		return fmt.Errorf("error reading key for service account token controller: %w", err)
+		return err
 	}
 
 	var rootCA []byte
@@ -282,10 +495,6 @@ func (s *Server) installKubeServiceAccountTokenController(ctx context.Context, c
 		rootCA = config.CAData
 	}
 
-	tokenGenerator, err := serviceaccount.JWTTokenGenerator(serviceaccount.LegacyIssuer, privateKey)
-	if err != nil {
-		return fmt.Errorf("failed to build token generator: %w", err)
-	}
 	controller, err := serviceaccountcontroller.NewTokensController(
 		s.KubeSharedInformerFactory.Core().V1().ServiceAccounts(),
 		s.KubeSharedInformerFactory.Core().V1().Secrets(),
@@ -308,23 +517,28 @@ func (s *Server) installKubeServiceAccountTokenController(ctx context.Context, c
 			})
 		},
 		Runner: func(ctx context.Context) {
+			go func() {
+				if err := tokenGenerator.Run(ctx); err != nil {
+					klog.FromContext(ctx).Error(err, "error watching service account key", "controller", controllerName)
+				}
+			}()
+
 			controller.Run(
 				ctx,
-				int(s.Options.Controllers.SAController.ConcurrentSATokenSyncs),
+				s.controllerWorkers(controllerName, int(s.Options.Controllers.SAController.ConcurrentSATokenSyncs)),
 			)
 		},
 	})
 }
 
 func (s *Server) installRootCAConfigMapController(ctx context.Context, config *rest.Config) error {
-	controllerName := "kube-root-ca-configmap-controller"
+	controllerName := rootcacertpublisher.ControllerName
 	config = rest.AddUserAgent(rest.CopyConfig(config), controllerName)
 	kubeClient, err := kcpkubernetesclientset.NewForConfig(config)
 	if err != nil {
 		return err
 	}
 
-	// TODO(jmprusi): We should make the CA loading dynamic when the file changes on disk.
 	caDataPath := s.Options.Controllers.SAController.RootCAFile
 	if caDataPath == "" {
 		caDataPath = s.Options.GenericControlPlane.SecureServing.SecureServingOptions.ServerCert.CertKey.CertFile
@@ -335,18 +549,95 @@ func (s *Server) installRootCAConfigMapController(ctx context.Context, config *r
 		return fmt.Errorf("error parsing root-ca-file at %s: %w", caDataPath, err)
 	}
 
-	c, err := rootcacertpublisher.NewPublisher(
-		s.KubeSharedInformerFactory.Core().V1().ConfigMaps(),
-		s.KubeSharedInformerFactory.Core().V1().Namespaces(),
+	namespaceInformer, startNamespaceInformer := s.controllerNamespaceInformer(controllerName, kubeClient)
+
+	c, err := rootcacertpublisher.NewController(
 		kubeClient,
+		s.KubeSharedInformerFactory.Core().V1().ConfigMaps(),
+		namespaceInformer,
 		caData,
 	)
 	if err != nil {
-		return fmt.Errorf("error creating %s controller: %w", controllerName, err)
+		return err
 	}
 
 	return s.registerController(&controllerWrapper{
-		Name: controllerName,
+		Name:  controllerName,
+		Drain: c.Drain,
+		Wait: func(ctx context.Context, s *Server) error {
+			return wait.PollUntilContextCancel(ctx, waitPollInterval, true, func(ctx context.Context) (bool, error) {
+				return s.KubeSharedInformerFactory.Core().V1().ConfigMaps().Informer().HasSynced() &&
+					s.KubeSharedInformerFactory.Core().V1().Namespaces().Informer().HasSynced(), nil
+			})
+		},
+		Runner: func(ctx context.Context) {
+			startNamespaceInformer(ctx)
+
+			go func() {
+				err := reloader.NewReloader(caDataPath, time.Second).Run(ctx, func(ctx context.Context, caData []byte) {
+					if err := c.SetRootCA(caData); err != nil {
+						klog.FromContext(ctx).Error(err, "error switching to the new root CA", "controller", controllerName)
+					}
+				})
+				if err != nil {
+					klog.FromContext(ctx).Error(err, "error watching the root CA", "controller", controllerName)
+				}
+			}()
+
+			c.Start(ctx, s.controllerWorkers(controllerName, 2))
+		},
+	})
+}
+
+// controllerNamespaceInformer returns the namespace informer of the named controller. If selectors
+// are configured for the controller, it is a dedicated informer listing and watching only the
+// matching namespaces, which is started by the returned function. Otherwise it is the shared
+// informer and the function does nothing.
+func (s *Server) controllerNamespaceInformer(controllerName string, kubeClient kcpkubernetesclientset.ClusterInterface) (kcpcorev1informers.NamespaceClusterInformer, func(ctx context.Context)) {
+	labelSelector := s.Options.Controllers.InformerLabelSelectors[controllerName]
+	fieldSelector := s.Options.Controllers.InformerFieldSelectors[controllerName]
+	if labelSelector == "" && fieldSelector == "" {
+		return s.KubeSharedInformerFactory.Core().V1().Namespaces(), func(context.Context) {}
+	}
+
+	factory := kcpkubernetesinformers.NewSharedInformerFactoryWithOptions(
+		kubeClient,
+		s.Options.Controllers.InformerResyncPeriod,
+		kcpkubernetesinformers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.LabelSelector = labelSelector
+			options.FieldSelector = fieldSelector
+		}),
+	)
+	namespaceInformer := factory.Core().V1().Namespaces()
+	_ = namespaceInformer.Informer() // register with the factory before it is started
+
+	return namespaceInformer, func(ctx context.Context) {
+		klog.FromContext(ctx).Info("starting dedicated namespace informer", "controller", controllerName, "labelSelector", labelSelector, "fieldSelector", fieldSelector)
+		factory.Start(ctx.Done())
+		factory.WaitForCacheSync(ctx.Done())
+	}
+}
+
+func (s *Server) installRootCAConfigMapCleanupController(ctx context.Context, config *rest.Config) error {
+	config = rest.CopyConfig(config)
+	config = rest.AddUserAgent(config, rootcacleanup.ControllerName)
+	kubeClusterClient, err := kcpkubernetesclientset.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+
+	c, err := rootcacleanup.NewController(
+		kubeClusterClient,
+		s.KubeSharedInformerFactory.Core().V1().ConfigMaps(),
+		s.KubeSharedInformerFactory.Core().V1().Namespaces(),
+	)
+	if err != nil {
+		return err
+	}
+
+	return s.registerController(&controllerWrapper{
+		Name:  rootcacleanup.ControllerName,
+		Drain: c.Drain,
 		Wait: func(ctx context.Context, s *Server) error {
 			return wait.PollUntilContextCancel(ctx, waitPollInterval, true, func(ctx context.Context) (bool, error) {
 				return s.KubeSharedInformerFactory.Core().V1().ConfigMaps().Informer().HasSynced() &&
@@ -354,7 +645,7 @@ func (s *Server) installRootCAConfigMapController(ctx context.Context, config *r
 			})
 		},
 		Runner: func(ctx context.Context) {
-			c.Run(ctx, 2)
+			c.Start(ctx, s.controllerWorkers(rootcacleanup.ControllerName, 1))
 		},
 	})
 }
@@ -401,7 +692,7 @@ func (s *Server) installKubeValidatingAdmissionPolicyStatusController(_ context.
 			})
 		},
 		Runner: func(ctx context.Context) {
-			c.Run(ctx, 5)
+			c.Run(ctx, s.controllerWorkers(controllerName, 5))
 		},
 	})
 }
@@ -428,20 +719,21 @@ func (s *Server) installTenancyLogicalClusterController(ctx context.Context, con
 
 	controller := tenancylogicalcluster.NewController(
 		kubeClusterClient,
-		s.KcpSharedInformerFactory.Core().V1alpha1().LogicalClusters(),
+		s.ControllerKcpSharedInformerFactory.Core().V1alpha1().LogicalClusters(),
 		s.KubeSharedInformerFactory.Rbac().V1().ClusterRoleBindings(),
 	)
 
 	return s.registerController(&controllerWrapper{
-		Name: tenancylogicalcluster.ControllerName,
+		Name:  tenancylogicalcluster.ControllerName,
+		Drain: controller.Drain,
 		Wait: func(ctx context.Context, s *Server) error {
 			return wait.PollUntilContextCancel(ctx, waitPollInterval, true, func(ctx context.Context) (bool, error) {
-				return s.KcpSharedInformerFactory.Core().V1alpha1().LogicalClusters().Informer().HasSynced() &&
+				return s.ControllerKcpSharedInformerFactory.Core().V1alpha1().LogicalClusters().Informer().HasSynced() &&
 					s.KubeSharedInformerFactory.Rbac().V1().ClusterRoleBindings().Informer().HasSynced(), nil
 			})
 		},
 		Runner: func(ctx context.Context) {
-			controller.Start(ctx, 10)
+			controller.Start(ctx, s.controllerWorkers(tenancylogicalcluster.ControllerName, 10))
 		},
 	})
 }
@@ -477,21 +769,22 @@ func (s *Server) installLogicalClusterDeletionController(ctx context.Context, co
 		logicalClusterAdminConfig,
 		externalLogicalClusterAdminConfig,
 		metadataClusterClient,
-		s.KcpSharedInformerFactory.Core().V1alpha1().LogicalClusters(),
+		s.ControllerKcpSharedInformerFactory.Core().V1alpha1().LogicalClusters(),
 		discoverResourcesFn,
-		s.KcpSharedInformerFactory.Apis().V1alpha1().APIBindings(),
+		s.ControllerKcpSharedInformerFactory.Apis().V1alpha1().APIBindings(),
 	)
 
 	return s.registerController(&controllerWrapper{
-		Name: logicalclusterdeletion.ControllerName,
+		Name:  logicalclusterdeletion.ControllerName,
+		Drain: logicalClusterDeletionController.Drain,
 		Wait: func(ctx context.Context, s *Server) error {
 			return wait.PollUntilContextCancel(ctx, waitPollInterval, true, func(ctx context.Context) (bool, error) {
-				return s.KcpSharedInformerFactory.Core().V1alpha1().LogicalClusters().Informer().HasSynced() &&
-					s.KcpSharedInformerFactory.Apis().V1alpha1().APIBindings().Informer().HasSynced(), nil
+				return s.ControllerKcpSharedInformerFactory.Core().V1alpha1().LogicalClusters().Informer().HasSynced() &&
+					s.ControllerKcpSharedInformerFactory.Apis().V1alpha1().APIBindings().Informer().HasSynced(), nil
 			})
 		},
 		Runner: func(ctx context.Context) {
-			logicalClusterDeletionController.Start(ctx, 10)
+			logicalClusterDeletionController.Start(ctx, s.controllerWorkers(logicalclusterdeletion.ControllerName, s.Options.Controllers.WorkspaceDeletionWorkers))
 		},
 	})
 }
@@ -521,27 +814,28 @@ func (s *Server) installWorkspaceScheduler(ctx context.Context, config *rest.Con
 		kubeClusterClient,
 		logicalClusterAdminConfig,
 		externalLogicalClusterAdminConfig,
-		s.KcpSharedInformerFactory.Tenancy().V1alpha1().Workspaces(),
+		s.ControllerKcpSharedInformerFactory.Tenancy().V1alpha1().Workspaces(),
 		s.CacheKcpSharedInformerFactory.Core().V1alpha1().Shards(),
 		s.CacheKcpSharedInformerFactory.Tenancy().V1alpha1().WorkspaceTypes(),
-		s.KcpSharedInformerFactory.Core().V1alpha1().LogicalClusters(),
+		s.ControllerKcpSharedInformerFactory.Core().V1alpha1().LogicalClusters(),
 	)
 	if err != nil {
 		return err
 	}
 
 	if err := s.registerController(&controllerWrapper{
-		Name: workspace.ControllerName,
+		Name:  workspace.ControllerName,
+		Drain: workspaceController.Drain,
 		Wait: func(ctx context.Context, s *Server) error {
 			return wait.PollUntilContextCancel(ctx, waitPollInterval, true, func(ctx context.Context) (bool, error) {
-				return s.KcpSharedInformerFactory.Tenancy().V1alpha1().Workspaces().Informer().HasSynced() &&
+				return s.ControllerKcpSharedInformerFactory.Tenancy().V1alpha1().Workspaces().Informer().HasSynced() &&
 					s.CacheKcpSharedInformerFactory.Core().V1alpha1().Shards().Informer().HasSynced() &&
 					s.CacheKcpSharedInformerFactory.Tenancy().V1alpha1().WorkspaceTypes().Informer().HasSynced() &&
-					s.KcpSharedInformerFactory.Core().V1alpha1().LogicalClusters().Informer().HasSynced(), nil
+					s.ControllerKcpSharedInformerFactory.Core().V1alpha1().LogicalClusters().Informer().HasSynced(), nil
 			})
 		},
 		Runner: func(ctx context.Context) {
-			workspaceController.Start(ctx, 2)
+			workspaceController.Start(ctx, s.controllerWorkers(workspace.ControllerName, 2))
 		},
 	}); err != nil {
 		return err
@@ -558,7 +852,7 @@ func (s *Server) installWorkspaceScheduler(ctx context.Context, config *rest.Con
 	if s.Options.Extra.ShardName == corev1alpha1.RootShard {
 		workspaceShardController, err = shard.NewController(
 			kcpClusterClient,
-			s.KcpSharedInformerFactory.Core().V1alpha1().Shards(),
+			s.ControllerKcpSharedInformerFactory.Core().V1alpha1().Shards(),
 		)
 		if err != nil {
 			return err
@@ -566,14 +860,15 @@ func (s *Server) installWorkspaceScheduler(ctx context.Context, config *rest.Con
 	}
 	if workspaceShardController != nil {
 		if err := s.registerController(&controllerWrapper{
-			Name: shard.ControllerName,
+			Name:  shard.ControllerName,
+			Drain: workspaceShardController.Drain,
 			Wait: func(ctx context.Context, s *Server) error {
 				return wait.PollUntilContextCancel(ctx, waitPollInterval, true, func(ctx context.Context) (bool, error) {
-					return s.KcpSharedInformerFactory.Core().V1alpha1().Shards().Informer().HasSynced(), nil
+					return s.ControllerKcpSharedInformerFactory.Core().V1alpha1().Shards().Informer().HasSynced(), nil
 				})
 			},
 			Runner: func(ctx context.Context) {
-				workspaceShardController.Start(ctx, 2)
+				workspaceShardController.Start(ctx, s.controllerWorkers(shard.ControllerName, 2))
 			},
 		}); err != nil {
 			return err
@@ -589,7 +884,7 @@ func (s *Server) installWorkspaceScheduler(ctx context.Context, config *rest.Con
 
 	workspaceTypeController, err := workspacetype.NewController(
 		kcpClusterClient,
-		s.KcpSharedInformerFactory.Tenancy().V1alpha1().WorkspaceTypes(),
+		s.ControllerKcpSharedInformerFactory.Tenancy().V1alpha1().WorkspaceTypes(),
 		s.CacheKcpSharedInformerFactory.Core().V1alpha1().Shards(),
 	)
 	if err != nil {
@@ -597,15 +892,16 @@ func (s *Server) installWorkspaceScheduler(ctx context.Context, config *rest.Con
 	}
 
 	if err := s.registerController(&controllerWrapper{
-		Name: workspacetype.ControllerName,
+		Name:  workspacetype.ControllerName,
+		Drain: workspaceTypeController.Drain,
 		Wait: func(ctx context.Context, s *Server) error {
 			return wait.PollUntilContextCancel(ctx, waitPollInterval, true, func(ctx context.Context) (bool, error) {
-				return s.KcpSharedInformerFactory.Tenancy().V1alpha1().WorkspaceTypes().Informer().HasSynced() &&
+				return s.ControllerKcpSharedInformerFactory.Tenancy().V1alpha1().WorkspaceTypes().Informer().HasSynced() &&
 					s.CacheKcpSharedInformerFactory.Core().V1alpha1().Shards().Informer().HasSynced(), nil
 			})
 		},
 		Runner: func(ctx context.Context) {
-			workspaceTypeController.Start(ctx, 2)
+			workspaceTypeController.Start(ctx, s.controllerWorkers(workspacetype.ControllerName, 2))
 		},
 	}); err != nil {
 		return err
@@ -627,12 +923,23 @@ func (s *Server) installWorkspaceScheduler(ctx context.Context, config *rest.Con
 		return err
 	}
 
+	retry := confighelpers.RetryOption(confighelpers.RetryPolicy{
+		FailedOnly: s.Options.Controllers.UniversalBootstrapRetryFailedOnly,
+		Backoff: wait.Backoff{
+			Duration: s.Options.Controllers.UniversalBootstrapRetryBackoff,
+			Factor:   2,
+			Jitter:   0.1,
+			Cap:      s.Options.Controllers.UniversalBootstrapRetryMaxBackoff,
+		},
+	})
 	universalController, err := bootstrap.NewController(
 		dynamicClusterClient,
 		bootstrapKcpClusterClient,
-		s.KcpSharedInformerFactory.Core().V1alpha1().LogicalClusters(),
+		s.ControllerKcpSharedInformerFactory.Core().V1alpha1().LogicalClusters(),
 		tenancyv1alpha1.WorkspaceTypeReference{Path: "root", Name: "universal"},
-		configuniversal.Bootstrap,
+		func(ctx context.Context, discoveryClient discovery.DiscoveryInterface, dynamicClient dynamic.Interface, kcpClient kcpclient.Interface, batteriesIncluded sets.Set[string]) error {
+			return configuniversal.Bootstrap(ctx, discoveryClient, dynamicClient, kcpClient, batteriesIncluded, retry)
+		},
 		sets.New[string](s.Options.Extra.BatteriesIncluded...),
 	)
 	if err != nil {
@@ -640,14 +947,119 @@ func (s *Server) installWorkspaceScheduler(ctx context.Context, config *rest.Con
 	}
 
 	return s.registerController(&controllerWrapper{
-		Name: universalControllerName,
+		Name:  universalControllerName,
+		Drain: universalController.Drain,
+		Wait: func(ctx context.Context, s *Server) error {
+			return wait.PollUntilContextCancel(ctx, waitPollInterval, true, func(ctx context.Context) (bool, error) {
+				return s.ControllerKcpSharedInformerFactory.Core().V1alpha1().LogicalClusters().Informer().HasSynced(), nil
+			})
+		},
+		Runner: func(ctx context.Context) {
+			universalController.Start(ctx, s.controllerWorkers(universalControllerName, s.Options.Controllers.UniversalBootstrapWorkers))
+		},
+	})
+}
+
+func (s *Server) installWorkspaceDefaultsController(ctx context.Context, config *rest.Config) error {
+	batteriesIncluded := sets.New[string](s.Options.Extra.BatteriesIncluded...)
+
+	var defaults []workspacedefaults.Default
+	if batteriesIncluded.Has(batteries.DefaultNetworkPolicy) {
+		d, err := workspacedefaults.NetworkPolicy(batteries.DefaultNetworkPolicy, s.Options.Controllers.DefaultNetworkPolicyFile)
+		if err != nil {
+			return err
+		}
+		defaults = append(defaults, d)
+	}
+	if batteriesIncluded.Has(batteries.DefaultLimitRange) {
+		d, err := workspacedefaults.LimitRange(batteries.DefaultLimitRange, s.Options.Controllers.DefaultLimitRangeFile)
+		if err != nil {
+			return err
+		}
+		defaults = append(defaults, d)
+	}
+	if s.Options.Controllers.PruneDisabledBatteryDefaults {
+		if err := s.installWorkspaceDefaultsPruningController(ctx, config, batteriesIncluded); err != nil {
+			return err
+		}
+	}
+	if len(defaults) == 0 {
+		return nil
+	}
+
+	config = rest.CopyConfig(config)
+	config = rest.AddUserAgent(config, workspacedefaults.ControllerName)
+	kcpClusterClient, err := kcpclientset.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+	dynamicClusterClient, err := kcpdynamic.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+
+	c, err := workspacedefaults.NewController(
+		defaults,
+		kcpClusterClient,
+		dynamicClusterClient,
+		s.ControllerKcpSharedInformerFactory.Core().V1alpha1().LogicalClusters(),
+	)
+	if err != nil {
+		return err
+	}
+
+	return s.registerController(&controllerWrapper{
+		Name:  workspacedefaults.ControllerName,
+		Drain: c.Drain,
+		Wait: func(ctx context.Context, s *Server) error {
+			return wait.PollUntilContextCancel(ctx, waitPollInterval, true, func(ctx context.Context) (bool, error) {
+				return s.ControllerKcpSharedInformerFactory.Core().V1alpha1().LogicalClusters().Informer().HasSynced(), nil
+			})
+		},
+		Runner: func(ctx context.Context) {
+			c.Start(ctx, s.controllerWorkers(workspacedefaults.ControllerName, 2))
+		},
+	})
+}
+
+func (s *Server) installWorkspaceDefaultsPruningController(ctx context.Context, config *rest.Config, batteriesIncluded sets.Set[string]) error {
+	disabled := map[string][]schema.GroupVersionResource{}
+	if !batteriesIncluded.Has(batteries.DefaultNetworkPolicy) {
+		disabled[batteries.DefaultNetworkPolicy] = []schema.GroupVersionResource{workspacedefaults.NetworkPolicyResource}
+	}
+	if !batteriesIncluded.Has(batteries.DefaultLimitRange) {
+		disabled[batteries.DefaultLimitRange] = []schema.GroupVersionResource{workspacedefaults.LimitRangeResource}
+	}
+	if len(disabled) == 0 {
+		return nil
+	}
+
+	config = rest.CopyConfig(config)
+	config = rest.AddUserAgent(config, workspacedefaults.PruningControllerName)
+	dynamicClusterClient, err := kcpdynamic.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+
+	c, err := workspacedefaults.NewPruningController(
+		disabled,
+		dynamicClusterClient,
+		s.ControllerKcpSharedInformerFactory.Core().V1alpha1().LogicalClusters(),
+	)
+	if err != nil {
+		return err
+	}
+
+	return s.registerController(&controllerWrapper{
+		Name:  workspacedefaults.PruningControllerName,
+		Drain: c.Drain,
 		Wait: func(ctx context.Context, s *Server) error {
 			return wait.PollUntilContextCancel(ctx, waitPollInterval, true, func(ctx context.Context) (bool, error) {
-				return s.KcpSharedInformerFactory.Core().V1alpha1().LogicalClusters().Informer().HasSynced(), nil
+				return s.ControllerKcpSharedInformerFactory.Core().V1alpha1().LogicalClusters().Informer().HasSynced(), nil
 			})
 		},
 		Runner: func(ctx context.Context) {
-			universalController.Start(ctx, 2)
+			c.Start(ctx, s.controllerWorkers(workspacedefaults.PruningControllerName, 2))
 		},
 	})
 }
@@ -674,7 +1086,7 @@ func (s *Server) installWorkspaceMountsScheduler(ctx context.Context, config *re
 	workspaceMountsController, err := workspacemounts.NewController(
 		kcpClusterClient,
 		dynamicClusterClient,
-		s.KcpSharedInformerFactory.Tenancy().V1alpha1().Workspaces(),
+		s.ControllerKcpSharedInformerFactory.Tenancy().V1alpha1().Workspaces(),
 		s.DiscoveringDynamicSharedInformerFactory,
 	)
 	if err != nil {
@@ -682,18 +1094,19 @@ func (s *Server) installWorkspaceMountsScheduler(ctx context.Context, config *re
 	}
 
 	return s.registerController(&controllerWrapper{
-		Name: workspacemounts.ControllerName,
+		Name:  workspacemounts.ControllerName,
+		Drain: workspaceMountsController.Drain,
 		Wait: func(ctx context.Context, s *Server) error {
 			return wait.PollUntilContextCancel(ctx, waitPollInterval, true, func(ctx context.Context) (bool, error) {
 				_, notSynced := s.DiscoveringDynamicSharedInformerFactory.Informers()
 				if len(notSynced) > 0 {
 					return false, nil
 				}
-				return s.KcpSharedInformerFactory.Tenancy().V1alpha1().Workspaces().Informer().HasSynced(), nil
+				return s.ControllerKcpSharedInformerFactory.Tenancy().V1alpha1().Workspaces().Informer().HasSynced(), nil
 			})
 		},
 		Runner: func(ctx context.Context) {
-			workspaceMountsController.Start(ctx, 2)
+			workspaceMountsController.Start(ctx, s.controllerWorkers(workspacemounts.ControllerName, 2))
 		},
 	})
 }
@@ -709,21 +1122,22 @@ func (s *Server) installLogicalCluster(ctx context.Context, config *rest.Config)
 	logicalClusterController, err := logicalclusterctrl.NewController(
 		s.CompletedConfig.ShardExternalURL,
 		kcpClusterClient,
-		s.KcpSharedInformerFactory.Core().V1alpha1().LogicalClusters(),
+		s.ControllerKcpSharedInformerFactory.Core().V1alpha1().LogicalClusters(),
 	)
 	if err != nil {
 		return err
 	}
 
 	return s.registerController(&controllerWrapper{
-		Name: logicalclusterctrl.ControllerName,
+		Name:  logicalclusterctrl.ControllerName,
+		Drain: logicalClusterController.Drain,
 		Wait: func(ctx context.Context, s *Server) error {
 			return wait.PollUntilContextCancel(ctx, waitPollInterval, true, func(ctx context.Context) (bool, error) {
-				return s.KcpSharedInformerFactory.Core().V1alpha1().LogicalClusters().Informer().HasSynced(), nil
+				return s.ControllerKcpSharedInformerFactory.Core().V1alpha1().LogicalClusters().Informer().HasSynced(), nil
 			})
 		},
 		Runner: func(ctx context.Context) {
-			logicalClusterController.Start(ctx, 2)
+			logicalClusterController.Start(ctx, s.controllerWorkers(logicalclusterctrl.ControllerName, 2))
 		},
 	})
 }
@@ -746,37 +1160,38 @@ func (s *Server) installAPIBindingController(ctx context.Context, config *rest.C
 	c, err := apibinding.NewController(
 		crdClusterClient,
 		kcpClusterClient,
-		s.KcpSharedInformerFactory.Apis().V1alpha1().APIBindings(),
-		s.KcpSharedInformerFactory.Apis().V1alpha1().APIExports(),
-		s.KcpSharedInformerFactory.Apis().V1alpha1().APIResourceSchemas(),
-		s.KcpSharedInformerFactory.Apis().V1alpha1().APIConversions(),
-		s.KcpSharedInformerFactory.Core().V1alpha1().LogicalClusters(),
+		s.ControllerKcpSharedInformerFactory.Apis().V1alpha1().APIBindings(),
+		s.ControllerKcpSharedInformerFactory.Apis().V1alpha1().APIExports(),
+		s.ControllerKcpSharedInformerFactory.Apis().V1alpha1().APIResourceSchemas(),
+		s.ControllerKcpSharedInformerFactory.Apis().V1alpha1().APIConversions(),
+		s.ControllerKcpSharedInformerFactory.Core().V1alpha1().LogicalClusters(),
 		s.CacheKcpSharedInformerFactory.Apis().V1alpha1().APIExports(),
 		s.CacheKcpSharedInformerFactory.Apis().V1alpha1().APIResourceSchemas(),
 		s.CacheKcpSharedInformerFactory.Apis().V1alpha1().APIConversions(),
-		s.ApiExtensionsSharedInformerFactory.Apiextensions().V1().CustomResourceDefinitions(),
+		s.ControllerApiExtensionsSharedInformerFactory.Apiextensions().V1().CustomResourceDefinitions(),
 	)
 	if err != nil {
 		return err
 	}
 
 	if err := s.registerController(&controllerWrapper{
-		Name: apibinding.ControllerName,
+		Name:  apibinding.ControllerName,
+		Drain: c.Drain,
 		Wait: func(ctx context.Context, s *Server) error {
 			// do custom wait logic here because APIExports+APIBindings are special as system CRDs,
 			// and the controllers must run as soon as these two informers are up in order to bootstrap
 			// the rest of the system. Everything else in the kcp clientset is APIBinding based.
 			return wait.PollUntilContextCancel(ctx, waitPollInterval, true, func(ctx context.Context) (bool, error) {
-				return s.ApiExtensionsSharedInformerFactory.Apiextensions().V1().CustomResourceDefinitions().Informer().HasSynced() &&
-					s.KcpSharedInformerFactory.Apis().V1alpha1().APIExports().Informer().HasSynced() &&
+				return s.ControllerApiExtensionsSharedInformerFactory.Apiextensions().V1().CustomResourceDefinitions().Informer().HasSynced() &&
+					s.ControllerKcpSharedInformerFactory.Apis().V1alpha1().APIExports().Informer().HasSynced() &&
 					s.CacheKcpSharedInformerFactory.Apis().V1alpha1().APIExports().Informer().HasSynced() &&
-					s.KcpSharedInformerFactory.Apis().V1alpha1().APIResourceSchemas().Informer().HasSynced() &&
+					s.ControllerKcpSharedInformerFactory.Apis().V1alpha1().APIResourceSchemas().Informer().HasSynced() &&
 					s.CacheKcpSharedInformerFactory.Apis().V1alpha1().APIResourceSchemas().Informer().HasSynced() &&
-					s.KcpSharedInformerFactory.Apis().V1alpha1().APIBindings().Informer().HasSynced(), nil
+					s.ControllerKcpSharedInformerFactory.Apis().V1alpha1().APIBindings().Informer().HasSynced(), nil
 			})
 		},
 		Runner: func(ctx context.Context) {
-			c.Start(ctx, 2)
+			c.Start(ctx, s.controllerWorkers(apibinding.ControllerName, 2))
 		},
 	}); err != nil {
 		return err
@@ -798,8 +1213,8 @@ func (s *Server) installAPIBindingController(ctx context.Context, config *rest.C
 		kcpClusterClient,
 		dynamicClusterClient,
 		ddsif,
-		s.KcpSharedInformerFactory.Apis().V1alpha1().APIBindings(),
-		s.KcpSharedInformerFactory.Apis().V1alpha1().APIExports(),
+		s.ControllerKcpSharedInformerFactory.Apis().V1alpha1().APIBindings(),
+		s.ControllerKcpSharedInformerFactory.Apis().V1alpha1().APIExports(),
 		s.CacheKcpSharedInformerFactory.Apis().V1alpha1().APIExports(),
 	)
 	if err != nil {
@@ -807,16 +1222,17 @@ func (s *Server) installAPIBindingController(ctx context.Context, config *rest.C
 	}
 
 	if err := s.registerController(&controllerWrapper{
-		Name: permissionclaimlabel.ControllerName,
+		Name:  permissionclaimlabel.ControllerName,
+		Drain: permissionClaimLabelController.Drain,
 		Wait: func(ctx context.Context, s *Server) error {
 			return wait.PollUntilContextCancel(ctx, waitPollInterval, true, func(ctx context.Context) (bool, error) {
-				return s.KcpSharedInformerFactory.Apis().V1alpha1().APIBindings().Informer().HasSynced() &&
-					s.KcpSharedInformerFactory.Apis().V1alpha1().APIExports().Informer().HasSynced() &&
+				return s.ControllerKcpSharedInformerFactory.Apis().V1alpha1().APIBindings().Informer().HasSynced() &&
+					s.ControllerKcpSharedInformerFactory.Apis().V1alpha1().APIExports().Informer().HasSynced() &&
 					s.CacheKcpSharedInformerFactory.Apis().V1alpha1().APIExports().Informer().HasSynced(), nil
 			})
 		},
 		Runner: func(ctx context.Context) {
-			permissionClaimLabelController.Start(ctx, 5)
+			permissionClaimLabelController.Start(ctx, s.controllerWorkers(permissionclaimlabel.ControllerName, 5))
 		},
 	}); err != nil {
 		return err
@@ -837,8 +1253,8 @@ func (s *Server) installAPIBindingController(ctx context.Context, config *rest.C
 		kcpClusterClient,
 		dynamicClusterClient,
 		ddsif,
-		s.KcpSharedInformerFactory.Apis().V1alpha1().APIBindings(),
-		s.KcpSharedInformerFactory.Apis().V1alpha1().APIExports(),
+		s.ControllerKcpSharedInformerFactory.Apis().V1alpha1().APIBindings(),
+		s.ControllerKcpSharedInformerFactory.Apis().V1alpha1().APIExports(),
 		s.CacheKcpSharedInformerFactory.Apis().V1alpha1().APIExports(),
 	)
 	if err != nil {
@@ -849,13 +1265,13 @@ func (s *Server) installAPIBindingController(ctx context.Context, config *rest.C
 		Name: permissionclaimlabel.ResourceControllerName,
 		Wait: func(ctx context.Context, s *Server) error {
 			return wait.PollUntilContextCancel(ctx, waitPollInterval, true, func(ctx context.Context) (bool, error) {
-				return s.KcpSharedInformerFactory.Apis().V1alpha1().APIBindings().Informer().HasSynced() &&
-					s.KcpSharedInformerFactory.Apis().V1alpha1().APIExports().Informer().HasSynced() &&
+				return s.ControllerKcpSharedInformerFactory.Apis().V1alpha1().APIBindings().Informer().HasSynced() &&
+					s.ControllerKcpSharedInformerFactory.Apis().V1alpha1().APIExports().Informer().HasSynced() &&
 					s.CacheKcpSharedInformerFactory.Apis().V1alpha1().APIExports().Informer().HasSynced(), nil
 			})
 		},
 		Runner: func(ctx context.Context) {
-			permissionClaimLabelResourceController.Start(ctx, 2)
+			permissionClaimLabelResourceController.Start(ctx, s.controllerWorkers(permissionclaimlabel.ResourceControllerName, 2))
 		},
 	}); err != nil {
 		return err
@@ -875,18 +1291,19 @@ func (s *Server) installAPIBindingController(ctx context.Context, config *rest.C
 	apibindingDeletionController := apibindingdeletion.NewController(
 		metadataClient,
 		kcpClusterClient,
-		s.KcpSharedInformerFactory.Apis().V1alpha1().APIBindings(),
+		s.ControllerKcpSharedInformerFactory.Apis().V1alpha1().APIBindings(),
 	)
 
 	return s.registerController(&controllerWrapper{
-		Name: apibindingdeletion.ControllerName,
+		Name:  apibindingdeletion.ControllerName,
+		Drain: apibindingDeletionController.Drain,
 		Wait: func(ctx context.Context, s *Server) error {
 			return wait.PollUntilContextCancel(ctx, waitPollInterval, true, func(ctx context.Context) (bool, error) {
-				return s.KcpSharedInformerFactory.Apis().V1alpha1().APIBindings().Informer().HasSynced(), nil
+				return s.ControllerKcpSharedInformerFactory.Apis().V1alpha1().APIBindings().Informer().HasSynced(), nil
 			})
 		},
 		Runner: func(ctx context.Context) {
-			apibindingDeletionController.Start(ctx, 10)
+			apibindingDeletionController.Start(ctx, s.controllerWorkers(apibindingdeletion.ControllerName, 10))
 		},
 	})
 }
@@ -898,7 +1315,9 @@ func (s *Server) installAPIBinderController(ctx context.Context, config *rest.Co
 	config.Host += initializingworkspacesbuilder.URLFor(tenancyv1alpha1.WorkspaceAPIBindingsInitializer)
 
 	if !s.Options.Virtual.Enabled && s.Options.Extra.ShardVirtualWorkspaceURL != "" {
-		vwURL := fmt.Sprintf("https://%s", s.GenericConfig.ExternalAddress)
+		// talk to the standalone virtual workspace server directly. The CA and client
+		// certificate below are the ones of that server, not of the shard.
+		vwURL := strings.TrimSuffix(s.CompletedConfig.ShardVirtualWorkspaceURL(), "/")
 		if s.Options.Extra.ShardVirtualWorkspaceCAFile == "" {
 			// TODO move verification up
 			return fmt.Errorf("s.Options.Extra.ShardVirtualWorkspaceCAFile is required")
@@ -930,16 +1349,16 @@ func (s *Server) installAPIBinderController(ctx context.Context, config *rest.Co
 	// workspace.
 	initializingWorkspacesKcpInformers := kcpinformers.NewSharedInformerFactoryWithOptions(
 		informerClient,
-		resyncPeriod,
+		s.Options.Controllers.InformerResyncPeriod,
 	)
 
 	c, err := initialization.NewAPIBinder(
 		initializingWorkspacesKcpClusterClient,
 		initializingWorkspacesKcpInformers.Core().V1alpha1().LogicalClusters(),
-		s.KcpSharedInformerFactory.Tenancy().V1alpha1().WorkspaceTypes(),
+		s.ControllerKcpSharedInformerFactory.Tenancy().V1alpha1().WorkspaceTypes(),
 		s.CacheKcpSharedInformerFactory.Tenancy().V1alpha1().WorkspaceTypes(),
-		s.KcpSharedInformerFactory.Apis().V1alpha1().APIBindings(),
-		s.KcpSharedInformerFactory.Apis().V1alpha1().APIExports(),
+		s.ControllerKcpSharedInformerFactory.Apis().V1alpha1().APIBindings(),
+		s.ControllerKcpSharedInformerFactory.Apis().V1alpha1().APIExports(),
 		s.CacheKcpSharedInformerFactory.Apis().V1alpha1().APIExports(),
 	)
 	if err != nil {
@@ -950,10 +1369,10 @@ func (s *Server) installAPIBinderController(ctx context.Context, config *rest.Co
 		Name: initialization.ControllerName,
 		Wait: func(ctx context.Context, s *Server) error {
 			return wait.PollUntilContextCancel(ctx, waitPollInterval, true, func(ctx context.Context) (bool, error) {
-				return s.KcpSharedInformerFactory.Tenancy().V1alpha1().WorkspaceTypes().Informer().HasSynced() &&
+				return s.ControllerKcpSharedInformerFactory.Tenancy().V1alpha1().WorkspaceTypes().Informer().HasSynced() &&
 					s.CacheKcpSharedInformerFactory.Tenancy().V1alpha1().WorkspaceTypes().Informer().HasSynced() &&
-					s.KcpSharedInformerFactory.Apis().V1alpha1().APIBindings().Informer().HasSynced() &&
-					s.KcpSharedInformerFactory.Apis().V1alpha1().APIExports().Informer().HasSynced() &&
+					s.ControllerKcpSharedInformerFactory.Apis().V1alpha1().APIBindings().Informer().HasSynced() &&
+					s.ControllerKcpSharedInformerFactory.Apis().V1alpha1().APIExports().Informer().HasSynced() &&
 					s.CacheKcpSharedInformerFactory.Apis().V1alpha1().APIExports().Informer().HasSynced(), nil
 			})
 		},
@@ -961,7 +1380,88 @@ func (s *Server) installAPIBinderController(ctx context.Context, config *rest.Co
 			initializingWorkspacesKcpInformers.Start(ctx.Done())
 			initializingWorkspacesKcpInformers.WaitForCacheSync(ctx.Done())
 
-			c.Start(ctx, 2)
+			c.Start(ctx, s.controllerWorkers(initialization.ControllerName, 2))
+		},
+	})
+}
+
+func (s *Server) installPermissionClaimAutoAcceptController(ctx context.Context, config *rest.Config) error {
+	policy, err := permissionclaimautoaccept.ParsePolicy(s.Options.Controllers.PermissionClaimAutoAcceptPolicy)
+	if err != nil {
+		return err
+	}
+	if len(policy) == 0 {
+		return nil
+	}
+
+	config = rest.CopyConfig(config)
+	config = rest.AddUserAgent(config, permissionclaimautoaccept.ControllerName)
+	kcpClusterClient, err := kcpclientset.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+
+	c, err := permissionclaimautoaccept.NewController(
+		policy,
+		kcpClusterClient,
+		s.ControllerKcpSharedInformerFactory.Apis().V1alpha1().APIBindings(),
+		s.ControllerKcpSharedInformerFactory.Apis().V1alpha1().APIExports(),
+		s.CacheKcpSharedInformerFactory.Apis().V1alpha1().APIExports(),
+	)
+	if err != nil {
+		return err
+	}
+
+	return s.registerController(&controllerWrapper{
+		Name:  permissionclaimautoaccept.ControllerName,
+		Drain: c.Drain,
+		Wait: func(ctx context.Context, s *Server) error {
+			return wait.PollUntilContextCancel(ctx, waitPollInterval, true, func(ctx context.Context) (bool, error) {
+				return s.ControllerKcpSharedInformerFactory.Apis().V1alpha1().APIBindings().Informer().HasSynced() &&
+					s.ControllerKcpSharedInformerFactory.Apis().V1alpha1().APIExports().Informer().HasSynced() &&
+					s.CacheKcpSharedInformerFactory.Apis().V1alpha1().APIExports().Informer().HasSynced(), nil
+			})
+		},
+		Runner: func(ctx context.Context) {
+			c.Start(ctx, s.controllerWorkers(permissionclaimautoaccept.ControllerName, 2))
+		},
+	})
+}
+
+func (s *Server) installSchemaCompatibilityController(ctx context.Context, config *rest.Config) error {
+	config = rest.CopyConfig(config)
+	config = rest.AddUserAgent(config, schemacompatibility.ControllerName)
+	kcpClusterClient, err := kcpclientset.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+
+	c, err := schemacompatibility.NewController(
+		kcpClusterClient,
+		s.ControllerKcpSharedInformerFactory.Apis().V1alpha1().APIBindings(),
+		s.ControllerKcpSharedInformerFactory.Apis().V1alpha1().APIExports(),
+		s.ControllerKcpSharedInformerFactory.Apis().V1alpha1().APIResourceSchemas(),
+		s.CacheKcpSharedInformerFactory.Apis().V1alpha1().APIExports(),
+		s.CacheKcpSharedInformerFactory.Apis().V1alpha1().APIResourceSchemas(),
+	)
+	if err != nil {
+		return err
+	}
+
+	return s.registerController(&controllerWrapper{
+		Name:  schemacompatibility.ControllerName,
+		Drain: c.Drain,
+		Wait: func(ctx context.Context, s *Server) error {
+			return wait.PollUntilContextCancel(ctx, waitPollInterval, true, func(ctx context.Context) (bool, error) {
+				return s.ControllerKcpSharedInformerFactory.Apis().V1alpha1().APIBindings().Informer().HasSynced() &&
+					s.ControllerKcpSharedInformerFactory.Apis().V1alpha1().APIExports().Informer().HasSynced() &&
+					s.ControllerKcpSharedInformerFactory.Apis().V1alpha1().APIResourceSchemas().Informer().HasSynced() &&
+					s.CacheKcpSharedInformerFactory.Apis().V1alpha1().APIExports().Informer().HasSynced() &&
+					s.CacheKcpSharedInformerFactory.Apis().V1alpha1().APIResourceSchemas().Informer().HasSynced(), nil
+			})
+		},
+		Runner: func(ctx context.Context) {
+			c.Start(ctx, s.controllerWorkers(schemacompatibility.ControllerName, 2))
 		},
 	})
 }
@@ -976,24 +1476,53 @@ func (s *Server) installCRDCleanupController(ctx context.Context, config *rest.C
 	}
 
 	c, err := crdcleanup.NewController(
-		s.ApiExtensionsSharedInformerFactory.Apiextensions().V1().CustomResourceDefinitions(),
+		s.ControllerApiExtensionsSharedInformerFactory.Apiextensions().V1().CustomResourceDefinitions(),
 		crdClusterClient,
-		s.KcpSharedInformerFactory.Apis().V1alpha1().APIBindings(),
+		s.ControllerKcpSharedInformerFactory.Apis().V1alpha1().APIBindings(),
 	)
 	if err != nil {
 		return err
 	}
 
 	return s.registerController(&controllerWrapper{
-		Name: crdcleanup.ControllerName,
+		Name:  crdcleanup.ControllerName,
+		Drain: c.Drain,
 		Wait: func(ctx context.Context, s *Server) error {
 			return wait.PollUntilContextCancel(ctx, waitPollInterval, true, func(ctx context.Context) (bool, error) {
-				return s.ApiExtensionsSharedInformerFactory.Apiextensions().V1().CustomResourceDefinitions().Informer().HasSynced() &&
-					s.KcpSharedInformerFactory.Apis().V1alpha1().APIBindings().Informer().HasSynced(), nil
+				return s.ControllerApiExtensionsSharedInformerFactory.Apiextensions().V1().CustomResourceDefinitions().Informer().HasSynced() &&
+					s.ControllerKcpSharedInformerFactory.Apis().V1alpha1().APIBindings().Informer().HasSynced(), nil
 			})
 		},
 		Runner: func(ctx context.Context) {
-			c.Start(ctx, 2)
+			c.Start(ctx, s.controllerWorkers(crdcleanup.ControllerName, 2))
+		},
+	})
+}
+
+func (s *Server) installSystemCRDRepairController(ctx context.Context, config *rest.Config) error {
+	config = rest.CopyConfig(config)
+	config = rest.AddUserAgent(config, systemcrdrepair.ControllerName)
+
+	crdClusterClient, err := kcpapiextensionsclientset.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+
+	c, err := systemcrdrepair.NewController(
+		SystemCRDClusterName,
+		crdClusterClient,
+		s.ControllerApiExtensionsSharedInformerFactory.Apiextensions().V1().CustomResourceDefinitions(),
+	)
+	if err != nil {
+		return err
+	}
+
+	// no custom wait, the system CRDs are bootstrapped before the informers are reported synced.
+	return s.registerController(&controllerWrapper{
+		Name:  systemcrdrepair.ControllerName,
+		Drain: c.Drain,
+		Runner: func(ctx context.Context) {
+			c.Start(ctx, s.controllerWorkers(systemcrdrepair.ControllerName, 1))
 		},
 	})
 }
@@ -1009,25 +1538,26 @@ func (s *Server) installLogicalClusterCleanupController(ctx context.Context, con
 
 	c, err := logicalclustercleanup.NewController(
 		kcpClusterClient,
-		s.KcpSharedInformerFactory.Core().V1alpha1().LogicalClusters(),
-		s.ApiExtensionsSharedInformerFactory.Apiextensions().V1().CustomResourceDefinitions(),
-		s.KcpSharedInformerFactory.Apis().V1alpha1().APIBindings(),
+		s.ControllerKcpSharedInformerFactory.Core().V1alpha1().LogicalClusters(),
+		s.ControllerApiExtensionsSharedInformerFactory.Apiextensions().V1().CustomResourceDefinitions(),
+		s.ControllerKcpSharedInformerFactory.Apis().V1alpha1().APIBindings(),
 	)
 	if err != nil {
 		return err
 	}
 
 	return s.registerController(&controllerWrapper{
-		Name: logicalclustercleanup.ControllerName,
+		Name:  logicalclustercleanup.ControllerName,
+		Drain: c.Drain,
 		Wait: func(ctx context.Context, s *Server) error {
 			return wait.PollUntilContextCancel(ctx, waitPollInterval, true, func(ctx context.Context) (bool, error) {
-				return s.KcpSharedInformerFactory.Core().V1alpha1().LogicalClusters().Informer().HasSynced() &&
-					s.ApiExtensionsSharedInformerFactory.Apiextensions().V1().CustomResourceDefinitions().Informer().HasSynced() &&
-					s.KcpSharedInformerFactory.Apis().V1alpha1().APIBindings().Informer().HasSynced(), nil
+				return s.ControllerKcpSharedInformerFactory.Core().V1alpha1().LogicalClusters().Informer().HasSynced() &&
+					s.ControllerApiExtensionsSharedInformerFactory.Apiextensions().V1().CustomResourceDefinitions().Informer().HasSynced() &&
+					s.ControllerKcpSharedInformerFactory.Apis().V1alpha1().APIBindings().Informer().HasSynced(), nil
 			})
 		},
 		Runner: func(ctx context.Context) {
-			c.Start(ctx, 2)
+			c.Start(ctx, s.controllerWorkers(logicalclustercleanup.ControllerName, 2))
 		},
 	})
 }
@@ -1046,7 +1576,7 @@ func (s *Server) installAPIExportController(ctx context.Context, config *rest.Co
 
 	c, err := apiexport.NewController(
 		kcpClusterClient,
-		s.KcpSharedInformerFactory.Apis().V1alpha1().APIExports(),
+		s.ControllerKcpSharedInformerFactory.Apis().V1alpha1().APIExports(),
 		s.CacheKcpSharedInformerFactory.Core().V1alpha1().Shards(),
 		kubeClusterClient,
 		s.KubeSharedInformerFactory.Core().V1().Namespaces(),
@@ -1057,20 +1587,160 @@ func (s *Server) installAPIExportController(ctx context.Context, config *rest.Co
 	}
 
 	return s.registerController(&controllerWrapper{
-		Name: apiexport.ControllerName,
+		Name:  apiexport.ControllerName,
+		Drain: c.Drain,
 		Wait: func(ctx context.Context, s *Server) error {
 			// do custom wait logic here because APIExports+APIBindings are special as system CRDs,
 			// and the controllers must run as soon as these two informers are up in order to bootstrap
 			// the rest of the system. Everything else in the kcp clientset is APIBinding based.
 			return wait.PollUntilContextCancel(ctx, waitPollInterval, true, func(ctx context.Context) (bool, error) {
-				return s.ApiExtensionsSharedInformerFactory.Apiextensions().V1().CustomResourceDefinitions().Informer().HasSynced() &&
-					s.KcpSharedInformerFactory.Apis().V1alpha1().APIExports().Informer().HasSynced() &&
+				return s.ControllerApiExtensionsSharedInformerFactory.Apiextensions().V1().CustomResourceDefinitions().Informer().HasSynced() &&
+					s.ControllerKcpSharedInformerFactory.Apis().V1alpha1().APIExports().Informer().HasSynced() &&
 					s.KubeSharedInformerFactory.Core().V1().Namespaces().Informer().HasSynced() &&
 					s.KubeSharedInformerFactory.Core().V1().Secrets().Informer().HasSynced(), nil
 			})
 		},
 		Runner: func(ctx context.Context) {
-			c.Start(ctx, 2)
+			c.Start(ctx, s.controllerWorkers(apiexport.ControllerName, 2))
+		},
+	})
+}
+
+func (s *Server) installAPIExportIdentityConflictController(ctx context.Context, config *rest.Config) error {
+	config = rest.CopyConfig(config)
+	config = rest.AddUserAgent(config, identityconflict.ControllerName)
+	kcpClusterClient, err := kcpclientset.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+
+	c, err := identityconflict.NewController(
+		kcpClusterClient,
+		s.ControllerKcpSharedInformerFactory.Apis().V1alpha1().APIExports(),
+		s.CacheKcpSharedInformerFactory.Apis().V1alpha1().APIExports(),
+	)
+	if err != nil {
+		return err
+	}
+
+	return s.registerController(&controllerWrapper{
+		Name:  identityconflict.ControllerName,
+		Drain: c.Drain,
+		Wait: func(ctx context.Context, s *Server) error {
+			return wait.PollUntilContextCancel(ctx, waitPollInterval, true, func(ctx context.Context) (bool, error) {
+				return s.ControllerKcpSharedInformerFactory.Apis().V1alpha1().APIExports().Informer().HasSynced() &&
+					s.CacheKcpSharedInformerFactory.Apis().V1alpha1().APIExports().Informer().HasSynced(), nil
+			})
+		},
+		Runner: func(ctx context.Context) {
+			c.Start(ctx, s.controllerWorkers(identityconflict.ControllerName, 2))
+		},
+	})
+}
+
+func (s *Server) installAPIExportExpiryController(ctx context.Context, config *rest.Config) error {
+	if s.Options.Controllers.APIExportExpiryAge == 0 {
+		return nil
+	}
+
+	config = rest.CopyConfig(config)
+	config = rest.AddUserAgent(config, apiexportexpiry.ControllerName)
+	kcpClusterClient, err := kcpclientset.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+
+	c, err := apiexportexpiry.NewController(
+		s.Options.Controllers.APIExportExpiryAge,
+		s.Options.Controllers.PruneExpiredAPIExports,
+		kcpClusterClient,
+		s.ControllerKcpSharedInformerFactory.Apis().V1alpha1().APIExports(),
+		s.ControllerKcpSharedInformerFactory.Apis().V1alpha1().APIBindings(),
+		s.CacheKcpSharedInformerFactory.Core().V1alpha1().Shards(),
+	)
+	if err != nil {
+		return err
+	}
+
+	return s.registerController(&controllerWrapper{
+		Name:  apiexportexpiry.ControllerName,
+		Drain: c.Drain,
+		Wait: func(ctx context.Context, s *Server) error {
+			return wait.PollUntilContextCancel(ctx, waitPollInterval, true, func(ctx context.Context) (bool, error) {
+				return s.ControllerKcpSharedInformerFactory.Apis().V1alpha1().APIExports().Informer().HasSynced() &&
+					s.ControllerKcpSharedInformerFactory.Apis().V1alpha1().APIBindings().Informer().HasSynced() &&
+					s.CacheKcpSharedInformerFactory.Core().V1alpha1().Shards().Informer().HasSynced(), nil
+			})
+		},
+		Runner: func(ctx context.Context) {
+			c.Start(ctx, s.controllerWorkers(apiexportexpiry.ControllerName, 2))
+		},
+	})
+}
+
+func (s *Server) installAPIExportBindingCountController(ctx context.Context, config *rest.Config) error {
+	config = rest.CopyConfig(config)
+	config = rest.AddUserAgent(config, apiexportbindingcount.ControllerName)
+	kcpClusterClient, err := kcpclientset.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+
+	c, err := apiexportbindingcount.NewController(
+		kcpClusterClient,
+		s.ControllerKcpSharedInformerFactory.Apis().V1alpha1().APIExports(),
+		s.ControllerKcpSharedInformerFactory.Apis().V1alpha1().APIBindings(),
+	)
+	if err != nil {
+		return err
+	}
+
+	return s.registerController(&controllerWrapper{
+		Name:  apiexportbindingcount.ControllerName,
+		Drain: c.Drain,
+		Wait: func(ctx context.Context, s *Server) error {
+			return wait.PollUntilContextCancel(ctx, waitPollInterval, true, func(ctx context.Context) (bool, error) {
+				return s.ControllerKcpSharedInformerFactory.Apis().V1alpha1().APIExports().Informer().HasSynced() &&
+					s.ControllerKcpSharedInformerFactory.Apis().V1alpha1().APIBindings().Informer().HasSynced(), nil
+			})
+		},
+		Runner: func(ctx context.Context) {
+			c.Start(ctx, s.controllerWorkers(apiexportbindingcount.ControllerName, 2))
+		},
+	})
+}
+
+func (s *Server) installAPIExportDeletionController(ctx context.Context, config *rest.Config) error {
+	config = rest.CopyConfig(config)
+	config = rest.AddUserAgent(config, apiexportdeletion.ControllerName)
+	kcpClusterClient, err := kcpclientset.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+	kubeClusterClient, err := kcpkubernetesclientset.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+
+	c, err := apiexportdeletion.NewController(
+		kcpClusterClient,
+		kubeClusterClient,
+		s.ControllerKcpSharedInformerFactory.Apis().V1alpha1().APIExports(),
+	)
+	if err != nil {
+		return err
+	}
+
+	return s.registerController(&controllerWrapper{
+		Name:  apiexportdeletion.ControllerName,
+		Drain: c.Drain,
+		Wait: func(ctx context.Context, s *Server) error {
+			return wait.PollUntilContextCancel(ctx, waitPollInterval, true, func(ctx context.Context) (bool, error) {
+				return s.ControllerKcpSharedInformerFactory.Apis().V1alpha1().APIExports().Informer().HasSynced(), nil
+			})
+		},
+		Runner: func(ctx context.Context) {
+			c.Start(ctx, s.controllerWorkers(apiexportdeletion.ControllerName, 2))
 		},
 	})
 }
@@ -1090,7 +1760,8 @@ func (s *Server) installApisReplicateClusterRoleControllers(ctx context.Context,
 	)
 
 	return s.registerController(&controllerWrapper{
-		Name: apisreplicateclusterrole.ControllerName,
+		Name:  apisreplicateclusterrole.ControllerName,
+		Drain: c.Drain,
 		Wait: func(ctx context.Context, s *Server) error {
 			return wait.PollUntilContextCancel(ctx, waitPollInterval, true, func(ctx context.Context) (bool, error) {
 				return s.KubeSharedInformerFactory.Rbac().V1().ClusterRoles().Informer().HasSynced() &&
@@ -1098,7 +1769,7 @@ func (s *Server) installApisReplicateClusterRoleControllers(ctx context.Context,
 			})
 		},
 		Runner: func(ctx context.Context) {
-			c.Start(ctx, 2)
+			c.Start(ctx, s.controllerWorkers(apisreplicateclusterrole.ControllerName, 2))
 		},
 	})
 }
@@ -1115,20 +1786,21 @@ func (s *Server) installCoreReplicateClusterRoleControllers(ctx context.Context,
 		kubeClusterClient,
 		s.KubeSharedInformerFactory.Rbac().V1().ClusterRoles(),
 		s.KubeSharedInformerFactory.Rbac().V1().ClusterRoleBindings(),
-		s.KcpSharedInformerFactory.Core().V1alpha1().LogicalClusters(),
+		s.ControllerKcpSharedInformerFactory.Core().V1alpha1().LogicalClusters(),
 	)
 
 	return s.registerController(&controllerWrapper{
-		Name: coresreplicateclusterrole.ControllerName,
+		Name:  coresreplicateclusterrole.ControllerName,
+		Drain: c.Drain,
 		Wait: func(ctx context.Context, s *Server) error {
 			return wait.PollUntilContextCancel(ctx, waitPollInterval, true, func(ctx context.Context) (bool, error) {
 				return s.KubeSharedInformerFactory.Rbac().V1().ClusterRoles().Informer().HasSynced() &&
 					s.KubeSharedInformerFactory.Rbac().V1().ClusterRoleBindings().Informer().HasSynced() &&
-					s.KcpSharedInformerFactory.Core().V1alpha1().LogicalClusters().Informer().HasSynced(), nil
+					s.ControllerKcpSharedInformerFactory.Core().V1alpha1().LogicalClusters().Informer().HasSynced(), nil
 			})
 		},
 		Runner: func(ctx context.Context) {
-			c.Start(ctx, 2)
+			c.Start(ctx, s.controllerWorkers(coresreplicateclusterrole.ControllerName, 2))
 		},
 	})
 }
@@ -1148,7 +1820,8 @@ func (s *Server) installApisReplicateClusterRoleBindingControllers(ctx context.C
 	)
 
 	return s.registerController(&controllerWrapper{
-		Name: apisreplicateclusterrolebinding.ControllerName,
+		Name:  apisreplicateclusterrolebinding.ControllerName,
+		Drain: c.Drain,
 		Wait: func(ctx context.Context, s *Server) error {
 			return wait.PollUntilContextCancel(ctx, waitPollInterval, true, func(ctx context.Context) (bool, error) {
 				return s.KubeSharedInformerFactory.Rbac().V1().ClusterRoles().Informer().HasSynced() &&
@@ -1156,7 +1829,7 @@ func (s *Server) installApisReplicateClusterRoleBindingControllers(ctx context.C
 			})
 		},
 		Runner: func(ctx context.Context) {
-			c.Start(ctx, 2)
+			c.Start(ctx, s.controllerWorkers(apisreplicateclusterrolebinding.ControllerName, 2))
 		},
 	})
 }
@@ -1171,20 +1844,21 @@ func (s *Server) installApisReplicateLogicalClusterControllers(ctx context.Conte
 
 	c := apisreplicatelogicalcluster.NewController(
 		kcpClusterClient,
-		s.KcpSharedInformerFactory.Core().V1alpha1().LogicalClusters(),
-		s.KcpSharedInformerFactory.Apis().V1alpha1().APIExports(),
+		s.ControllerKcpSharedInformerFactory.Core().V1alpha1().LogicalClusters(),
+		s.ControllerKcpSharedInformerFactory.Apis().V1alpha1().APIExports(),
 	)
 
 	return s.registerController(&controllerWrapper{
-		Name: apisreplicatelogicalcluster.ControllerName,
+		Name:  apisreplicatelogicalcluster.ControllerName,
+		Drain: c.Drain,
 		Wait: func(ctx context.Context, s *Server) error {
 			return wait.PollUntilContextCancel(ctx, waitPollInterval, true, func(ctx context.Context) (bool, error) {
-				return s.KcpSharedInformerFactory.Apis().V1alpha1().APIExports().Informer().HasSynced() &&
-					s.KcpSharedInformerFactory.Core().V1alpha1().LogicalClusters().Informer().HasSynced(), nil
+				return s.ControllerKcpSharedInformerFactory.Apis().V1alpha1().APIExports().Informer().HasSynced() &&
+					s.ControllerKcpSharedInformerFactory.Core().V1alpha1().LogicalClusters().Informer().HasSynced(), nil
 			})
 		},
 		Runner: func(ctx context.Context) {
-			c.Start(ctx, 2)
+			c.Start(ctx, s.controllerWorkers(apisreplicatelogicalcluster.ControllerName, 2))
 		},
 	})
 }
@@ -1199,20 +1873,21 @@ func (s *Server) installTenancyReplicateLogicalClusterControllers(ctx context.Co
 
 	c := tenancyreplicatelogicalcluster.NewController(
 		kcpClusterClient,
-		s.KcpSharedInformerFactory.Core().V1alpha1().LogicalClusters(),
-		s.KcpSharedInformerFactory.Tenancy().V1alpha1().WorkspaceTypes(),
+		s.ControllerKcpSharedInformerFactory.Core().V1alpha1().LogicalClusters(),
+		s.ControllerKcpSharedInformerFactory.Tenancy().V1alpha1().WorkspaceTypes(),
 	)
 
 	return s.registerController(&controllerWrapper{
-		Name: tenancyreplicatelogicalcluster.ControllerName,
+		Name:  tenancyreplicatelogicalcluster.ControllerName,
+		Drain: c.Drain,
 		Wait: func(ctx context.Context, s *Server) error {
 			return wait.PollUntilContextCancel(ctx, waitPollInterval, true, func(ctx context.Context) (bool, error) {
-				return s.KcpSharedInformerFactory.Tenancy().V1alpha1().WorkspaceTypes().Informer().HasSynced() &&
-					s.KcpSharedInformerFactory.Core().V1alpha1().LogicalClusters().Informer().HasSynced(), nil
+				return s.ControllerKcpSharedInformerFactory.Tenancy().V1alpha1().WorkspaceTypes().Informer().HasSynced() &&
+					s.ControllerKcpSharedInformerFactory.Core().V1alpha1().LogicalClusters().Informer().HasSynced(), nil
 			})
 		},
 		Runner: func(ctx context.Context) {
-			c.Start(ctx, 2)
+			c.Start(ctx, s.controllerWorkers(tenancyreplicatelogicalcluster.ControllerName, 2))
 		},
 	})
 }
@@ -1229,20 +1904,21 @@ func (s *Server) installCoreReplicateClusterRoleBindingControllers(ctx context.C
 		kubeClusterClient,
 		s.KubeSharedInformerFactory.Rbac().V1().ClusterRoleBindings(),
 		s.KubeSharedInformerFactory.Rbac().V1().ClusterRoles(),
-		s.KcpSharedInformerFactory.Core().V1alpha1().LogicalClusters(),
+		s.ControllerKcpSharedInformerFactory.Core().V1alpha1().LogicalClusters(),
 	)
 
 	return s.registerController(&controllerWrapper{
-		Name: corereplicateclusterrolebinding.ControllerName,
+		Name:  corereplicateclusterrolebinding.ControllerName,
+		Drain: c.Drain,
 		Wait: func(ctx context.Context, s *Server) error {
 			return wait.PollUntilContextCancel(ctx, waitPollInterval, true, func(ctx context.Context) (bool, error) {
 				return s.KubeSharedInformerFactory.Rbac().V1().ClusterRoles().Informer().HasSynced() &&
 					s.KubeSharedInformerFactory.Rbac().V1().ClusterRoleBindings().Informer().HasSynced() &&
-					s.KcpSharedInformerFactory.Core().V1alpha1().LogicalClusters().Informer().HasSynced(), nil
+					s.ControllerKcpSharedInformerFactory.Core().V1alpha1().LogicalClusters().Informer().HasSynced(), nil
 			})
 		},
 		Runner: func(ctx context.Context) {
-			c.Start(ctx, 2)
+			c.Start(ctx, s.controllerWorkers(corereplicateclusterrolebinding.ControllerName, 2))
 		},
 	})
 }
@@ -1262,7 +1938,8 @@ func (s *Server) installTenancyReplicateClusterRoleControllers(ctx context.Conte
 	)
 
 	return s.registerController(&controllerWrapper{
-		Name: tenancyreplicateclusterrole.ControllerName,
+		Name:  tenancyreplicateclusterrole.ControllerName,
+		Drain: c.Drain,
 		Wait: func(ctx context.Context, s *Server) error {
 			return wait.PollUntilContextCancel(ctx, waitPollInterval, true, func(ctx context.Context) (bool, error) {
 				return s.KubeSharedInformerFactory.Rbac().V1().ClusterRoles().Informer().HasSynced() &&
@@ -1270,7 +1947,7 @@ func (s *Server) installTenancyReplicateClusterRoleControllers(ctx context.Conte
 			})
 		},
 		Runner: func(ctx context.Context) {
-			c.Start(ctx, 2)
+			c.Start(ctx, s.controllerWorkers(tenancyreplicateclusterrole.ControllerName, 2))
 		},
 	})
 }
@@ -1290,7 +1967,8 @@ func (s *Server) installTenancyReplicateClusterRoleBindingControllers(ctx contex
 	)
 
 	return s.registerController(&controllerWrapper{
-		Name: tenancyreplicateclusterrolebinding.ControllerName,
+		Name:  tenancyreplicateclusterrolebinding.ControllerName,
+		Drain: c.Drain,
 		Wait: func(ctx context.Context, s *Server) error {
 			return wait.PollUntilContextCancel(ctx, waitPollInterval, true, func(ctx context.Context) (bool, error) {
 				return s.KubeSharedInformerFactory.Rbac().V1().ClusterRoles().Informer().HasSynced() &&
@@ -1298,7 +1976,7 @@ func (s *Server) installTenancyReplicateClusterRoleBindingControllers(ctx contex
 			})
 		},
 		Runner: func(ctx context.Context) {
-			c.Start(ctx, 2)
+			c.Start(ctx, s.controllerWorkers(tenancyreplicateclusterrolebinding.ControllerName, 2))
 		},
 	})
 }
@@ -1313,10 +1991,10 @@ func (s *Server) installAPIExportEndpointSliceController(_ context.Context, conf
 	}
 
 	c, err := apiexportendpointslice.NewController(
-		s.KcpSharedInformerFactory.Apis().V1alpha1().APIExportEndpointSlices(),
+		s.ControllerKcpSharedInformerFactory.Apis().V1alpha1().APIExportEndpointSlices(),
 		// Shards and APIExports get retrieved from cache server
 		s.CacheKcpSharedInformerFactory.Apis().V1alpha1().APIExports(),
-		s.KcpSharedInformerFactory.Topology().V1alpha1().Partitions(),
+		s.ControllerKcpSharedInformerFactory.Topology().V1alpha1().Partitions(),
 		kcpClusterClient,
 	)
 	if err != nil {
@@ -1324,16 +2002,17 @@ func (s *Server) installAPIExportEndpointSliceController(_ context.Context, conf
 	}
 
 	return s.registerController(&controllerWrapper{
-		Name: apiexportendpointslice.ControllerName,
+		Name:  apiexportendpointslice.ControllerName,
+		Drain: c.Drain,
 		Wait: func(ctx context.Context, s *Server) error {
 			return wait.PollUntilContextCancel(ctx, waitPollInterval, true, func(ctx context.Context) (bool, error) {
-				return s.KcpSharedInformerFactory.Apis().V1alpha1().APIExportEndpointSlices().Informer().HasSynced() &&
+				return s.ControllerKcpSharedInformerFactory.Apis().V1alpha1().APIExportEndpointSlices().Informer().HasSynced() &&
 					s.CacheKcpSharedInformerFactory.Apis().V1alpha1().APIExports().Informer().HasSynced() &&
-					s.KcpSharedInformerFactory.Topology().V1alpha1().Partitions().Informer().HasSynced(), nil
+					s.ControllerKcpSharedInformerFactory.Topology().V1alpha1().Partitions().Informer().HasSynced(), nil
 			})
 		},
 		Runner: func(ctx context.Context) {
-			c.Start(ctx, 2)
+			c.Start(ctx, s.controllerWorkers(apiexportendpointslice.ControllerName, 2))
 		},
 	})
 }
@@ -1349,8 +2028,8 @@ func (s *Server) installAPIExportEndpointSliceURLsController(_ context.Context,
 
 	c, err := apiexportendpointsliceurls.NewController(
 		s.Options.Extra.ShardName,
-		s.KcpSharedInformerFactory.Apis().V1alpha1().APIExportEndpointSlices(),
-		s.KcpSharedInformerFactory.Apis().V1alpha1().APIBindings(),
+		s.ControllerKcpSharedInformerFactory.Apis().V1alpha1().APIExportEndpointSlices(),
+		s.ControllerKcpSharedInformerFactory.Apis().V1alpha1().APIBindings(),
 		// Shards and APIExports get retrieved from cache server
 		s.CacheKcpSharedInformerFactory.Apis().V1alpha1().APIExportEndpointSlices(),
 		s.CacheKcpSharedInformerFactory.Core().V1alpha1().Shards(),
@@ -1362,18 +2041,19 @@ func (s *Server) installAPIExportEndpointSliceURLsController(_ context.Context,
 	}
 
 	return s.registerController(&controllerWrapper{
-		Name: apiexportendpointsliceurls.ControllerName,
+		Name:  apiexportendpointsliceurls.ControllerName,
+		Drain: c.Drain,
 		Wait: func(ctx context.Context, s *Server) error {
 			return wait.PollUntilContextCancel(ctx, waitPollInterval, true, func(ctx context.Context) (bool, error) {
 				return s.CacheKcpSharedInformerFactory.Core().V1alpha1().Shards().Informer().HasSynced() &&
-					s.KcpSharedInformerFactory.Apis().V1alpha1().APIExportEndpointSlices().Informer().HasSynced() &&
+					s.ControllerKcpSharedInformerFactory.Apis().V1alpha1().APIExportEndpointSlices().Informer().HasSynced() &&
 					s.CacheKcpSharedInformerFactory.Apis().V1alpha1().APIExportEndpointSlices().Informer().HasSynced() &&
 					s.CacheKcpSharedInformerFactory.Apis().V1alpha1().APIExports().Informer().HasSynced() &&
-					s.KcpSharedInformerFactory.Apis().V1alpha1().APIBindings().Informer().HasSynced(), nil
+					s.ControllerKcpSharedInformerFactory.Apis().V1alpha1().APIBindings().Informer().HasSynced(), nil
 			})
 		},
 		Runner: func(ctx context.Context) {
-			c.Start(ctx, 2)
+			c.Start(ctx, s.controllerWorkers(apiexportendpointsliceurls.ControllerName, 2))
 		},
 	})
 }
@@ -1388,8 +2068,8 @@ func (s *Server) installPartitionSetController(ctx context.Context, config *rest
 	}
 
 	c, err := partitionset.NewController(
-		s.KcpSharedInformerFactory.Topology().V1alpha1().PartitionSets(),
-		s.KcpSharedInformerFactory.Topology().V1alpha1().Partitions(),
+		s.ControllerKcpSharedInformerFactory.Topology().V1alpha1().PartitionSets(),
+		s.ControllerKcpSharedInformerFactory.Topology().V1alpha1().Partitions(),
 		s.CacheKcpSharedInformerFactory.Core().V1alpha1().Shards(),
 		kcpClusterClient,
 	)
@@ -1398,16 +2078,17 @@ func (s *Server) installPartitionSetController(ctx context.Context, config *rest
 	}
 
 	return s.registerController(&controllerWrapper{
-		Name: partitionset.ControllerName,
+		Name:  partitionset.ControllerName,
+		Drain: c.Drain,
 		Wait: func(ctx context.Context, s *Server) error {
 			return wait.PollUntilContextCancel(ctx, waitPollInterval, true, func(ctx context.Context) (bool, error) {
-				return s.KcpSharedInformerFactory.Topology().V1alpha1().PartitionSets().Informer().HasSynced() &&
-					s.KcpSharedInformerFactory.Topology().V1alpha1().Partitions().Informer().HasSynced() &&
+				return s.ControllerKcpSharedInformerFactory.Topology().V1alpha1().PartitionSets().Informer().HasSynced() &&
+					s.ControllerKcpSharedInformerFactory.Topology().V1alpha1().Partitions().Informer().HasSynced() &&
 					s.CacheKcpSharedInformerFactory.Core().V1alpha1().Shards().Informer().HasSynced(), nil
 			})
 		},
 		Runner: func(ctx context.Context) {
-			c.Start(ctx, 2)
+			c.Start(ctx, s.controllerWorkers(partitionset.ControllerName, 2))
 		},
 	})
 }
@@ -1421,23 +2102,24 @@ func (s *Server) installExtraAnnotationSyncController(ctx context.Context, confi
 	}
 
 	c, err := extraannotationsync.NewController(kcpClusterClient,
-		s.KcpSharedInformerFactory.Apis().V1alpha1().APIExports(),
-		s.KcpSharedInformerFactory.Apis().V1alpha1().APIBindings(),
+		s.ControllerKcpSharedInformerFactory.Apis().V1alpha1().APIExports(),
+		s.ControllerKcpSharedInformerFactory.Apis().V1alpha1().APIBindings(),
 	)
 	if err != nil {
 		return err
 	}
 
 	return s.registerController(&controllerWrapper{
-		Name: extraannotationsync.ControllerName,
+		Name:  extraannotationsync.ControllerName,
+		Drain: c.Drain,
 		Wait: func(ctx context.Context, s *Server) error {
 			return wait.PollUntilContextCancel(ctx, waitPollInterval, true, func(ctx context.Context) (bool, error) {
-				return s.KcpSharedInformerFactory.Apis().V1alpha1().APIExports().Informer().HasSynced() &&
-					s.KcpSharedInformerFactory.Apis().V1alpha1().APIBindings().Informer().HasSynced(), nil
+				return s.ControllerKcpSharedInformerFactory.Apis().V1alpha1().APIExports().Informer().HasSynced() &&
+					s.ControllerKcpSharedInformerFactory.Apis().V1alpha1().APIBindings().Informer().HasSynced(), nil
 			})
 		},
 		Runner: func(ctx context.Context) {
-			c.Start(ctx, 2)
+			c.Start(ctx, s.controllerWorkers(extraannotationsync.ControllerName, 2))
 		},
 	})
 }
@@ -1447,27 +2129,24 @@ func (s *Server) installKubeQuotaController(
 	config *rest.Config,
 ) error {
 	config = rest.CopyConfig(config)
-	// TODO(ncdc): figure out if we need config
+	// No multi-cluster round tripper needed: the cluster-aware client is scoped to the
+	// logical cluster of each per-workspace quota controller via Cluster().
 	config = rest.AddUserAgent(config, kubequota.ControllerName)
 	kubeClusterClient, err := kcpkubernetesclientset.NewForConfig(config)
 	if err != nil {
 		return err
 	}
 
-	// TODO(ncdc): should we make these configurable?
-	const (
-		quotaResyncPeriod        = 5 * time.Minute
-		replenishmentPeriod      = 12 * time.Hour
-		workersPerLogicalCluster = 1
-	)
+	const workersPerLogicalCluster = 1
 
 	c, err := kubequota.NewController(
-		s.KcpSharedInformerFactory.Core().V1alpha1().LogicalClusters(),
+		s.ControllerKcpSharedInformerFactory.Core().V1alpha1().LogicalClusters(),
 		kubeClusterClient,
 		s.KubeSharedInformerFactory,
 		s.DiscoveringDynamicSharedInformerFactory,
-		quotaResyncPeriod,
-		replenishmentPeriod,
+		s.Options.Controllers.KubeQuota.ResyncPeriod,
+		s.Options.Controllers.KubeQuota.ReplenishmentPeriod,
+		s.Options.Controllers.KubeQuotaResources(),
 		workersPerLogicalCluster,
 		s.syncedCh,
 	)
@@ -1476,19 +2155,20 @@ func (s *Server) installKubeQuotaController(
 	}
 
 	return s.registerController(&controllerWrapper{
-		Name: kubequota.ControllerName,
+		Name:  kubequota.ControllerName,
+		Drain: c.Drain,
 		Wait: func(ctx context.Context, s *Server) error {
 			return wait.PollUntilContextCancel(ctx, waitPollInterval, true, func(ctx context.Context) (bool, error) {
 				_, notSynced := s.DiscoveringDynamicSharedInformerFactory.Informers()
 				if len(notSynced) > 0 {
 					return false, nil
 				}
-				return s.KcpSharedInformerFactory.Core().V1alpha1().LogicalClusters().Informer().HasSynced() &&
+				return s.ControllerKcpSharedInformerFactory.Core().V1alpha1().LogicalClusters().Informer().HasSynced() &&
 					s.KubeSharedInformerFactory.Core().V1().ResourceQuotas().Informer().HasSynced(), nil
 			})
 		},
 		Runner: func(ctx context.Context) {
-			c.Start(ctx, 2)
+			c.Start(ctx, s.controllerWorkers(kubequota.ControllerName, 2))
 		},
 	})
 }
@@ -1516,7 +2196,7 @@ func (s *Server) installApiExportIdentityController(ctx context.Context, config
 			})
 		},
 		Runner: func(ctx context.Context) {
-			c.Start(ctx, 1)
+			c.Start(ctx, s.controllerWorkers(identitycache.ControllerName, 1))
 		},
 	})
 }
@@ -1541,7 +2221,18 @@ func (s *Server) installReplicationController(ctx context.Context, config *rest.
 			})
 		},
 		Runner: func(ctx context.Context) {
-			controller.Start(ctx, 2)
+			workers := s.controllerWorkers(replication.ControllerName, 2)
+			if !s.Options.Controllers.ReplicationOneShot {
+				controller.Start(ctx, workers)
+				return
+			}
+
+			logger := klog.FromContext(ctx).WithValues("controller", replication.ControllerName)
+			if err := controller.RunOnce(ctx, workers); err != nil {
+				logger.Error(err, "one-shot replication did not complete")
+				return
+			}
+			logger.Info("one-shot replication completed")
 		},
 	})
 }
@@ -1566,7 +2257,7 @@ func (s *Server) installGarbageCollectorController(ctx context.Context, config *
 	)
 
 	c, err := garbagecollector.NewController(
-		s.KcpSharedInformerFactory.Core().V1alpha1().LogicalClusters(),
+		s.ControllerKcpSharedInformerFactory.Core().V1alpha1().LogicalClusters(),
 		kubeClusterClient,
 		metadataClient,
 		s.DiscoveringDynamicSharedInformerFactory,
@@ -1578,18 +2269,19 @@ func (s *Server) installGarbageCollectorController(ctx context.Context, config *
 	}
 
 	return s.registerController(&controllerWrapper{
-		Name: garbagecollector.ControllerName,
+		Name:  garbagecollector.ControllerName,
+		Drain: c.Drain,
 		Wait: func(ctx context.Context, s *Server) error {
 			return wait.PollUntilContextCancel(ctx, waitPollInterval, true, func(ctx context.Context) (bool, error) {
 				_, notSynced := s.DiscoveringDynamicSharedInformerFactory.Informers()
 				if len(notSynced) > 0 {
 					return false, nil
 				}
-				return s.KcpSharedInformerFactory.Core().V1alpha1().LogicalClusters().Informer().HasSynced(), nil
+				return s.ControllerKcpSharedInformerFactory.Core().V1alpha1().LogicalClusters().Informer().HasSynced(), nil
 			})
 		},
 		Runner: func(ctx context.Context) {
-			c.Start(ctx, 2)
+			c.Start(ctx, s.controllerWorkers(garbagecollector.ControllerName, 2))
 		},
 	})
 }
@@ -1598,38 +2290,102 @@ func (s *Server) WaitForSync(stop <-chan struct{}) error {
 	// Wait for shared informer factories to by synced.
 	// factory. Otherwise, informer list calls may go into backoff (before the CRDs are ready) and
 	// take ~10 seconds to succeed.
+	var timeoutCh <-chan time.Time
+	if timeout := s.Options.Controllers.SyncTimeout; timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		timeoutCh = timer.C
+	}
+
 	select {
 	case <-stop:
 		return errors.New("timed out waiting for informers to sync")
+	case <-timeoutCh:
+		unsynced := s.unsyncedInformers()
+		if len(unsynced) == 0 {
+			return fmt.Errorf("informers synced, but the server did not finish bootstrapping within %s", s.Options.Controllers.SyncTimeout)
+		}
+		return fmt.Errorf("informers not synced within %s: %s", s.Options.Controllers.SyncTimeout, strings.Join(unsynced, ", "))
 	case <-s.syncedCh:
 		return nil
 	}
 }
 
+// unsyncedInformers returns the sorted names of the started informers that have not synced yet.
+func (s *Server) unsyncedInformers() []string {
+	// with a closed stop channel, WaitForCacheSync only checks the current state.
+	closed := make(chan struct{})
+	close(closed)
+
+	unsynced := sets.New[string]()
+	collect := func(prefix string, synced map[reflect.Type]bool) {
+		for typ, ok := range synced {
+			if !ok {
+				unsynced.Insert(prefix + typ.String())
+			}
+		}
+	}
+	collect("", s.KubeSharedInformerFactory.WaitForCacheSync(closed))
+	collect("", s.ControllerKcpSharedInformerFactory.WaitForCacheSync(closed))
+	collect("", s.ControllerApiExtensionsSharedInformerFactory.WaitForCacheSync(closed))
+	collect("cache ", s.CacheKubeSharedInformerFactory.WaitForCacheSync(closed))
+	collect("cache ", s.CacheKcpSharedInformerFactory.WaitForCacheSync(closed))
+
+	_, notSynced := s.DiscoveringDynamicSharedInformerFactory.Informers()
+	for _, gvr := range notSynced {
+		unsynced.Insert(gvr.String())
+	}
+
+	return sets.List(unsynced)
+}
+
 // addIndexerstoInformers is separated out from controllers as the re-election calls for controller re-initialization,
-// it would panics in indexer addition to informers as they are already started at bootup.
-func (s *Server) addIndexersToInformers(_ context.Context) map[schema.GroupVersionResource]replication.ReplicatedGVR {
+// it would panics in indexer addition to informers as they are already started at bootup. The returned GVRs are
+// those to be replicated with the given kcp informers.
+func (s *Server) addIndexersToInformers(_ context.Context, kcpInformers kcpinformers.SharedInformerFactory) map[schema.GroupVersionResource]replication.ReplicatedGVR {
 	permissionclaimlabel.InstallIndexers(
-		s.KcpSharedInformerFactory.Apis().V1alpha1().APIExports(),
-		s.KcpSharedInformerFactory.Apis().V1alpha1().APIBindings(),
+		kcpInformers.Apis().V1alpha1().APIExports(),
+		kcpInformers.Apis().V1alpha1().APIBindings(),
 	)
 	permissionclaimlabler.InstallIndexers(
-		s.KcpSharedInformerFactory.Apis().V1alpha1().APIExports())
+		kcpInformers.Apis().V1alpha1().APIExports())
 	apibinding.InstallIndexers(
-		s.KcpSharedInformerFactory.Apis().V1alpha1().APIBindings(),
-		s.KcpSharedInformerFactory.Apis().V1alpha1().APIExports(),
+		kcpInformers.Apis().V1alpha1().APIBindings(),
+		kcpInformers.Apis().V1alpha1().APIExports(),
 		s.CacheKcpSharedInformerFactory.Apis().V1alpha1().APIExports(),
 	)
 	apiexport.InstallIndexers(
-		s.KcpSharedInformerFactory.Apis().V1alpha1().APIExports())
+		kcpInformers.Apis().V1alpha1().APIExports())
+	identityconflict.InstallIndexers(
+		kcpInformers.Apis().V1alpha1().APIExports(),
+		s.CacheKcpSharedInformerFactory.Apis().V1alpha1().APIExports(),
+	)
+	apiexportdeletion.InstallIndexers(
+		kcpInformers.Apis().V1alpha1().APIExports(),
+	)
+	apiexportexpiry.InstallIndexers(
+		kcpInformers.Apis().V1alpha1().APIExports(),
+		kcpInformers.Apis().V1alpha1().APIBindings(),
+	)
+	apiexportbindingcount.InstallIndexers(
+		kcpInformers.Apis().V1alpha1().APIExports(),
+		kcpInformers.Apis().V1alpha1().APIBindings(),
+	)
+	permissionclaimautoaccept.InstallIndexers(
+		kcpInformers.Apis().V1alpha1().APIExports(),
+		s.CacheKcpSharedInformerFactory.Apis().V1alpha1().APIExports(),
+	)
+	schemacompatibility.InstallIndexers(
+		kcpInformers.Apis().V1alpha1().APIBindings(),
+	)
 	apiexportendpointslice.InstallIndexers(
 		s.CacheKcpSharedInformerFactory.Apis().V1alpha1().APIExports(),
-		s.KcpSharedInformerFactory.Apis().V1alpha1().APIExportEndpointSlices(),
+		kcpInformers.Apis().V1alpha1().APIExportEndpointSlices(),
 	)
 	apiexportendpointsliceurls.InstallIndexers(
 		s.CacheKcpSharedInformerFactory.Apis().V1alpha1().APIExportEndpointSlices(),
-		s.KcpSharedInformerFactory.Apis().V1alpha1().APIExportEndpointSlices(),
-		s.KcpSharedInformerFactory.Apis().V1alpha1().APIBindings(),
+		kcpInformers.Apis().V1alpha1().APIExportEndpointSlices(),
+		kcpInformers.Apis().V1alpha1().APIBindings(),
 	)
 	labelclusterrolebindings.InstallIndexers(
 		s.KubeSharedInformerFactory.Rbac().V1().ClusterRoleBindings(),
@@ -1638,25 +2394,25 @@ func (s *Server) addIndexersToInformers(_ context.Context) map[schema.GroupVersi
 		s.KubeSharedInformerFactory.Rbac().V1().ClusterRoleBindings(),
 	)
 	workspace.InstallIndexers(
-		s.KcpSharedInformerFactory.Tenancy().V1alpha1().Workspaces(),
+		kcpInformers.Tenancy().V1alpha1().Workspaces(),
 		s.CacheKcpSharedInformerFactory.Core().V1alpha1().Shards(),
 		s.CacheKcpSharedInformerFactory.Tenancy().V1alpha1().WorkspaceTypes(),
 	)
 	workspacemounts.InstallIndexers(
-		s.KcpSharedInformerFactory.Tenancy().V1alpha1().Workspaces(),
+		kcpInformers.Tenancy().V1alpha1().Workspaces(),
 	)
 	extraannotationsync.InstallIndexers(
-		s.KcpSharedInformerFactory.Apis().V1alpha1().APIExports(),
-		s.KcpSharedInformerFactory.Apis().V1alpha1().APIBindings(),
+		kcpInformers.Apis().V1alpha1().APIExports(),
+		kcpInformers.Apis().V1alpha1().APIBindings(),
 	)
 	initialization.InstallIndexers(
-		s.KcpSharedInformerFactory.Tenancy().V1alpha1().WorkspaceTypes(),
+		kcpInformers.Tenancy().V1alpha1().WorkspaceTypes(),
 		s.CacheKcpSharedInformerFactory.Tenancy().V1alpha1().WorkspaceTypes())
 	crdcleanup.InstallIndexers(
-		s.KcpSharedInformerFactory.Apis().V1alpha1().APIBindings(),
+		kcpInformers.Apis().V1alpha1().APIBindings(),
 	)
 	return replication.InstallIndexers(
-		s.KcpSharedInformerFactory,
+		kcpInformers,
 		s.CacheKcpSharedInformerFactory,
 		s.KubeSharedInformerFactory,
 		s.CacheKubeSharedInformerFactory,
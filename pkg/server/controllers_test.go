@@ -0,0 +1,141 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	genericapiserver "k8s.io/apiserver/pkg/server"
+	utilversion "k8s.io/apiserver/pkg/util/version"
+
+	"github.com/kcp-dev/kcp/pkg/reconciler/apis/apibinding"
+	"github.com/kcp-dev/kcp/pkg/reconciler/apis/apiexport"
+	kcpserveroptions "github.com/kcp-dev/kcp/pkg/server/options"
+)
+
+// newTestServer returns a server with completed options and without any controllers. Note that
+// completing the options a second time in the same process fails, hence tests share the server.
+func newTestServer(t *testing.T, mutate func(o *kcpserveroptions.Options)) *Server {
+	t.Helper()
+
+	rootDir := t.TempDir()
+	o := kcpserveroptions.NewOptions(rootDir)
+	mutate(o)
+	completed, err := o.Complete(rootDir)
+	require.NoError(t, err)
+
+	genericConfig := genericapiserver.NewConfig(serializer.NewCodecFactory(runtime.NewScheme()))
+	genericConfig.ExternalAddress = "localhost:6443"
+	genericConfig.EffectiveVersion = utilversion.NewEffectiveVersion("")
+
+	return &Server{
+		CompletedConfig: CompletedConfig{&completedConfig{
+			Options:       *completed,
+			GenericConfig: genericConfig.Complete(nil),
+		}},
+		controllers:        map[string]*controllerWrapper{},
+		pendingControllers: sets.New[string](),
+		failedControllers:  map[string]error{},
+		runningControllers: map[string]*controllerWrapper{},
+
+		controllerStartupErrCh: make(chan error, 1),
+	}
+}
+
+func TestControllers(t *testing.T) {
+	s := newTestServer(t, func(o *kcpserveroptions.Options) {
+		o.Controllers.EnabledControllers = []string{"*", "-" + apiexport.ControllerName}
+	})
+
+	t.Run("register", func(t *testing.T) {
+		require.NoError(t, s.registerController(&controllerWrapper{Name: apibinding.ControllerName}))
+		require.NoError(t, s.registerController(&controllerWrapper{Name: apiexport.ControllerName}))
+		require.Equal(t, []string{apibinding.ControllerName}, s.InstalledControllers(), "expected disabled controllers not to be installed")
+
+		require.Error(t, s.registerController(&controllerWrapper{Name: apibinding.ControllerName}), "expected duplicate controllers to be rejected")
+		require.Error(t, s.registerController(&controllerWrapper{Name: "kcp-unknown"}), "expected controllers missing from the known controllers to be rejected")
+	})
+
+	t.Run("wait failure", func(t *testing.T) {
+		started := make(chan struct{})
+		s.controllers = map[string]*controllerWrapper{
+			"failing": {
+				Name:   "failing",
+				Wait:   func(ctx context.Context, s *Server) error { return errors.New("informers did not sync") },
+				Runner: func(ctx context.Context) { close(started) },
+			},
+		}
+
+		s.startControllers(context.Background())
+		require.Eventually(t, func() bool {
+			err := s.checkControllersSynced(nil)
+			return err != nil && strings.Contains(err.Error(), "controller failing failed to start: informers did not sync")
+		}, wait.ForeverTestTimeout, 10*time.Millisecond, "expected the failed controller to fail readyz")
+
+		select {
+		case <-started:
+			t.Fatal("expected the failed controller not to run")
+		default:
+		}
+		select {
+		case err := <-s.controllerStartupErrCh:
+			t.Fatalf("expected only startup timeouts to stop the server, got: %v", err)
+		default:
+		}
+	})
+
+	t.Run("startup timeout", func(t *testing.T) {
+		s.Options.Controllers.StartupTimeout = 100 * time.Millisecond
+		defer func() { s.Options.Controllers.StartupTimeout = 0 }()
+
+		started := make(chan struct{})
+		s.controllers = map[string]*controllerWrapper{
+			"stuck": {
+				Name: "stuck",
+				Wait: func(ctx context.Context, s *Server) error {
+					<-ctx.Done()
+					return ctx.Err()
+				},
+				Runner: func(ctx context.Context) { close(started) },
+			},
+		}
+
+		s.startControllers(context.Background())
+		select {
+		case err := <-s.controllerStartupErrCh:
+			require.ErrorContains(t, err, "controller stuck did not start within the startup timeout")
+		case <-time.After(wait.ForeverTestTimeout):
+			t.Fatal("expected the startup timeout to stop the server")
+		}
+
+		select {
+		case <-started:
+			t.Fatal("expected the stuck controller not to run")
+		default:
+		}
+	})
+}
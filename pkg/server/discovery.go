@@ -0,0 +1,90 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilcache "k8s.io/apimachinery/pkg/util/cache"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/cache"
+)
+
+// namespaceDiscoveryTTL is the time the namespace controller reuses the discovered resources
+// of a logical cluster. Changes of CRDs and APIBindings invalidate them earlier.
+const namespaceDiscoveryTTL = 30 * time.Second
+
+// cachedDiscovery caches the discovered resources of logical clusters for a TTL. A cached
+// logical cluster is invalidated by invalidateFor when an object changing its discovery,
+// like a CRD or an APIBinding, changes in it.
+type cachedDiscovery struct {
+	discover func(clusterName logicalcluster.Path) ([]*metav1.APIResourceList, error)
+	ttl      time.Duration
+	cache    *utilcache.Expiring
+}
+
+func newCachedDiscovery(discover func(clusterName logicalcluster.Path) ([]*metav1.APIResourceList, error), ttl time.Duration) *cachedDiscovery {
+	return &cachedDiscovery{
+		discover: discover,
+		ttl:      ttl,
+		cache:    utilcache.NewExpiring(),
+	}
+}
+
+// Discover returns the cached resources of the given logical cluster, or discovers them if
+// they are not cached or expired. Failed discoveries are not cached, but their partial results
+// are passed on.
+func (d *cachedDiscovery) Discover(clusterName logicalcluster.Path) ([]*metav1.APIResourceList, error) {
+	if resources, ok := d.cache.Get(clusterName.String()); ok {
+		return resources.([]*metav1.APIResourceList), nil
+	}
+
+	resources, err := d.discover(clusterName)
+	if err != nil {
+		return resources, err
+	}
+	d.cache.Set(clusterName.String(), resources, d.ttl)
+	return resources, nil
+}
+
+// invalidateFor drops the cached resources of the logical cluster of obj.
+func (d *cachedDiscovery) invalidateFor(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	m, err := meta.Accessor(obj)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("unable to invalidate cached discovery: %w", err))
+		return
+	}
+	d.cache.Delete(logicalcluster.From(m).Path().String())
+}
+
+// EventHandler returns an event handler invalidating the cached discovery of the logical
+// cluster of every changed object.
+func (d *cachedDiscovery) EventHandler() cache.ResourceEventHandler {
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc:    d.invalidateFor,
+		UpdateFunc: func(_, obj interface{}) { d.invalidateFor(obj) },
+		DeleteFunc: d.invalidateFor,
+	}
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/stretchr/testify/require"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestCachedDiscovery(t *testing.T) {
+	calls := map[string]int{}
+	var discoverErr error
+	d := newCachedDiscovery(func(clusterName logicalcluster.Path) ([]*metav1.APIResourceList, error) {
+		calls[clusterName.String()]++
+		return []*metav1.APIResourceList{{GroupVersion: "v1"}}, discoverErr
+	}, time.Hour)
+
+	for range 3 {
+		resources, err := d.Discover(logicalcluster.NewPath("root:org"))
+		require.NoError(t, err)
+		require.Len(t, resources, 1)
+	}
+	require.Equal(t, 1, calls["root:org"], "expected repeated calls within the TTL to be cached")
+
+	_, err := d.Discover(logicalcluster.NewPath("root:other"))
+	require.NoError(t, err)
+	require.Equal(t, 1, calls["root:other"], "expected logical clusters to be cached separately")
+
+	crd := &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "widgets.example.io",
+			Annotations: map[string]string{logicalcluster.AnnotationKey: "root:org"},
+		},
+	}
+	d.EventHandler().OnAdd(crd, false)
+	_, err = d.Discover(logicalcluster.NewPath("root:org"))
+	require.NoError(t, err)
+	require.Equal(t, 2, calls["root:org"], "expected a CRD change to invalidate its logical cluster")
+
+	d.EventHandler().OnDelete(cache.DeletedFinalStateUnknown{Key: "root:org|widgets.example.io", Obj: crd})
+	discoverErr = errors.New("partial discovery")
+	for range 2 {
+		resources, err := d.Discover(logicalcluster.NewPath("root:org"))
+		require.Error(t, err)
+		require.Len(t, resources, 1, "expected partial results to be passed on")
+	}
+	require.Equal(t, 4, calls["root:org"], "expected failed discoveries not to be cached")
+	require.Equal(t, 1, calls["root:other"])
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filters
+
+import (
+	"net/http"
+	"slices"
+
+	kaudit "k8s.io/apiserver/pkg/audit"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/endpoints/request"
+)
+
+const (
+	// ControllerHeader names the kcp controller a request is sent by. The controllers
+	// set it with --controller-audit-annotations.
+	ControllerHeader = "X-Kcp-Controller"
+	// ReconcileIDHeader holds the ID of the reconcile a request is sent by. It is sent
+	// together with the ControllerHeader.
+	ReconcileIDHeader = "X-Kcp-Reconcile-Id"
+
+	controllerAnnotation  = "kcp.io/controller"
+	reconcileIDAnnotation = "kcp.io/reconcile-id"
+)
+
+// WithAuditEventControllerAnnotation adds the controller named in the ControllerHeader, and
+// the reconcile ID in the ReconcileIDHeader, into the annotations of the audit event. Only the
+// headers of requests by system:masters, which the controllers run as, are honored, so that
+// other users cannot pose as a controller in the audit log. Needs the user in the context and
+// initialized annotations.
+func WithAuditEventControllerAnnotation(handler http.Handler) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		if controller := controllerFrom(req); controller != "" {
+			kaudit.AddAuditAnnotation(req.Context(), controllerAnnotation, controller)
+			if reconcileID := req.Header.Get(ReconcileIDHeader); reconcileID != "" {
+				kaudit.AddAuditAnnotation(req.Context(), reconcileIDAnnotation, reconcileID)
+			}
+		}
+
+		handler.ServeHTTP(w, req)
+	}
+}
+
+func controllerFrom(req *http.Request) string {
+	controller := req.Header.Get(ControllerHeader)
+	if controller == "" {
+		return ""
+	}
+	u, ok := request.UserFrom(req.Context())
+	if !ok || !slices.Contains(u.GetGroups(), user.SystemPrivilegedGroup) {
+		return ""
+	}
+	return controller
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filters
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/endpoints/request"
+)
+
+func TestControllerFrom(t *testing.T) {
+	tests := map[string]struct {
+		header string
+		user   user.Info
+		want   string
+	}{
+		"no header": {
+			user: &user.DefaultInfo{Name: "system:apiserver", Groups: []string{user.SystemPrivilegedGroup}},
+		},
+		"privileged user": {
+			header: "kcp-apibinding",
+			user:   &user.DefaultInfo{Name: "system:apiserver", Groups: []string{user.SystemPrivilegedGroup}},
+			want:   "kcp-apibinding",
+		},
+		"other user": {
+			header: "kcp-apibinding",
+			user:   &user.DefaultInfo{Name: "alice", Groups: []string{"system:authenticated"}},
+		},
+		"no user": {
+			header: "kcp-apibinding",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/api/v1/namespaces", nil)
+			if tt.header != "" {
+				req.Header.Set(ControllerHeader, tt.header)
+			}
+			if tt.user != nil {
+				req = req.WithContext(request.WithUser(req.Context(), tt.user))
+			}
+			require.Equal(t, tt.want, controllerFrom(req))
+		})
+	}
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"sync"
+
+	compbasemetrics "k8s.io/component-base/metrics"
+	"k8s.io/component-base/metrics/legacyregistry"
+	// registers the workqueue metrics provider, so that the queues of all controllers report
+	// their depth, adds, latencies and retries, labeled with the queue name.
+	_ "k8s.io/component-base/metrics/prometheus/workqueue"
+)
+
+var (
+	controllerStartDuration = compbasemetrics.NewHistogramVec(
+		&compbasemetrics.HistogramOpts{
+			Name:           "kcp_controller_poststarthook_duration_seconds",
+			Help:           "Time in seconds from starting the controllers in the post-start hook until a controller finished waiting for its informers and is started.",
+			Buckets:        []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 20, 30, 60, 120, 300, 600},
+			StabilityLevel: compbasemetrics.ALPHA,
+		},
+		[]string{"controller"},
+	)
+
+	controllerStartCancelled = compbasemetrics.NewCounterVec(
+		&compbasemetrics.CounterOpts{
+			Name:           "kcp_controller_poststarthook_cancelled_total",
+			Help:           "Number of controllers that were not started because the context was cancelled or timed out while waiting for their informers.",
+			StabilityLevel: compbasemetrics.ALPHA,
+		},
+		[]string{"controller"},
+	)
+)
+
+var registerMetrics sync.Once
+
+func init() {
+	registerMetrics.Do(func() {
+		legacyregistry.MustRegister(controllerStartDuration)
+		legacyregistry.MustRegister(controllerStartCancelled)
+	})
+}
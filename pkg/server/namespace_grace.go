@@ -0,0 +1,91 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"time"
+
+	kcpcache "github.com/kcp-dev/apimachinery/v2/pkg/cache"
+	kcpcorev1informers "github.com/kcp-dev/client-go/informers/core/v1"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// namespaceGraceInformer wraps the namespace informer of the namespace controller. It holds
+// back the events of terminating namespaces until their deletion grace period elapsed, so that
+// the controller starts deleting their content only afterwards, e.g. to give backup hooks time.
+type namespaceGraceInformer struct {
+	kcpcorev1informers.NamespaceClusterInformer
+	grace time.Duration
+}
+
+func (i *namespaceGraceInformer) Informer() kcpcache.ScopeableSharedIndexInformer {
+	return &namespaceGraceSharedIndexInformer{
+		ScopeableSharedIndexInformer: i.NamespaceClusterInformer.Informer(),
+		grace:                        i.grace,
+	}
+}
+
+type namespaceGraceSharedIndexInformer struct {
+	kcpcache.ScopeableSharedIndexInformer
+	grace time.Duration
+}
+
+func (i *namespaceGraceSharedIndexInformer) AddEventHandler(handler cache.ResourceEventHandler) (cache.ResourceEventHandlerRegistration, error) {
+	return i.ScopeableSharedIndexInformer.AddEventHandler(&namespaceGraceHandler{delegate: handler, grace: i.grace})
+}
+
+func (i *namespaceGraceSharedIndexInformer) AddEventHandlerWithResyncPeriod(handler cache.ResourceEventHandler, resyncPeriod time.Duration) (cache.ResourceEventHandlerRegistration, error) {
+	return i.ScopeableSharedIndexInformer.AddEventHandlerWithResyncPeriod(&namespaceGraceHandler{delegate: handler, grace: i.grace}, resyncPeriod)
+}
+
+// namespaceGraceHandler delivers the events of namespaces terminating for less than the grace
+// period once it elapsed. Deletions are delivered right away.
+type namespaceGraceHandler struct {
+	delegate cache.ResourceEventHandler
+	grace    time.Duration
+}
+
+func (h *namespaceGraceHandler) OnAdd(obj interface{}, isInInitialList bool) {
+	if remaining := h.remainingGrace(obj); remaining > 0 {
+		time.AfterFunc(remaining, func() { h.delegate.OnAdd(obj, false) })
+		return
+	}
+	h.delegate.OnAdd(obj, isInInitialList)
+}
+
+func (h *namespaceGraceHandler) OnUpdate(oldObj, newObj interface{}) {
+	if remaining := h.remainingGrace(newObj); remaining > 0 {
+		time.AfterFunc(remaining, func() { h.delegate.OnUpdate(oldObj, newObj) })
+		return
+	}
+	h.delegate.OnUpdate(oldObj, newObj)
+}
+
+func (h *namespaceGraceHandler) OnDelete(obj interface{}) {
+	h.delegate.OnDelete(obj)
+}
+
+// remainingGrace returns how long the deletion of the namespace obj still has to wait.
+func (h *namespaceGraceHandler) remainingGrace(obj interface{}) time.Duration {
+	ns, ok := obj.(*corev1.Namespace)
+	if !ok || ns.DeletionTimestamp == nil {
+		return 0
+	}
+	return time.Until(ns.DeletionTimestamp.Add(h.grace))
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestNamespaceGraceHandler(t *testing.T) {
+	var adds, updates, deletes atomic.Int32
+	h := &namespaceGraceHandler{
+		delegate: cache.ResourceEventHandlerFuncs{
+			AddFunc:    func(interface{}) { adds.Add(1) },
+			UpdateFunc: func(_, _ interface{}) { updates.Add(1) },
+			DeleteFunc: func(interface{}) { deletes.Add(1) },
+		},
+		grace: 200 * time.Millisecond,
+	}
+
+	active := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "active"}}
+	h.OnAdd(active, true)
+	h.OnUpdate(active, active)
+	require.Equal(t, int32(1), adds.Load(), "expected events of active namespaces to be delivered right away")
+	require.Equal(t, int32(1), updates.Load(), "expected events of active namespaces to be delivered right away")
+
+	longAgo := metav1.NewTime(time.Now().Add(-time.Hour))
+	terminatingLongAgo := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "old", DeletionTimestamp: &longAgo}}
+	h.OnUpdate(active, terminatingLongAgo)
+	require.Equal(t, int32(2), updates.Load(), "expected events of namespaces past their grace period to be delivered right away")
+
+	now := metav1.Now()
+	terminating := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "new", DeletionTimestamp: &now}}
+	h.OnAdd(terminating, true)
+	h.OnUpdate(active, terminating)
+	require.Equal(t, int32(1), adds.Load(), "expected events within the grace period to be held back")
+	require.Equal(t, int32(2), updates.Load(), "expected events within the grace period to be held back")
+
+	h.OnDelete(terminating)
+	require.Equal(t, int32(1), deletes.Load(), "expected deletions to be delivered right away")
+
+	require.Eventually(t, func() bool {
+		return adds.Load() == 2 && updates.Load() == 3
+	}, wait.ForeverTestTimeout, 10*time.Millisecond, "expected held back events to be delivered after the grace period")
+}
@@ -34,6 +34,15 @@ const (
 
 	// MetricsViewer leads to an additional service account named "metrics" in the root namespace that can view metrics.
 	MetricsViewer = "metrics-viewer"
+
+	// DefaultNetworkPolicy leads to a default NetworkPolicy, denying all traffic unless configured
+	// otherwise, in the default namespace of newly initialized workspaces that serve NetworkPolicies.
+	DefaultNetworkPolicy = "default-network-policy"
+
+	// DefaultLimitRange leads to a default LimitRange, setting default container requests and limits
+	// unless configured otherwise, in the default namespace of newly initialized workspaces that serve
+	// LimitRanges.
+	DefaultLimitRange = "default-limit-range"
 )
 
 var All = sets.New[string](
@@ -41,6 +50,8 @@ var All = sets.New[string](
 	Admin,
 	User,
 	MetricsViewer,
+	DefaultNetworkPolicy,
+	DefaultLimitRange,
 )
 
 var Defaults = sets.New[string](
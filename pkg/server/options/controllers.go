@@ -23,26 +23,109 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/spf13/pflag"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apiserver/pkg/server/healthz"
 	"k8s.io/client-go/util/keyutil"
 	"k8s.io/klog/v2"
 	kcmoptions "k8s.io/kubernetes/cmd/kube-controller-manager/app/options"
+
+	"github.com/kcp-dev/kcp/pkg/reconciler/apis/permissionclaimautoaccept"
 )
 
 type Controllers struct {
 	EnableAll           bool
 	IndividuallyEnabled []string
+	EnabledControllers  []string
 
 	EnableLeaderElection    bool
 	LeaderElectionNamespace string
 	LeaderElectionName      string
 
 	SAController kcmoptions.SAControllerOptions
+	KubeQuota    KubeQuotaController
+
+	// ServiceAccountsToEnsure are the names of the ServiceAccounts the service account
+	// controller creates in every namespace.
+	ServiceAccountsToEnsure []string
+
+	UniversalBootstrapWorkers int
+	WorkspaceDeletionWorkers  int
+	Workers                   map[string]int
+	MaxRetries                map[string]int
+	MaxInFlightReconciles     int
+	StartupTimeout            time.Duration
+	SyncTimeout               time.Duration
+	ShutdownDrainTimeout      time.Duration
+	InformerResyncPeriod      time.Duration
+	ClientKeepAlive           time.Duration
+	ClientIdleConnTimeout     time.Duration
+	AuditAnnotations          bool
+	ReconcileTimeouts         map[string]string
+	ReconcileTraceKeys        []string
+	ReconcileTraceAnnotation  bool
+
+	UniversalBootstrapRetryFailedOnly bool
+	UniversalBootstrapRetryBackoff    time.Duration
+	UniversalBootstrapRetryMaxBackoff time.Duration
+
+	PermissionClaimAutoAcceptPolicy []string
+
+	APIExportExpiryAge     time.Duration
+	PruneExpiredAPIExports bool
+
+	DefaultNetworkPolicyFile string
+	DefaultLimitRangeFile    string
+
+	PruneDisabledBatteryDefaults bool
+
+	ReadReplicaKubeconfig string
+
+	ReplicationOneShot bool
+
+	NamespaceDeletionGracePeriod time.Duration
+
+	InformerLabelSelectors map[string]string
+	InformerFieldSelectors map[string]string
+
+	// ReadyzChecks are additional checks gating /readyz of the server. They are not exposed as
+	// flags, but allow embedders to tie the server readiness to the health of their controllers.
+	ReadyzChecks []healthz.HealthChecker
 }
 
+// KubeQuotaController holds the options of the kube quota controller.
+type KubeQuotaController struct {
+	// ResyncPeriod is the period in which the quota usage of all ResourceQuotas is recalculated.
+	ResyncPeriod time.Duration
+	// ReplenishmentPeriod is the resync period of the informers which replenish quota usage
+	// when quota-tracked objects change.
+	ReplenishmentPeriod time.Duration
+	// Resources limits quota tracking to the given resources, in the form <resource>[.<group>].
+	// All resources are tracked if empty.
+	Resources []string
+}
+
+// SelectableControllers are the controllers supporting --controller-informer-label-selectors and
+// --controller-informer-field-selectors. The selectors restrict the informer of the given resource,
+// which drives the controller.
+var SelectableControllers = map[string]string{
+	"kube-service-account-controller":   "namespaces",
+	"kube-root-ca-configmap-controller": "namespaces",
+}
+
+// minInformerResyncPeriod is the lowest accepted --informer-resync-period. Shorter periods
+// make the controllers reconcile every object continuously.
+const minInformerResyncPeriod = time.Minute
+
 var kcmDefaults *kcmoptions.KubeControllerManagerOptions
 
 func init() {
@@ -56,13 +139,29 @@ func init() {
 
 func NewControllers() *Controllers {
 	return &Controllers{
-		EnableAll: true,
+		EnableAll:          true,
+		EnabledControllers: []string{"*"},
 
 		EnableLeaderElection:    false,
 		LeaderElectionNamespace: metav1.NamespaceSystem,
 		LeaderElectionName:      "kcp-controllers",
 
 		SAController: *kcmDefaults.SAController,
+		KubeQuota: KubeQuotaController{
+			ResyncPeriod:        5 * time.Minute,
+			ReplenishmentPeriod: 12 * time.Hour,
+		},
+
+		ServiceAccountsToEnsure: []string{"default"},
+
+		UniversalBootstrapWorkers: 2,
+		WorkspaceDeletionWorkers:  10,
+
+		UniversalBootstrapRetryBackoff:    time.Second,
+		UniversalBootstrapRetryMaxBackoff: time.Second,
+
+		ShutdownDrainTimeout: 10 * time.Second,
+		InformerResyncPeriod: 10 * time.Hour,
 	}
 }
 
@@ -70,16 +169,84 @@ func (c *Controllers) AddFlags(fs *pflag.FlagSet) {
 	fs.BoolVar(&c.EnableAll, "run-controllers", c.EnableAll, "Run the controllers in-process")
 
 	fs.StringSliceVar(&c.IndividuallyEnabled, "unsupported-run-individual-controllers", c.IndividuallyEnabled, "Run individual controllers in-process. The controller names can change at any time.")
-	fs.MarkHidden("unsupported-run-individual-controllers") //nolint:errcheck
+	fs.MarkDeprecated("unsupported-run-individual-controllers", "use --run-controllers with --controllers instead, which takes the controller names the server logs") //nolint:errcheck
+	fs.StringSliceVar(&c.EnabledControllers, "controllers", c.EnabledControllers, "A list of controllers to run, by the names the server logs. '*' enables all controllers, 'foo' enables the controller named 'foo', '-foo' disables the controller named 'foo'. E.g. '*,-kcp-replication-controller' stops replicating the objects of this shard to the cache server, if another component replicates them. '*,-kube-cluster-role-aggregation-controller' stops rewriting aggregated ClusterRoles, if RBAC is managed externally.")
 
 	fs.BoolVar(&c.EnableLeaderElection, "enable-leader-election", c.EnableLeaderElection, "Enable a leader election for kcp controllers running in the system:admin workspace")
 	fs.StringVar(&c.LeaderElectionNamespace, "leader-election-namespace", c.LeaderElectionNamespace, "Namespace in system:admin workspace to use for leader election")
 	fs.StringVar(&c.LeaderElectionName, "leader-election-name", c.LeaderElectionName, "Name of the lease to use for leader election")
 
+	fs.IntVar(&c.UniversalBootstrapWorkers, "universal-bootstrap-workers", c.UniversalBootstrapWorkers, "Number of workers bootstrapping workspaces of the universal type concurrently")
+	fs.BoolVar(&c.UniversalBootstrapRetryFailedOnly, "universal-bootstrap-retry-failed-only", c.UniversalBootstrapRetryFailedOnly, "Retry only the failed manifests when bootstrapping a workspace of the universal type, instead of all of them.")
+	fs.DurationVar(&c.UniversalBootstrapRetryBackoff, "universal-bootstrap-retry-backoff", c.UniversalBootstrapRetryBackoff, "Initial delay between retries of failed manifests when bootstrapping a workspace of the universal type. It doubles with every retry up to --universal-bootstrap-retry-max-backoff.")
+	fs.DurationVar(&c.UniversalBootstrapRetryMaxBackoff, "universal-bootstrap-retry-max-backoff", c.UniversalBootstrapRetryMaxBackoff, "Maximum delay between retries of failed manifests when bootstrapping a workspace of the universal type.")
+	fs.StringToIntVar(&c.Workers, "controller-workers", c.Workers, "Number of workers per controller name, e.g. kcp-apibinding=8. Controllers not listed keep their default.")
+	fs.StringToIntVar(&c.MaxRetries, "controller-max-retries", c.MaxRetries, "Number of retries of a failing key per controller name, e.g. kcp-apibinding=20, after which the key is dropped from the queue with an error log and a RetriesExhausted warning event on its object. Use * as name to set it for all other controllers. 0 or unset means retrying forever. Dropped keys are reconciled again on the next change or resync of their object.")
+	fs.IntVar(&c.WorkspaceDeletionWorkers, "workspace-deletion-workers", c.WorkspaceDeletionWorkers, "Number of workers deleting the contents of workspaces concurrently")
+	fs.DurationVar(&c.NamespaceDeletionGracePeriod, "namespace-deletion-grace-period", c.NamespaceDeletionGracePeriod, "Time the namespace controller waits after a namespace is deleted before it starts deleting its content, e.g. to give external backup hooks a chance to run. 0 starts right away.")
+
+	fs.IntVar(&c.MaxInFlightReconciles, "max-in-flight-reconciles", c.MaxInFlightReconciles, "Maximum number of reconciles running concurrently across all controllers. 0 means unlimited.")
+
+	fs.DurationVar(&c.StartupTimeout, "controller-startup-timeout", c.StartupTimeout, "Maximum time a controller may wait for its informers to sync before the server shuts down and exits with an error. 0 means waiting forever.")
+	fs.DurationVar(&c.SyncTimeout, "controller-sync-timeout", c.SyncTimeout, "Maximum time to wait for all informers to sync before starting the controllers depending on all of them. When it elapses, the unsynced informers are logged and those controllers are not started. 0 means waiting forever.")
+	fs.DurationVar(&c.ShutdownDrainTimeout, "controller-shutdown-drain-timeout", c.ShutdownDrainTimeout, "Maximum time to wait on shutdown for the controllers to process their queued work. Controllers stop accepting new work when the server starts shutting down. 0 disables draining.")
+
+	fs.DurationVar(&c.InformerResyncPeriod, "informer-resync-period", c.InformerResyncPeriod, "Period in which the shared informers of the kcp, cache and CRD objects replay all cached objects to their controllers. Lower it to let controllers catch up sooner on missed changes, at the cost of more reconciles.")
+
+	fs.StringToStringVar(&c.InformerLabelSelectors, "controller-informer-label-selectors", c.InformerLabelSelectors, fmt.Sprintf("Label selectors per controller name, e.g. kube-service-account-controller=team=a. The controller gets a dedicated informer listing and watching only the matching objects, and ignores all others. Supported controllers: %s.", strings.Join(sets.List(sets.KeySet(SelectableControllers)), ", ")))
+	fs.StringToStringVar(&c.InformerFieldSelectors, "controller-informer-field-selectors", c.InformerFieldSelectors, fmt.Sprintf("Field selectors per controller name, e.g. kube-root-ca-configmap-controller=metadata.name!=kube-system. The controller gets a dedicated informer listing and watching only the matching objects, and ignores all others. Supported controllers: %s.", strings.Join(sets.List(sets.KeySet(SelectableControllers)), ", ")))
+
+	fs.DurationVar(&c.ClientKeepAlive, "controller-client-keepalive", c.ClientKeepAlive, "Interval of TCP keepalive probes on the connections of the controllers to the apiserver, e.g. to keep them open behind load balancers closing idle connections. 0 keeps the default.")
+	fs.DurationVar(&c.ClientIdleConnTimeout, "controller-client-idle-timeout", c.ClientIdleConnTimeout, "Time after which idle connections of the controllers to the apiserver are closed by the client. Set it below the idle timeout of load balancers in between. 0 keeps the default.")
+	fs.BoolVar(&c.AuditAnnotations, "controller-audit-annotations", c.AuditAnnotations, "Send the name of the reconciling controller and the reconcile ID with every request of a reconcile. The audit events of these requests are annotated with kcp.io/controller and kcp.io/reconcile-id.")
+
+	fs.StringToStringVar(&c.ReconcileTimeouts, "controller-reconcile-timeouts", c.ReconcileTimeouts, "Maximum duration of a single reconcile per controller name, e.g. kcp-apibinding=30s. Use * as name to set a timeout for all other controllers. Reconciles running into the timeout are requeued.")
+	fs.StringSliceVar(&c.ReconcileTraceKeys, "reconcile-trace-keys", c.ReconcileTraceKeys, "Queue keys, e.g. root:org|my-binding, whose reconciles are logged verbosely in all controllers regardless of -v. Objects can also be traced with the debug.kcp.io/trace-reconcile annotation if --reconcile-trace-annotation is set.")
+	fs.BoolVar(&c.ReconcileTraceAnnotation, "reconcile-trace-annotation", c.ReconcileTraceAnnotation, "Log the reconciles of objects annotated with debug.kcp.io/trace-reconcile=true verbosely in all controllers regardless of -v. Everybody able to annotate an object can then increase the log volume, hence only enable it while debugging.")
+
+	fs.StringSliceVar(&c.PermissionClaimAutoAcceptPolicy, "permission-claim-auto-accept-policy", c.PermissionClaimAutoAcceptPolicy, "Permission claims that are accepted automatically on new APIBindings, in the form <export identity hash>=<resource>[.<group>]. Use * as resource to accept all claims of a trusted APIExport.")
+
+	fs.DurationVar(&c.APIExportExpiryAge, "apiexport-expiry-age", c.APIExportExpiryAge, "Age after which APIExports without any APIBinding on this shard are flagged with a false InUse condition. 0 disables the check.")
+	fs.BoolVar(&c.PruneExpiredAPIExports, "prune-expired-apiexports", c.PruneExpiredAPIExports, "Delete APIExports flagged as unused by --apiexport-expiry-age. APIExports in the root and system logical clusters are never deleted. Only APIBindings on this shard are visible, hence nothing is deleted while there is more than one shard.")
+
+	fs.StringVar(&c.DefaultNetworkPolicyFile, "default-network-policy-file", c.DefaultNetworkPolicyFile, "File with the NetworkPolicy created in newly initialized workspaces if the default-network-policy battery is included. Defaults to denying all traffic in the default namespace.")
+	fs.StringVar(&c.DefaultLimitRangeFile, "default-limit-range-file", c.DefaultLimitRangeFile, "File with the LimitRange created in newly initialized workspaces if the default-limit-range battery is included. Defaults to default container requests and limits in the default namespace.")
+	fs.BoolVar(&c.PruneDisabledBatteryDefaults, "prune-disabled-battery-defaults", c.PruneDisabledBatteryDefaults, "Delete the objects created in workspaces by the default-network-policy and default-limit-range batteries if the battery is not included anymore. Only objects carrying the bootstrap.kcp.io/battery label are deleted, including ones modified by the workspace owner.")
+
+	fs.StringVar(&c.ReadReplicaKubeconfig, "read-replica-kubeconfig", c.ReadReplicaKubeconfig, "Kubeconfig of a replica of this shard to list and watch kcp and CRD objects from for the informers of the controllers, instead of the shard itself. Writes still go to this shard. The informers of Kubernetes objects, e.g. namespaces, secrets and RBAC, stay on this shard because they are shared with admission, authorization and the embedded Kubernetes apiserver, which must see the current state of the shard. The dynamic informers of CRD-backed and bound resources, used e.g. by garbage collection and quota, stay on this shard because they follow the resources this shard serves, which the replica may not serve yet. Admission and authorization keep watching this shard.")
+
+	fs.BoolVar(&c.ReplicationOneShot, "replication-one-shot", c.ReplicationOneShot, "Replicate all objects to the cache server once after startup and then stop the replication controller, e.g. to verify a migration. Later changes are not replicated.")
+
+	fs.DurationVar(&c.KubeQuota.ResyncPeriod, "kube-quota-resync-period", c.KubeQuota.ResyncPeriod, "Period in which the usage of all ResourceQuotas is recalculated.")
+	fs.DurationVar(&c.KubeQuota.ReplenishmentPeriod, "kube-quota-replenishment-period", c.KubeQuota.ReplenishmentPeriod, "Resync period of the informers replenishing ResourceQuota usage when quota-tracked objects change. Lower it if quota usage becomes stale on workspaces with rapidly changing objects.")
+
+	fs.StringSliceVar(&c.KubeQuota.Resources, "kube-quota-resources", c.KubeQuota.Resources, "Resources to track quota usage for, in the form <resource>[.<group>], e.g. pods,configmaps,widgets.example.io. Quota usage of other resources is neither calculated nor replenished. Defaults to all resources.")
+
+	fs.StringSliceVar(&c.ServiceAccountsToEnsure, "service-accounts-to-ensure", c.ServiceAccountsToEnsure, "Names of the ServiceAccounts created in every namespace by the service account controller.")
+
 	c.SAController.AddFlags(fs)
 }
 
 func (c *Controllers) Complete(rootDir string) error {
+	if !c.EnableAll {
+		// fold the deprecated flags into --controllers, keeping the controllers they run
+		// unless --controllers disables them.
+		enabled := sets.New[string](legacyControllers...)
+		for _, group := range c.IndividuallyEnabled {
+			enabled.Insert(legacyControllerGroups[group]...)
+		}
+		if len(c.IndividuallyEnabled) > 0 {
+			klog.Background().WithValues("controllers", c.IndividuallyEnabled).Info("starting controllers individually")
+		}
+		controllers := []string{}
+		for _, name := range sets.List(enabled) {
+			if c.IsControllerEnabled(name) {
+				controllers = append(controllers, name)
+			}
+		}
+		c.EnabledControllers = controllers
+	}
+
 	if c.SAController.ServiceAccountKeyFile == "" {
 		if rootDir == "" {
 			return errors.New("no serviceaccount key file loaded and no root directory set")
@@ -115,5 +282,168 @@ func (c *Controllers) Validate() []error {
 		errs = append(errs, saErrs...)
 	}
 
+	seenServiceAccounts := sets.New[string]()
+	for _, name := range c.ServiceAccountsToEnsure {
+		if msgs := validation.IsDNS1123Subdomain(name); len(msgs) > 0 {
+			errs = append(errs, fmt.Errorf("--service-accounts-to-ensure: invalid name %q: %s", name, strings.Join(msgs, ", ")))
+		} else if seenServiceAccounts.Has(name) {
+			errs = append(errs, fmt.Errorf("--service-accounts-to-ensure: duplicate name %q", name))
+		}
+		seenServiceAccounts.Insert(name)
+	}
+
+	if c.KubeQuota.ResyncPeriod <= 0 {
+		errs = append(errs, fmt.Errorf("--kube-quota-resync-period must be positive"))
+	}
+	if c.KubeQuota.ReplenishmentPeriod <= 0 {
+		errs = append(errs, fmt.Errorf("--kube-quota-replenishment-period must be positive"))
+	}
+	for _, resource := range c.KubeQuota.Resources {
+		if gr := schema.ParseGroupResource(resource); gr.Resource == "" {
+			errs = append(errs, fmt.Errorf("--kube-quota-resources: invalid resource %q", resource))
+		}
+	}
+
+	if c.UniversalBootstrapWorkers < 1 {
+		errs = append(errs, fmt.Errorf("--universal-bootstrap-workers must be at least 1"))
+	}
+	if c.UniversalBootstrapRetryBackoff <= 0 {
+		errs = append(errs, fmt.Errorf("--universal-bootstrap-retry-backoff must be positive"))
+	}
+	if c.UniversalBootstrapRetryMaxBackoff < c.UniversalBootstrapRetryBackoff {
+		errs = append(errs, fmt.Errorf("--universal-bootstrap-retry-max-backoff must not be less than --universal-bootstrap-retry-backoff"))
+	}
+
+	for _, name := range c.EnabledControllers {
+		if name == "" || name == "-" || name == "-*" {
+			errs = append(errs, fmt.Errorf("--controllers: invalid entry %q", name))
+		} else if name := strings.TrimPrefix(name, "-"); name != "*" && !KnownControllers.Has(name) {
+			errs = append(errs, fmt.Errorf("--controllers: unknown controller %q", name))
+		}
+	}
+
+	if c.WorkspaceDeletionWorkers < 1 {
+		errs = append(errs, fmt.Errorf("--workspace-deletion-workers must be at least 1"))
+	}
+	if c.NamespaceDeletionGracePeriod < 0 {
+		errs = append(errs, fmt.Errorf("--namespace-deletion-grace-period must not be negative"))
+	}
+
+	for name, workers := range c.Workers {
+		if !KnownControllers.Has(name) {
+			errs = append(errs, fmt.Errorf("--controller-workers: unknown controller %q", name))
+		}
+		if workers < 1 {
+			errs = append(errs, fmt.Errorf("--controller-workers: %s must have at least 1 worker", name))
+		}
+	}
+
+	for name, retries := range c.MaxRetries {
+		if name != "*" && !KnownControllers.Has(name) {
+			errs = append(errs, fmt.Errorf("--controller-max-retries: unknown controller %q", name))
+		}
+		if retries < 0 {
+			errs = append(errs, fmt.Errorf("--controller-max-retries: %s must not be negative", name))
+		}
+	}
+
+	if c.MaxInFlightReconciles < 0 {
+		errs = append(errs, fmt.Errorf("--max-in-flight-reconciles must not be negative"))
+	}
+
+	if c.StartupTimeout < 0 {
+		errs = append(errs, fmt.Errorf("--controller-startup-timeout must not be negative"))
+	}
+	if c.SyncTimeout < 0 {
+		errs = append(errs, fmt.Errorf("--controller-sync-timeout must not be negative"))
+	}
+	if c.ShutdownDrainTimeout < 0 {
+		errs = append(errs, fmt.Errorf("--controller-shutdown-drain-timeout must not be negative"))
+	}
+	if c.InformerResyncPeriod < minInformerResyncPeriod {
+		errs = append(errs, fmt.Errorf("--informer-resync-period must be at least %s", minInformerResyncPeriod))
+	}
+	for name, selector := range c.InformerLabelSelectors {
+		if _, ok := SelectableControllers[name]; !ok {
+			errs = append(errs, fmt.Errorf("--controller-informer-label-selectors: controller %q does not support selectors", name))
+		}
+		if _, err := labels.Parse(selector); err != nil {
+			errs = append(errs, fmt.Errorf("--controller-informer-label-selectors: invalid selector for controller %q: %w", name, err))
+		}
+	}
+	for name, selector := range c.InformerFieldSelectors {
+		if _, ok := SelectableControllers[name]; !ok {
+			errs = append(errs, fmt.Errorf("--controller-informer-field-selectors: controller %q does not support selectors", name))
+		}
+		if _, err := fields.ParseSelector(selector); err != nil {
+			errs = append(errs, fmt.Errorf("--controller-informer-field-selectors: invalid selector for controller %q: %w", name, err))
+		}
+	}
+	if c.ClientKeepAlive < 0 {
+		errs = append(errs, fmt.Errorf("--controller-client-keepalive must not be negative"))
+	}
+	if c.ClientIdleConnTimeout < 0 {
+		errs = append(errs, fmt.Errorf("--controller-client-idle-timeout must not be negative"))
+	}
+
+	if _, err := c.ReconcileTimeoutDurations(); err != nil {
+		errs = append(errs, fmt.Errorf("--controller-reconcile-timeouts: %w", err))
+	}
+
+	if c.APIExportExpiryAge < 0 {
+		errs = append(errs, fmt.Errorf("--apiexport-expiry-age must not be negative"))
+	}
+	if c.PruneExpiredAPIExports && c.APIExportExpiryAge == 0 {
+		errs = append(errs, fmt.Errorf("--prune-expired-apiexports requires --apiexport-expiry-age"))
+	}
+
+	if _, err := permissionclaimautoaccept.ParsePolicy(c.PermissionClaimAutoAcceptPolicy); err != nil {
+		errs = append(errs, fmt.Errorf("--permission-claim-auto-accept-policy: %w", err))
+	}
+
 	return errs
 }
+
+// KubeQuotaResources returns the parsed resources tracked by the kube quota controller.
+func (c *Controllers) KubeQuotaResources() []schema.GroupResource {
+	resources := make([]schema.GroupResource, 0, len(c.KubeQuota.Resources))
+	for _, resource := range c.KubeQuota.Resources {
+		resources = append(resources, schema.ParseGroupResource(resource))
+	}
+	return resources
+}
+
+// ReconcileTimeoutDurations returns the parsed reconcile timeouts by controller name.
+func (c *Controllers) ReconcileTimeoutDurations() (map[string]time.Duration, error) {
+	timeouts := make(map[string]time.Duration, len(c.ReconcileTimeouts))
+	for name, value := range c.ReconcileTimeouts {
+		if name != "*" && !KnownControllers.Has(name) {
+			return nil, fmt.Errorf("unknown controller %q", name)
+		}
+		timeout, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timeout for controller %q: %w", name, err)
+		}
+		if timeout <= 0 {
+			return nil, fmt.Errorf("timeout for controller %q must be positive", name)
+		}
+		timeouts[name] = timeout
+	}
+	return timeouts, nil
+}
+
+// IsControllerEnabled returns whether the named controller is enabled by --controllers.
+func (c *Controllers) IsControllerEnabled(name string) bool {
+	hasStar := false
+	for _, entry := range c.EnabledControllers {
+		switch entry {
+		case name:
+			return true
+		case "-" + name:
+			return false
+		case "*":
+			hasStar = true
+		}
+	}
+	return hasStar
+}
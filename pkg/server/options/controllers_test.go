@@ -0,0 +1,262 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package options
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsControllerEnabled(t *testing.T) {
+	tests := map[string]struct {
+		controllers []string
+		name        string
+		want        bool
+	}{
+		"default": {
+			controllers: []string{"*"},
+			name:        "kube-namespace-controller",
+			want:        true,
+		},
+		"disabled": {
+			controllers: []string{"*", "-kube-namespace-controller"},
+			name:        "kube-namespace-controller",
+			want:        false,
+		},
+		"other disabled": {
+			controllers: []string{"*", "-kube-namespace-controller"},
+			name:        "kcp-apibinding",
+			want:        true,
+		},
+		"only disabled": {
+			controllers: []string{"-kube-namespace-controller"},
+			name:        "kcp-apibinding",
+			want:        false,
+		},
+		"explicitly enabled": {
+			controllers: []string{"kcp-apibinding"},
+			name:        "kcp-apibinding",
+			want:        true,
+		},
+		"not enabled": {
+			controllers: []string{"kcp-apibinding"},
+			name:        "kube-namespace-controller",
+			want:        false,
+		},
+		"none": {
+			name: "kcp-apibinding",
+			want: false,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := &Controllers{EnabledControllers: tc.controllers}
+			require.Equal(t, tc.want, c.IsControllerEnabled(tc.name))
+		})
+	}
+}
+
+func TestCompleteLegacyControllerFlags(t *testing.T) {
+	tests := map[string]struct {
+		mutate func(c *Controllers)
+		want   []string
+	}{
+		"all controllers": {
+			mutate: func(c *Controllers) {},
+			want:   []string{"*"},
+		},
+		"controllers not running": {
+			mutate: func(c *Controllers) { c.EnableAll = false },
+			want:   legacyControllers,
+		},
+		"controllers run individually": {
+			mutate: func(c *Controllers) {
+				c.EnableAll = false
+				c.IndividuallyEnabled = []string{"apiexport"}
+			},
+			want: append(slices.Clone(legacyControllers), legacyControllerGroups["apiexport"]...),
+		},
+		"controllers run individually and disabled": {
+			mutate: func(c *Controllers) {
+				c.EnableAll = false
+				c.IndividuallyEnabled = []string{"quota"}
+				c.EnabledControllers = []string{"*", "-kube-namespace-controller"}
+			},
+			want: append(slices.DeleteFunc(slices.Clone(legacyControllers), func(name string) bool {
+				return name == "kube-namespace-controller"
+			}), "kcp-kube-quota"),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := NewControllers()
+			c.SAController.ServiceAccountKeyFile = "sa.key"
+			tc.mutate(c)
+
+			require.NoError(t, c.Complete(""))
+			require.ElementsMatch(t, tc.want, c.EnabledControllers)
+			require.Empty(t, c.Validate())
+		})
+	}
+}
+
+func TestLegacyControllersKnown(t *testing.T) {
+	for _, name := range legacyControllers {
+		require.True(t, KnownControllers.Has(name), "unknown controller %q", name)
+	}
+	for group, names := range legacyControllerGroups {
+		for _, name := range names {
+			require.True(t, KnownControllers.Has(name), "unknown controller %q in group %q", name, group)
+		}
+	}
+}
+
+func TestValidateServiceAccountsToEnsure(t *testing.T) {
+	tests := map[string]struct {
+		names   []string
+		wantErr bool
+	}{
+		"default":   {names: []string{"default"}},
+		"extra":     {names: []string{"default", "builder"}},
+		"none":      {},
+		"invalid":   {names: []string{"Not_Valid"}, wantErr: true},
+		"duplicate": {names: []string{"default", "default"}, wantErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := NewControllers()
+			c.ServiceAccountsToEnsure = tc.names
+
+			var gotErr bool
+			for _, err := range c.Validate() {
+				if strings.Contains(err.Error(), "--service-accounts-to-ensure") {
+					gotErr = true
+				}
+			}
+			require.Equal(t, tc.wantErr, gotErr)
+		})
+	}
+}
+
+func TestValidateInformerSelectors(t *testing.T) {
+	tests := map[string]struct {
+		labelSelectors map[string]string
+		fieldSelectors map[string]string
+		wantErr        bool
+	}{
+		"none": {},
+		"label selector": {
+			labelSelectors: map[string]string{"kube-service-account-controller": "team in (a,b)"},
+		},
+		"field selector": {
+			fieldSelectors: map[string]string{"kube-root-ca-configmap-controller": "metadata.name!=kube-system"},
+		},
+		"unsupported controller": {
+			labelSelectors: map[string]string{"kcp-apibinding": "team=a"},
+			wantErr:        true,
+		},
+		"invalid label selector": {
+			labelSelectors: map[string]string{"kube-service-account-controller": "team in a"},
+			wantErr:        true,
+		},
+		"invalid field selector": {
+			fieldSelectors: map[string]string{"kube-service-account-controller": "metadata.name"},
+			wantErr:        true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := NewControllers()
+			c.InformerLabelSelectors = tc.labelSelectors
+			c.InformerFieldSelectors = tc.fieldSelectors
+
+			var gotErr bool
+			for _, err := range c.Validate() {
+				if strings.Contains(err.Error(), "--controller-informer-") {
+					gotErr = true
+				}
+			}
+			require.Equal(t, tc.wantErr, gotErr)
+		})
+	}
+}
+
+func TestValidateUnknownControllers(t *testing.T) {
+	tests := map[string]struct {
+		mutate  func(c *Controllers)
+		wantErr string
+	}{
+		"default": {
+			mutate: func(c *Controllers) {},
+		},
+		"known controller disabled": {
+			mutate: func(c *Controllers) { c.EnabledControllers = []string{"*", "-kube-namespace-controller"} },
+		},
+		"unknown controller enabled": {
+			mutate:  func(c *Controllers) { c.EnabledControllers = []string{"kcp-unknown"} },
+			wantErr: `--controllers: unknown controller "kcp-unknown"`,
+		},
+		"unknown controller disabled": {
+			mutate:  func(c *Controllers) { c.EnabledControllers = []string{"*", "-kcp-unknown"} },
+			wantErr: `--controllers: unknown controller "kcp-unknown"`,
+		},
+		"unknown controller workers": {
+			mutate:  func(c *Controllers) { c.Workers = map[string]int{"kcp-unknown": 2} },
+			wantErr: `--controller-workers: unknown controller "kcp-unknown"`,
+		},
+		"known controller max retries": {
+			mutate: func(c *Controllers) { c.MaxRetries = map[string]int{"*": 5, "kcp-apibinding": 20} },
+		},
+		"unknown controller max retries": {
+			mutate:  func(c *Controllers) { c.MaxRetries = map[string]int{"kcp-unknown": 5} },
+			wantErr: `--controller-max-retries: unknown controller "kcp-unknown"`,
+		},
+		"known controller reconcile timeouts": {
+			mutate: func(c *Controllers) { c.ReconcileTimeouts = map[string]string{"*": "1m", "kcp-apibinding": "30s"} },
+		},
+		"unknown controller reconcile timeouts": {
+			mutate:  func(c *Controllers) { c.ReconcileTimeouts = map[string]string{"kcp-unknown": "30s"} },
+			wantErr: `--controller-reconcile-timeouts: unknown controller "kcp-unknown"`,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := NewControllers()
+			tc.mutate(c)
+
+			var errs []string
+			for _, err := range c.Validate() {
+				if strings.Contains(err.Error(), "unknown controller") {
+					errs = append(errs, err.Error())
+				}
+			}
+			if tc.wantErr == "" {
+				require.Empty(t, errs)
+				return
+			}
+			require.Equal(t, []string{tc.wantErr}, errs)
+		})
+	}
+}
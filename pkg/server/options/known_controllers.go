@@ -0,0 +1,142 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package options
+
+import (
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// KnownControllers are the names of all controllers the server can run, whether they are
+// enabled or not. Flags taking controller names, like --controllers and --controller-workers,
+// are validated against them. The server refuses to register a controller missing here.
+var KnownControllers = sets.New[string](
+	"kube-cluster-role-aggregation-controller",
+	"kube-namespace-controller",
+	"kube-root-ca-configmap-controller",
+	"kube-service-account-controller",
+	"kube-service-account-token-controller",
+	"kube-validatingadmissionpolicy-status",
+
+	"kcp-api-export-extra-annotation-sync",
+	"kcp-api-export-identity-provider",
+	"kcp-apibinder-initializer",
+	"kcp-apibinding",
+	"kcp-apibinding-schemacompatibility",
+	"kcp-apibindingdeletion",
+	"kcp-apiexport",
+	"kcp-apiexport-binding-count",
+	"kcp-apiexport-expiry",
+	"kcp-apiexport-identity-conflict",
+	"kcp-apiexportdeletion",
+	"kcp-apiexportendpointslice",
+	"kcp-apiexportendpointslice-urls",
+	"kcp-apis-replicate-clusterrole",
+	"kcp-apis-replicate-clusterrolebinding",
+	"kcp-apis-replicate-logicalcluster",
+	"kcp-core-replicate-clusterrole",
+	"kcp-core-replicate-clusterrolebinding",
+	"kcp-crdcleanup",
+	"kcp-garbage-collector",
+	"kcp-kube-quota",
+	"kcp-logicalcluster",
+	"kcp-logicalcluster-deletion",
+	"kcp-logicalclustercleanup",
+	"kcp-permissionclaimautoaccept",
+	"kcp-permissionclaimlabel",
+	"kcp-replication-controller",
+	"kcp-resource-permissionclaimlabel",
+	"kcp-root-ca-configmap-cleanup",
+	"kcp-shard",
+	"kcp-systemcrdrepair",
+	"kcp-tenancy-logicalcluster",
+	"kcp-tenancy-replicate-clusterrole",
+	"kcp-tenancy-replicate-clusterrolebinding",
+	"kcp-tenancy-replicate-logicalcluster",
+	"kcp-topology-partitionset",
+	"kcp-workspace",
+	"kcp-workspace-mounts",
+	"kcp-workspacedefaults",
+	"kcp-workspacedefaults-pruning",
+	"kcp-workspacetype",
+	"kcp-workspacetypes-bootstrap-universal",
+)
+
+// legacyControllers are the controllers run with --run-controllers=false. Before --controllers
+// existed, these could not be disabled.
+var legacyControllers = []string{
+	"kube-cluster-role-aggregation-controller",
+	"kube-namespace-controller",
+	"kube-root-ca-configmap-controller",
+	"kube-service-account-controller",
+	"kube-service-account-token-controller",
+	"kube-validatingadmissionpolicy-status",
+	"kcp-api-export-identity-provider",
+	"kcp-replication-controller",
+	"kcp-root-ca-configmap-cleanup",
+}
+
+// legacyControllerGroups maps the names accepted by the deprecated
+// --unsupported-run-individual-controllers flag to the controllers they run.
+var legacyControllerGroups = map[string][]string{
+	"workspace-scheduler": {
+		"kcp-workspace",
+		"kcp-shard",
+		"kcp-workspacetype",
+		"kcp-workspacetypes-bootstrap-universal",
+		"kcp-workspace-mounts",
+		"kcp-tenancy-logicalcluster",
+		"kcp-workspacedefaults",
+		"kcp-workspacedefaults-pruning",
+		"kcp-logicalcluster-deletion",
+		"kcp-logicalcluster",
+	},
+	"apibinding": {
+		"kcp-apibinding",
+		"kcp-permissionclaimlabel",
+		"kcp-resource-permissionclaimlabel",
+		"kcp-apibindingdeletion",
+		"kcp-crdcleanup",
+		"kcp-logicalclustercleanup",
+		"kcp-api-export-extra-annotation-sync",
+		"kcp-permissionclaimautoaccept",
+		"kcp-apibinding-schemacompatibility",
+	},
+	"systemcrdrepair": {"kcp-systemcrdrepair"},
+	"apiexport": {
+		"kcp-apiexport",
+		"kcp-apiexport-identity-conflict",
+		"kcp-apiexport-expiry",
+		"kcp-apiexport-binding-count",
+		"kcp-apiexportdeletion",
+	},
+	"apisreplicateclusterrole":             {"kcp-apis-replicate-clusterrole"},
+	"apisreplicateclusterrolebinding":      {"kcp-apis-replicate-clusterrolebinding"},
+	"apisreplicatelogicalcluster":          {"kcp-apis-replicate-logicalcluster"},
+	"tenancyreplicatelogicalcluster":       {"kcp-tenancy-replicate-logicalcluster"},
+	"corereplicateclusterrole":             {"kcp-core-replicate-clusterrole"},
+	"corereplicateclusterrolebinding":      {"kcp-core-replicate-clusterrolebinding"},
+	"tenancyreplicateclusterrole":          {"kcp-tenancy-replicate-clusterrole"},
+	"tenancyreplicationclusterrolebinding": {"kcp-tenancy-replicate-clusterrolebinding"},
+	"apiexportendpointslice": {
+		"kcp-apiexportendpointslice",
+		"kcp-apiexportendpointslice-urls",
+	},
+	"apibinder":        {"kcp-apibinder-initializer"},
+	"partition":        {"kcp-topology-partitionset"},
+	"quota":            {"kcp-kube-quota"},
+	"garbagecollector": {"kcp-garbage-collector"},
+}
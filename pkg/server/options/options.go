@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"net"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
@@ -65,6 +66,8 @@ type ExtraOptions struct {
 	LogicalClusterAdminKubeconfig         string
 	ExternalLogicalClusterAdminKubeconfig string
 	ConversionCELTransformationTimeout    time.Duration
+	InformerStartStagger                  time.Duration
+	DynamicInformersNamespacedOnly        bool
 	BatteriesIncluded                     []string
 	// DEVELOPMENT ONLY. AdditionalMappingsFile is the path to a file that contains additional mappings
 	// for the mini-front-proxy to use. The file should be in the format of the
@@ -174,6 +177,8 @@ func (o *Options) AddFlags(fss *cliflag.NamedFlagSets) {
 	fs.MarkHidden("experimental-bind-free-port") //nolint:errcheck
 
 	fs.DurationVar(&o.Extra.ConversionCELTransformationTimeout, "conversion-cel-transformation-timeout", o.Extra.ConversionCELTransformationTimeout, "Maximum amount of time that CEL transformations may take per object conversion.")
+	fs.DurationVar(&o.Extra.InformerStartStagger, "informer-start-stagger", o.Extra.InformerStartStagger, "Delay between the initial LIST requests of the informers of kcp, CRD and cache server objects on startup, to spread their load on a cold server. The informers of Kubernetes objects are started by the embedded Kubernetes apiserver and are not delayed. 0 starts them all at once.")
+	fs.BoolVar(&o.Extra.DynamicInformersNamespacedOnly, "dynamic-informers-namespaced-only", o.Extra.DynamicInformersNamespacedOnly, "Only start dynamic informers for namespaced resources, saving the memory and watches of cluster-scoped ones. Consumers of the dynamic informers, like the garbage collector and the quota controller, will not see cluster-scoped resources then.")
 
 	fs.StringSliceVar(&o.Extra.BatteriesIncluded, "batteries-included", o.Extra.BatteriesIncluded, fmt.Sprintf(
 		`A list of batteries included (= default objects that might be unwanted in production, but are very helpful in trying out kcp or for development). These are the possible values: %s.
@@ -193,6 +198,24 @@ Prefixing with - or + means to remove from the default set or add to the default
 		"Options are:\n"+strings.Join(kcpfeatures.KnownFeatures(), "\n")) // hide kube-only gates
 }
 
+// apiExportFinalizerPlugin is the admission plugin adding the finalizer removed by the
+// kcp-apiexportdeletion controller.
+const apiExportFinalizerPlugin = "apis.kcp.io/APIExportDeletionFinalizer"
+
+// disableFinalizersWithoutController disables the admission plugins adding finalizers that only
+// a controller of this server removes, if that controller does not run. Otherwise, the objects
+// would never finish deleting.
+func (o *Options) disableFinalizersWithoutController() {
+	if o.Controllers.IsControllerEnabled("kcp-apiexportdeletion") {
+		return
+	}
+
+	admission := o.GenericControlPlane.Admission.GenericAdmission
+	if !slices.Contains(admission.DisablePlugins, apiExportFinalizerPlugin) {
+		admission.DisablePlugins = append(admission.DisablePlugins, apiExportFinalizerPlugin)
+	}
+}
+
 func (o *CompletedOptions) Validate() []error {
 	var errs []error
 
@@ -299,6 +322,7 @@ func (o *Options) Complete(rootDir string) (*CompletedOptions, error) {
 	if err := o.Controllers.Complete(rootDir); err != nil {
 		return nil, err
 	}
+	o.disableFinalizersWithoutController()
 	if o.Controllers.SAController.ServiceAccountKeyFile != "" && !filepath.IsAbs(o.Controllers.SAController.ServiceAccountKeyFile) {
 		o.Controllers.SAController.ServiceAccountKeyFile, err = filepath.Abs(o.Controllers.SAController.ServiceAccountKeyFile)
 		if err != nil {
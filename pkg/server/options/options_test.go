@@ -0,0 +1,62 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package options
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDisableFinalizersWithoutController(t *testing.T) {
+	tests := map[string]struct {
+		mutate func(c *Controllers)
+
+		wantDisabled bool
+	}{
+		"all controllers": {
+			mutate: func(c *Controllers) {},
+		},
+		"controllers not running": {
+			mutate:       func(c *Controllers) { c.EnableAll = false },
+			wantDisabled: true,
+		},
+		"apiexport controllers run individually": {
+			mutate: func(c *Controllers) {
+				c.EnableAll = false
+				c.IndividuallyEnabled = []string{"apiexport"}
+			},
+		},
+		"deletion controller disabled": {
+			mutate:       func(c *Controllers) { c.EnabledControllers = []string{"*", "-kcp-apiexportdeletion"} },
+			wantDisabled: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			o := NewOptions(t.TempDir())
+			o.Controllers.SAController.ServiceAccountKeyFile = "sa.key"
+			tc.mutate(&o.Controllers)
+
+			require.NoError(t, o.Controllers.Complete(""))
+			o.disableFinalizersWithoutController()
+			require.Equal(t, tc.wantDisabled, slices.Contains(o.GenericControlPlane.Admission.GenericAdmission.DisablePlugins, apiExportFinalizerPlugin))
+		})
+	}
+}
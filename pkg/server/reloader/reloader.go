@@ -0,0 +1,117 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package reloader picks up changes of files the server reads on startup, like
+// the root CA and the service account signing key.
+package reloader
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"k8s.io/klog/v2"
+)
+
+// RunFunc runs a consumer of the given file content until ctx is done.
+type RunFunc func(ctx context.Context, data []byte)
+
+// Reloader runs a consumer of a file and restarts it with the new content
+// whenever the file changes on disk.
+type Reloader struct {
+	file     string
+	debounce time.Duration
+}
+
+// NewReloader returns a reloader for the given file. Changes are only picked up
+// once the file has been quiet for the debounce duration, so that atomic swaps
+// (remove and rename, or the ..data symlink swap of mounted secrets) are seen
+// as one change.
+func NewReloader(file string, debounce time.Duration) *Reloader {
+	return &Reloader{
+		file:     file,
+		debounce: debounce,
+	}
+}
+
+// Run reads the file, calls run with its content, and calls it again with a new
+// context and the new content after each change, until ctx is done. The file
+// must be readable initially.
+func (r *Reloader) Run(ctx context.Context, run RunFunc) error {
+	logger := klog.FromContext(ctx).WithValues("file", r.file)
+
+	data, err := os.ReadFile(r.file)
+	if err != nil {
+		return fmt.Errorf("error reading %s: %w", r.file, err)
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+
+	// watch the directory, the file itself might be replaced.
+	if err := watcher.Add(filepath.Dir(r.file)); err != nil {
+		return fmt.Errorf("error watching %s: %w", r.file, err)
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	go run(runCtx, data)
+
+	var debounce <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			cancel()
+			return nil
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				cancel()
+				return nil
+			}
+			logger.Error(err, "error watching file")
+		case _, ok := <-watcher.Events:
+			if !ok {
+				cancel()
+				return nil
+			}
+			debounce = time.After(r.debounce)
+		case <-debounce:
+			debounce = nil
+
+			newData, err := os.ReadFile(r.file)
+			if err != nil {
+				logger.Error(err, "error reading file, keeping the old content")
+				continue
+			}
+			if len(newData) == 0 || bytes.Equal(newData, data) {
+				continue
+			}
+
+			logger.Info("file changed, restarting with the new content")
+			cancel()
+			data = newData
+			runCtx, cancel = context.WithCancel(ctx)
+			go run(runCtx, data)
+		}
+	}
+}
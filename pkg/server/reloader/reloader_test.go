@@ -0,0 +1,82 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reloader
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+func TestReloader(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "ca.crt")
+	require.NoError(t, os.WriteFile(file, []byte("old"), 0600))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	published := make(chan string, 10)
+	stopped := make(chan string, 10)
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- NewReloader(file, 50*time.Millisecond).Run(ctx, func(ctx context.Context, caData []byte) {
+			published <- string(caData)
+			<-ctx.Done()
+			stopped <- string(caData)
+		})
+	}()
+
+	require.Equal(t, "old", receive(t, published))
+
+	// replace the file atomically, like most rotation tooling does.
+	tmp := filepath.Join(dir, "ca.crt.tmp")
+	require.NoError(t, os.WriteFile(tmp, []byte("new"), 0600))
+	require.NoError(t, os.Rename(tmp, file))
+
+	require.Equal(t, "old", receive(t, stopped))
+	require.Equal(t, "new", receive(t, published))
+
+	// writing the same content again does not restart.
+	require.NoError(t, os.WriteFile(file, []byte("new"), 0600))
+	select {
+	case caData := <-published:
+		t.Fatalf("unexpected restart with %q", caData)
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	cancel()
+	require.NoError(t, <-errCh)
+	require.Equal(t, "new", receive(t, stopped))
+}
+
+func receive(t *testing.T, ch <-chan string) string {
+	t.Helper()
+	select {
+	case s := <-ch:
+		return s
+	case <-time.After(wait.ForeverTestTimeout):
+		t.Fatal("timed out")
+		return ""
+	}
+}
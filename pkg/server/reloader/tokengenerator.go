@@ -0,0 +1,160 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reloader
+
+import (
+	"context"
+	"crypto"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"gopkg.in/square/go-jose.v2/jwt"
+
+	"k8s.io/client-go/util/keyutil"
+	"k8s.io/klog/v2"
+	"k8s.io/kubernetes/pkg/serviceaccount"
+)
+
+// TokenGenerator is a service account token generator that switches to a new
+// signing key when the key file changes on disk.
+//
+// Every time the signing key changes, the verification key files are read again
+// too. A signing key is only used if its public key is among them. As the
+// authenticator verifying the tokens does not reload its keys, the public key must
+// also be among the verification keys the server started with, i.e. it must be
+// added to the verification keys (with a restart) before the signing key is
+// rotated. Tokens signed with the old key keep verifying as long as its public key
+// stays among the verification keys.
+type TokenGenerator struct {
+	issuer               string
+	file                 string
+	verificationKeyFiles []string
+	// startupKeys are the verification keys the server started with.
+	startupKeys []interface{}
+
+	current atomic.Pointer[serviceaccount.TokenGenerator]
+}
+
+var _ serviceaccount.TokenGenerator = &TokenGenerator{}
+
+// NewTokenGenerator returns a token generator signing with the private key in the
+// given file. verificationKeyFiles are the files with the public keys the server
+// accepts. It fails if the public key of the signing key is not among them.
+func NewTokenGenerator(issuer, file string, verificationKeyFiles []string) (*TokenGenerator, error) {
+	g := &TokenGenerator{
+		issuer:               issuer,
+		file:                 file,
+		verificationKeyFiles: verificationKeyFiles,
+	}
+
+	startupKeys, err := g.readVerificationKeys()
+	if err != nil {
+		return nil, err
+	}
+	g.startupKeys = startupKeys
+
+	privateKey, err := keyutil.PrivateKeyFromFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("error reading key for service account token controller: %w", err)
+	}
+	if !verifiable(privateKey, startupKeys) {
+		return nil, fmt.Errorf("the public key of the service account key %s is not among the verification keys", file)
+	}
+	if err := g.setKey(privateKey); err != nil {
+		return nil, err
+	}
+
+	return g, nil
+}
+
+// GenerateToken generates a token signed with the current key.
+func (g *TokenGenerator) GenerateToken(claims *jwt.Claims, privateClaims interface{}) (string, error) {
+	return (*g.current.Load()).GenerateToken(claims, privateClaims)
+}
+
+// Run watches the key file and switches to the new key on changes, until ctx is done.
+func (g *TokenGenerator) Run(ctx context.Context) error {
+	logger := klog.FromContext(ctx).WithValues("file", g.file)
+
+	return NewReloader(g.file, time.Second).Run(ctx, func(ctx context.Context, data []byte) {
+		privateKey, err := keyutil.ParsePrivateKeyPEM(data)
+		if err != nil {
+			logger.Error(err, "error parsing service account key, keeping the old key")
+			return
+		}
+		verificationKeys, err := g.readVerificationKeys()
+		if err != nil {
+			logger.Error(err, "keeping the old service account key")
+			return
+		}
+		if !verifiable(privateKey, verificationKeys) {
+			logger.Error(nil, "the public key of the new service account key is not among the verification keys, keeping the old key")
+			return
+		}
+		if !verifiable(privateKey, g.startupKeys) {
+			logger.Error(nil, "the public key of the new service account key was not among the verification keys at startup, keeping the old key until restart")
+			return
+		}
+		if err := g.setKey(privateKey); err != nil {
+			logger.Error(err, "keeping the old service account key")
+			return
+		}
+		logger.Info("switched to the new service account key")
+	})
+}
+
+func (g *TokenGenerator) setKey(privateKey interface{}) error {
+	generator, err := serviceaccount.JWTTokenGenerator(g.issuer, privateKey)
+	if err != nil {
+		return fmt.Errorf("failed to build token generator: %w", err)
+	}
+	g.current.Store(&generator)
+
+	return nil
+}
+
+func (g *TokenGenerator) readVerificationKeys() ([]interface{}, error) {
+	var keys []interface{}
+	for _, file := range g.verificationKeyFiles {
+		publicKeys, err := keyutil.PublicKeysFromFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("error reading service account verification keys: %w", err)
+		}
+		keys = append(keys, publicKeys...)
+	}
+	return keys, nil
+}
+
+// verifiable returns whether the public key of privateKey is among verificationKeys. It is
+// false if verificationKeys is empty.
+func verifiable(privateKey interface{}, verificationKeys []interface{}) bool {
+	signer, ok := privateKey.(crypto.Signer)
+	if !ok {
+		return false
+	}
+	publicKey, ok := signer.Public().(interface{ Equal(crypto.PublicKey) bool })
+	if !ok {
+		return false
+	}
+	for _, key := range verificationKeys {
+		if publicKey.Equal(key) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reloader
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/square/go-jose.v2/jwt"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+func TestTokenGeneratorRotation(t *testing.T) {
+	oldKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	newKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	unknownKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	lateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	file := filepath.Join(dir, "sa.key")
+	writeKey(t, file, oldKey)
+	verificationFile := filepath.Join(dir, "sa.pub")
+	writePublicKeys(t, verificationFile, oldKey, newKey, lateKey)
+
+	g, err := NewTokenGenerator("kcp", file, []string{verificationFile})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = g.Run(ctx)
+	}()
+
+	oldToken := generateToken(t, g)
+	require.True(t, verifies(oldToken, &oldKey.PublicKey))
+
+	// a key the server cannot verify is not picked up.
+	writeKey(t, file, unknownKey)
+	time.Sleep(2 * time.Second)
+	require.True(t, verifies(generateToken(t, g), &oldKey.PublicKey))
+
+	// the verification keys are read again, hence a key removed from them is not picked up either.
+	writePublicKeys(t, verificationFile, oldKey, newKey)
+	writeKey(t, file, lateKey)
+	time.Sleep(2 * time.Second)
+	require.True(t, verifies(generateToken(t, g), &oldKey.PublicKey))
+
+	writeKey(t, file, newKey)
+	require.Eventually(t, func() bool {
+		return verifies(generateToken(t, g), &newKey.PublicKey)
+	}, wait.ForeverTestTimeout, 100*time.Millisecond)
+
+	// tokens signed before the rotation keep verifying with the old public key.
+	require.True(t, verifies(oldToken, &oldKey.PublicKey))
+}
+
+func TestTokenGeneratorRejectsUnverifiableKey(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	file := filepath.Join(dir, "sa.key")
+	writeKey(t, file, key)
+	verificationFile := filepath.Join(dir, "sa.pub")
+	writePublicKeys(t, verificationFile, otherKey)
+
+	_, err = NewTokenGenerator("kcp", file, []string{verificationFile})
+	require.Error(t, err, "expected a signing key without verification key to be rejected")
+
+	_, err = NewTokenGenerator("kcp", file, nil)
+	require.Error(t, err, "expected a signing key to be rejected without any verification keys")
+
+	_, err = NewTokenGenerator("kcp", file, []string{file})
+	require.NoError(t, err, "expected the signing key file to be usable as verification key file")
+}
+
+func generateToken(t *testing.T, g *TokenGenerator) string {
+	t.Helper()
+	token, err := g.GenerateToken(&jwt.Claims{Subject: "test"}, map[string]interface{}{})
+	require.NoError(t, err)
+	return token
+}
+
+func writePublicKeys(t *testing.T, file string, keys ...*rsa.PrivateKey) {
+	t.Helper()
+	var bs []byte
+	for _, key := range keys {
+		der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+		require.NoError(t, err)
+		bs = append(bs, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})...)
+	}
+	require.NoError(t, os.WriteFile(file, bs, 0600))
+}
+
+func writeKey(t *testing.T, file string, key *rsa.PrivateKey) {
+	t.Helper()
+	bs := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	tmp := file + ".tmp"
+	require.NoError(t, os.WriteFile(tmp, bs, 0600))
+	require.NoError(t, os.Rename(tmp, file))
+}
+
+func verifies(token string, publicKey *rsa.PublicKey) bool {
+	parsed, err := jwt.ParseSigned(token)
+	if err != nil {
+		return false
+	}
+	var claims jwt.Claims
+	return parsed.Claims(publicKey, &claims) == nil
+}
@@ -23,10 +23,12 @@ import (
 	_ "net/http/pprof"
 	"net/url"
 	"os"
+	"sync"
 	"time"
 
 	"github.com/kcp-dev/logicalcluster/v3"
 
+	corev1 "k8s.io/api/core/v1"
 	extensionsapiserver "k8s.io/apiextensions-apiserver/pkg/apiserver"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -36,11 +38,14 @@ import (
 	"k8s.io/apimachinery/pkg/util/wait"
 	genericapifilters "k8s.io/apiserver/pkg/endpoints/filters"
 	genericapiserver "k8s.io/apiserver/pkg/server"
+	"k8s.io/apiserver/pkg/server/healthz"
 	"k8s.io/apiserver/pkg/util/notfoundhandler"
+	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/leaderelection"
 	"k8s.io/client-go/tools/leaderelection/resourcelock"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
 	controlplaneapiserver "k8s.io/kubernetes/pkg/controlplane/apiserver"
 	"k8s.io/kubernetes/pkg/controlplane/apiserver/miniaggregator"
@@ -53,17 +58,19 @@ import (
 	systemcrds "github.com/kcp-dev/kcp/config/system-crds"
 	bootstrappolicy "github.com/kcp-dev/kcp/pkg/authorization/bootstrap"
 	"github.com/kcp-dev/kcp/pkg/informer"
+	"github.com/kcp-dev/kcp/pkg/logging"
 	metadataclient "github.com/kcp-dev/kcp/pkg/metadata"
+	"github.com/kcp-dev/kcp/pkg/network"
 	"github.com/kcp-dev/kcp/pkg/reconciler/cache/replication"
+	"github.com/kcp-dev/kcp/pkg/reconciler/events"
 	"github.com/kcp-dev/kcp/pkg/reconciler/kubequota"
+	"github.com/kcp-dev/kcp/pkg/reconciler/limits"
 	"github.com/kcp-dev/kcp/pkg/server/options/batteries"
 	virtualrootapiserver "github.com/kcp-dev/kcp/pkg/virtual/framework/rootapiserver"
 	"github.com/kcp-dev/kcp/sdk/apis/core"
 	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
 )
 
-const resyncPeriod = 10 * time.Hour
-
 type Server struct {
 	CompletedConfig
 
@@ -76,6 +83,21 @@ type Server struct {
 	rootPhase1FinishedCh chan struct{}
 
 	controllers map[string]*controllerWrapper
+
+	controllerStateLock sync.Mutex
+	// pendingControllers holds the names of the started controllers that are still waiting
+	// for their informers to sync. It is reported by the controllers readyz check.
+	pendingControllers sets.Set[string]
+	// failedControllers holds the errors of the started controllers that failed to wait for
+	// their informers to sync. They are reported by the controllers readyz check.
+	failedControllers map[string]error
+	// runningControllers holds the controllers whose runners are running. They are drained
+	// on shutdown.
+	runningControllers map[string]*controllerWrapper
+
+	// controllerStartupErrCh receives the error of the first controller that did not start within
+	// --controller-startup-timeout. Run returns it, such that the server exits.
+	controllerStartupErrCh chan error
 }
 
 func (s *Server) AddPostStartHook(name string, hook genericapiserver.PostStartHookFunc) error {
@@ -86,12 +108,23 @@ func (s *Server) AddPreShutdownHook(name string, hook genericapiserver.PreShutdo
 	return s.MiniAggregator.GenericAPIServer.AddPreShutdownHook(name, hook)
 }
 
+// AddReadyzChecks adds checks gating /readyz of the server, e.g. on the health of controllers
+// of an embedder. It must be called before the server is run.
+func (s *Server) AddReadyzChecks(checks ...healthz.HealthChecker) error {
+	return s.MiniAggregator.GenericAPIServer.AddReadyzChecks(checks...)
+}
+
 func NewServer(c CompletedConfig) (*Server, error) {
 	s := &Server{
 		CompletedConfig:      c,
 		syncedCh:             make(chan struct{}),
 		rootPhase1FinishedCh: make(chan struct{}),
 		controllers:          make(map[string]*controllerWrapper),
+		pendingControllers:   sets.New[string](),
+		failedControllers:    map[string]error{},
+		runningControllers:   make(map[string]*controllerWrapper),
+
+		controllerStartupErrCh: make(chan error, 1),
 	}
 
 	notFoundHandler := notfoundhandler.New(c.GenericConfig.Serializer, genericapifilters.NoMuxAndDiscoveryIncompleteKey)
@@ -134,6 +167,12 @@ func NewServer(c CompletedConfig) (*Server, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err := s.AddReadyzChecks(c.Options.Controllers.ReadyzChecks...); err != nil {
+		return nil, err
+	}
+	if err := s.AddReadyzChecks(healthz.NamedCheck("controllers", s.checkControllersSynced)); err != nil {
+		return nil, err
+	}
 
 	s.Apis.GenericAPIServer.Handler.GoRestfulContainer.Filter(
 		mergeCRDsIntoCoreGroup(
@@ -153,12 +192,16 @@ func NewServer(c CompletedConfig) (*Server, error) {
 	if err != nil {
 		return nil, err
 	}
+	var gvrSource informer.GVRSource = crdGVRSource
+	if c.Options.Extra.DynamicInformersNamespacedOnly {
+		gvrSource = informer.NewNamespacedGVRSource(crdGVRSource)
+	}
 
 	s.DiscoveringDynamicSharedInformerFactory, err = informer.NewDiscoveringDynamicSharedInformerFactory(
 		metadataClusterClient,
 		func(obj interface{}) bool { return true },
 		nil,
-		crdGVRSource,
+		gvrSource,
 		cache.Indexers{},
 	)
 	if err != nil {
@@ -190,7 +233,6 @@ func (s *Server) uninstallControllers() {
 
 /* Registering all controllers and informers before starting informers. */
 func (s *Server) installControllers(ctx context.Context, controllerConfig *rest.Config, gvrs map[schema.GroupVersionResource]replication.ReplicatedGVR) error {
-	logger := klog.FromContext(ctx).WithValues("component", "kcp")
 	if err := s.installKubeNamespaceController(ctx, controllerConfig); err != nil {
 		return err
 	}
@@ -210,6 +252,9 @@ func (s *Server) installControllers(ctx context.Context, controllerConfig *rest.
 	if err := s.installRootCAConfigMapController(ctx, s.Apis.GenericAPIServer.LoopbackClientConfig); err != nil {
 		return err
 	}
+	if err := s.installRootCAConfigMapCleanupController(ctx, controllerConfig); err != nil {
+		return err
+	}
 
 	if err := s.installKubeValidatingAdmissionPolicyStatusController(ctx, controllerConfig); err != nil {
 		return err
@@ -222,132 +267,105 @@ func (s *Server) installControllers(ctx context.Context, controllerConfig *rest.
 		return err
 	}
 
-	enabled := sets.New[string](s.Options.Controllers.IndividuallyEnabled...)
-	if len(enabled) > 0 {
-		logger.WithValues("controllers", enabled).Info("starting controllers individually")
+	if err := s.installWorkspaceScheduler(ctx, controllerConfig, s.LogicalClusterAdminConfig, s.ExternalLogicalClusterAdminConfig); err != nil {
+		return err
 	}
-
-	if s.Options.Controllers.EnableAll || enabled.Has("workspace-scheduler") {
-		if err := s.installWorkspaceScheduler(ctx, controllerConfig, s.LogicalClusterAdminConfig, s.ExternalLogicalClusterAdminConfig); err != nil {
-			return err
-		}
-		if err := s.installWorkspaceMountsScheduler(ctx, controllerConfig); err != nil {
-			return err
-		}
-		if err := s.installTenancyLogicalClusterController(ctx, controllerConfig); err != nil {
-			return err
-		}
-		if err := s.installLogicalClusterDeletionController(ctx, controllerConfig, s.LogicalClusterAdminConfig, s.ExternalLogicalClusterAdminConfig); err != nil {
-			return err
-		}
-		if err := s.installLogicalCluster(ctx, controllerConfig); err != nil {
-			return err
-		}
+	if err := s.installWorkspaceMountsScheduler(ctx, controllerConfig); err != nil {
+		return err
 	}
-
-	if s.Options.Controllers.EnableAll || enabled.Has("apibinding") {
-		if err := s.installAPIBindingController(ctx, controllerConfig, s.DiscoveringDynamicSharedInformerFactory); err != nil {
-			return err
-		}
-		if err := s.installCRDCleanupController(ctx, controllerConfig); err != nil {
-			return err
-		}
-		if err := s.installLogicalClusterCleanupController(ctx, controllerConfig); err != nil {
-			return err
-		}
-		if err := s.installExtraAnnotationSyncController(ctx, controllerConfig); err != nil {
-			return err
-		}
+	if err := s.installTenancyLogicalClusterController(ctx, controllerConfig); err != nil {
+		return err
 	}
-
-	if s.Options.Controllers.EnableAll || enabled.Has("apiexport") {
-		if err := s.installAPIExportController(ctx, controllerConfig); err != nil {
-			return err
-		}
+	if err := s.installWorkspaceDefaultsController(ctx, controllerConfig); err != nil {
+		return err
 	}
-
-	if s.Options.Controllers.EnableAll || enabled.Has("apisreplicateclusterrole") {
-		if err := s.installApisReplicateClusterRoleControllers(ctx, controllerConfig); err != nil {
-			return err
-		}
+	if err := s.installLogicalClusterDeletionController(ctx, controllerConfig, s.LogicalClusterAdminConfig, s.ExternalLogicalClusterAdminConfig); err != nil {
+		return err
 	}
-
-	if s.Options.Controllers.EnableAll || enabled.Has("apisreplicateclusterrolebinding") {
-		if err := s.installApisReplicateClusterRoleBindingControllers(ctx, controllerConfig); err != nil {
-			return err
-		}
+	if err := s.installLogicalCluster(ctx, controllerConfig); err != nil {
+		return err
 	}
-
-	if s.Options.Controllers.EnableAll || enabled.Has("apisreplicatelogicalcluster") {
-		if err := s.installApisReplicateLogicalClusterControllers(ctx, controllerConfig); err != nil {
-			return err
-		}
+	if err := s.installAPIBindingController(ctx, controllerConfig, s.DiscoveringDynamicSharedInformerFactory); err != nil {
+		return err
 	}
-
-	if s.Options.Controllers.EnableAll || enabled.Has("tenancyreplicatelogicalcluster") {
-		if err := s.installTenancyReplicateLogicalClusterControllers(ctx, controllerConfig); err != nil {
-			return err
-		}
+	if err := s.installCRDCleanupController(ctx, controllerConfig); err != nil {
+		return err
 	}
-
-	if s.Options.Controllers.EnableAll || enabled.Has("corereplicateclusterrole") {
-		if err := s.installCoreReplicateClusterRoleControllers(ctx, controllerConfig); err != nil {
-			return err
-		}
+	if err := s.installLogicalClusterCleanupController(ctx, controllerConfig); err != nil {
+		return err
 	}
-
-	if s.Options.Controllers.EnableAll || enabled.Has("corereplicateclusterrolebinding") {
-		if err := s.installCoreReplicateClusterRoleBindingControllers(ctx, controllerConfig); err != nil {
-			return err
-		}
+	if err := s.installExtraAnnotationSyncController(ctx, controllerConfig); err != nil {
+		return err
 	}
-
-	if s.Options.Controllers.EnableAll || enabled.Has("tenancyreplicateclusterrole") {
-		if err := s.installTenancyReplicateClusterRoleControllers(ctx, controllerConfig); err != nil {
-			return err
-		}
+	if err := s.installPermissionClaimAutoAcceptController(ctx, controllerConfig); err != nil {
+		return err
 	}
-	if s.Options.Controllers.EnableAll || enabled.Has("tenancyreplicationclusterrolebinding") {
-		if err := s.installTenancyReplicateClusterRoleBindingControllers(ctx, controllerConfig); err != nil {
-			return err
-		}
+	if err := s.installSchemaCompatibilityController(ctx, controllerConfig); err != nil {
+		return err
 	}
-
-	if s.Options.Controllers.EnableAll || enabled.Has("apiexportendpointslice") {
-		if err := s.installAPIExportEndpointSliceController(ctx, controllerConfig); err != nil {
-			return err
-		}
-		if err := s.installAPIExportEndpointSliceURLsController(ctx, controllerConfig); err != nil {
-			return err
-		}
+	if err := s.installSystemCRDRepairController(ctx, controllerConfig); err != nil {
+		return err
 	}
-
-	if s.Options.Controllers.EnableAll || enabled.Has("apibinder") {
-		if err := s.installAPIBinderController(ctx, controllerConfig); err != nil {
-			return err
-		}
+	if err := s.installAPIExportController(ctx, controllerConfig); err != nil {
+		return err
 	}
-
-	if s.Options.Controllers.EnableAll || enabled.Has("partition") {
-		if err := s.installPartitionSetController(ctx, controllerConfig); err != nil {
-			return err
-		}
+	if err := s.installAPIExportIdentityConflictController(ctx, controllerConfig); err != nil {
+		return err
 	}
-
-	if s.Options.Controllers.EnableAll || enabled.Has("quota") {
-		if err := s.installKubeQuotaController(ctx, controllerConfig); err != nil {
-			return err
-		}
+	if err := s.installAPIExportExpiryController(ctx, controllerConfig); err != nil {
+		return err
 	}
-
-	if s.Options.Controllers.EnableAll || enabled.Has("garbagecollector") {
-		if err := s.installGarbageCollectorController(ctx, controllerConfig); err != nil {
-			return err
-		}
+	if err := s.installAPIExportBindingCountController(ctx, controllerConfig); err != nil {
+		return err
+	}
+	if err := s.installAPIExportDeletionController(ctx, controllerConfig); err != nil {
+		return err
+	}
+	if err := s.installApisReplicateClusterRoleControllers(ctx, controllerConfig); err != nil {
+		return err
+	}
+	if err := s.installApisReplicateClusterRoleBindingControllers(ctx, controllerConfig); err != nil {
+		return err
+	}
+	if err := s.installApisReplicateLogicalClusterControllers(ctx, controllerConfig); err != nil {
+		return err
+	}
+	if err := s.installTenancyReplicateLogicalClusterControllers(ctx, controllerConfig); err != nil {
+		return err
+	}
+	if err := s.installCoreReplicateClusterRoleControllers(ctx, controllerConfig); err != nil {
+		return err
+	}
+	if err := s.installCoreReplicateClusterRoleBindingControllers(ctx, controllerConfig); err != nil {
+		return err
+	}
+	if err := s.installTenancyReplicateClusterRoleControllers(ctx, controllerConfig); err != nil {
+		return err
+	}
+	if err := s.installTenancyReplicateClusterRoleBindingControllers(ctx, controllerConfig); err != nil {
+		return err
+	}
+	if err := s.installAPIExportEndpointSliceController(ctx, controllerConfig); err != nil {
+		return err
+	}
+	if err := s.installAPIExportEndpointSliceURLsController(ctx, controllerConfig); err != nil {
+		return err
+	}
+	if err := s.installAPIBinderController(ctx, controllerConfig); err != nil {
+		return err
+	}
+	if err := s.installPartitionSetController(ctx, controllerConfig); err != nil {
+		return err
+	}
+	if err := s.installKubeQuotaController(ctx, controllerConfig); err != nil {
+		return err
+	}
+	if err := s.installGarbageCollectorController(ctx, controllerConfig); err != nil {
+		return err
 	}
-
 	return nil
 }
+
 func (s *Server) Run(ctx context.Context) error {
 	logger := klog.FromContext(ctx).WithValues("component", "kcp")
 	ctx = klog.NewContext(ctx, logger)
@@ -364,10 +382,12 @@ func (s *Server) Run(ctx context.Context) error {
 		logger.Info("starting kube informers")
 		s.KubeSharedInformerFactory.Start(hookCtx.Done())
 		s.ApiExtensionsSharedInformerFactory.Start(hookCtx.Done())
+		s.ControllerApiExtensionsSharedInformerFactory.Start(hookCtx.Done())
 		s.CacheKubeSharedInformerFactory.Start(hookCtx.Done())
 
 		s.KubeSharedInformerFactory.WaitForCacheSync(hookCtx.Done())
 		s.ApiExtensionsSharedInformerFactory.WaitForCacheSync(hookCtx.Done())
+		s.ControllerApiExtensionsSharedInformerFactory.WaitForCacheSync(hookCtx.Done())
 		s.CacheKubeSharedInformerFactory.WaitForCacheSync(hookCtx.Done())
 
 		select {
@@ -473,9 +493,11 @@ func (s *Server) Run(ctx context.Context) error {
 		}
 
 		s.KcpSharedInformerFactory.Start(hookCtx.Done())
+		s.ControllerKcpSharedInformerFactory.Start(hookCtx.Done())
 		s.CacheKcpSharedInformerFactory.Start(hookCtx.Done())
 
 		s.KcpSharedInformerFactory.WaitForCacheSync(hookCtx.Done())
+		s.ControllerKcpSharedInformerFactory.WaitForCacheSync(hookCtx.Done())
 		s.CacheKcpSharedInformerFactory.WaitForCacheSync(hookCtx.Done())
 
 		// create or update shard
@@ -561,8 +583,39 @@ func (s *Server) Run(ctx context.Context) error {
 	// TODO: split apart everything after this line, into their own commands, optional launched in this process
 
 	controllerConfig := s.IdentityConfig
+	if keepAlive, idleConnTimeout := s.Options.Controllers.ClientKeepAlive, s.Options.Controllers.ClientIdleConnTimeout; keepAlive > 0 || idleConnTimeout > 0 {
+		controllerConfig = rest.CopyConfig(controllerConfig)
+		// a custom dialer gives the controllers their own transports instead of
+		// changing the cached one shared with the loopback clients.
+		controllerConfig.Dial = network.DefaultDialContext()
+		controllerConfig.Wrap(network.KeepAliveTransportWrapper(keepAlive, idleConnTimeout))
+	}
+	if s.Options.Controllers.AuditAnnotations {
+		controllerConfig = rest.CopyConfig(controllerConfig)
+		controllerConfig.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+			return &controllerHeaderRoundTripper{delegate: rt}
+		})
+	}
+
+	limits.SetMaxInFlightReconciles(s.Options.Controllers.MaxInFlightReconciles)
+	limits.SetTracerProvider(s.GenericConfig.TracerProvider)
+	reconcileTimeouts, err := s.Options.Controllers.ReconcileTimeoutDurations()
+	if err != nil {
+		return err
+	}
+	limits.SetReconcileTimeouts(reconcileTimeouts)
+	limits.SetMaxRetries(s.Options.Controllers.MaxRetries)
+	eventBroadcaster := record.NewBroadcaster(record.WithContext(ctx))
+	eventBroadcaster.StartRecordingToSink(events.NewClusterAwareSink(s.KubeClusterClient))
+	limits.SetEventRecorder(eventBroadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: "kcp-controllers"}))
+	logging.SetTraceKeys(s.Options.Controllers.ReconcileTraceKeys)
+	logging.SetTraceAnnotationEnabled(s.Options.Controllers.ReconcileTraceAnnotation)
 
-	gvrs := s.addIndexersToInformers(ctx)
+	gvrs := s.addIndexersToInformers(ctx, s.ControllerKcpSharedInformerFactory)
+	if s.ControllerKcpSharedInformerFactory != s.KcpSharedInformerFactory {
+		// admission uses some of the indexers too, e.g. of the permission claim labeler.
+		s.addIndexersToInformers(ctx, s.KcpSharedInformerFactory)
+	}
 	if err := s.installControllers(ctx, controllerConfig, gvrs); err != nil {
 		return err
 	}
@@ -574,6 +627,12 @@ func (s *Server) Run(ctx context.Context) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddPreShutdownHook("kcp-drain-controllers", func() error {
+		s.drainControllers(klog.NewContext(context.Background(), logger))
+		return nil
+	}); err != nil {
+		return err
+	}
 	if len(s.Options.Cache.Client.KubeconfigFile) == 0 {
 		if err := s.installCacheServer(ctx); err != nil {
 			return err
@@ -627,7 +686,22 @@ func (s *Server) Run(ctx context.Context) error {
 		return err
 	}
 
-	return s.MiniAggregator.GenericAPIServer.PrepareRun().RunWithContext(ctx)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	runErrCh := make(chan error, 1)
+	go func() {
+		runErrCh <- s.MiniAggregator.GenericAPIServer.PrepareRun().RunWithContext(ctx)
+	}()
+
+	select {
+	case err := <-runErrCh:
+		return err
+	case err := <-s.controllerStartupErrCh:
+		// shut down gracefully, but fail, such that the process exits non-zero.
+		cancel()
+		<-runErrCh
+		return err
+	}
 }
 
 type handlerChainMuxes []*http.ServeMux
@@ -229,6 +229,15 @@ const (
 	// PermissionClaimsApplied is a condition for APIBinding that indicates that all the accepted permission claims
 	// have been applied.
 	PermissionClaimsApplied conditionsv1alpha1.ConditionType = "PermissionClaimsApplied"
+
+	// SchemasCompatible is a condition for APIBinding that indicates whether the latest APIResourceSchemas of the
+	// APIExport are compatible with the bound ones, i.e. do not remove served versions or fields, change field types
+	// or require new fields.
+	SchemasCompatible conditionsv1alpha1.ConditionType = "SchemasCompatible"
+
+	// IncompatibleSchemaChangeReason is a reason for the SchemasCompatible condition that at least one of the latest
+	// APIResourceSchemas of the APIExport contains breaking changes.
+	IncompatibleSchemaChangeReason = "IncompatibleSchemaChange"
 )
 
 // These are annotations for bound CRDs.
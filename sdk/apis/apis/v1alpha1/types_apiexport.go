@@ -35,6 +35,22 @@ const (
 	APIExportVirtualWorkspaceURLsReady conditionsv1alpha1.ConditionType = "VirtualWorkspaceURLsReady"
 
 	ErrorGeneratingURLsReason = "ErrorGeneratingURLs"
+
+	// APIExportIdentityUnique is a condition for APIExport that reflects whether no other APIExport
+	// exports any of the same resources under the same identity hash.
+	APIExportIdentityUnique conditionsv1alpha1.ConditionType = "IdentityUnique"
+
+	// DuplicateIdentityReason is a reason for the IdentityUnique condition that another APIExport
+	// exports at least one of the same resources under the same identity hash.
+	DuplicateIdentityReason = "DuplicateIdentity"
+
+	// APIExportInUse is a condition for APIExport that reflects whether the APIExport is bound by
+	// any APIBinding. It is only maintained if expiry of unused APIExports is enabled.
+	APIExportInUse conditionsv1alpha1.ConditionType = "InUse"
+
+	// UnusedReason is a reason for the InUse condition that the APIExport has not been bound by
+	// any APIBinding for longer than the configured expiry age.
+	UnusedReason = "Unused"
 )
 
 // These are for APIExport identity.
@@ -54,6 +70,7 @@ const (
 // +kubebuilder:resource:scope=Cluster,categories=kcp
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=`.status.conditions[?(@.type=="VirtualWorkspaceURLsReady")].status`
+// +kubebuilder:printcolumn:name="Bindings",type="integer",JSONPath=`.status.bindingCount`
 type APIExport struct {
 	metav1.TypeMeta `json:",inline"`
 	// +optional
@@ -297,6 +314,13 @@ type APIExportStatus struct {
 	//
 	// +optional
 	VirtualWorkspaces []VirtualWorkspace `json:"virtualWorkspaces,omitempty"`
+
+	// bindingCount is the number of APIBindings bound to this APIExport on the shard of the
+	// APIExport. It is a per-shard count: APIBindings in workspaces on other shards are not
+	// included.
+	//
+	// +optional
+	BindingCount int32 `json:"bindingCount,omitempty"`
 }
 
 type VirtualWorkspace struct {
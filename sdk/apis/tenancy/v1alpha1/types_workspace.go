@@ -57,6 +57,11 @@ const (
 	ExperimentalWorkspaceOwnerAnnotationKey string = "experimental.tenancy.kcp.io/owner"
 	// ExperimentalWorkspaceMountAnnotationKey is the annotation key used to indicate the mounts of the workspace.
 	ExperimentalWorkspaceMountAnnotationKey string = "experimental.tenancy.kcp.io/mount"
+	// ExperimentalWorkspaceArchivedAnnotationKey is the annotation key used to archive a workspace. If set
+	// to "true", the content of the workspace is retained, but becomes read-only. The annotation is propagated
+	// to the LogicalCluster of the workspace. To unarchive a workspace, set it to "false". Removing the
+	// annotation is not propagated.
+	ExperimentalWorkspaceArchivedAnnotationKey string = "experimental.tenancy.kcp.io/archived"
 )
 
 // These are valid conditions of workspace.
@@ -118,6 +123,7 @@ const LogicalClusterTypeAnnotationKey = "internal.tenancy.kcp.io/type"
 // +kubebuilder:printcolumn:name="Type",type=string,JSONPath=`.spec.type.name`,description="Type of the workspace"
 // +kubebuilder:printcolumn:name="Region",type=string,JSONPath=`.metadata.labels['region']`,description="The region this workspace is in"
 // +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.metadata.labels['tenancy\.kcp\.io/phase']`,description="The current phase (e.g. Scheduling, Initializing, Ready, Deleting)"
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`,description="Whether the workspace is scheduled, initialized and mounted"
 // +kubebuilder:printcolumn:name="URL",type=string,JSONPath=`.spec.URL`,description="URL to access the workspace"
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 type Workspace struct {
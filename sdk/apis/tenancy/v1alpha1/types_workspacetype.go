@@ -89,6 +89,12 @@ type WorkspaceTypeSpec struct {
 	// +optional
 	AdditionalWorkspaceLabels map[string]string `json:"additionalWorkspaceLabels,omitempty"`
 
+	// additionalWorkspaceAnnotations are a set of annotations that will be added to a
+	// Workspace on creation, and restored if they are removed or changed later.
+	//
+	// +optional
+	AdditionalWorkspaceAnnotations map[string]string `json:"additionalWorkspaceAnnotations,omitempty"`
+
 	// defaultChildWorkspaceType is the WorkspaceType that will be used
 	// by default if another, nested Workspace is created in a workspace
 	// of this type. When this field is unset, the user must specify a type when
@@ -168,8 +174,12 @@ type WorkspaceTypeExtension struct {
 // These are valid conditions of WorkspaceType.
 const (
 	WorkspaceTypeVirtualWorkspaceURLsReady conditionsv1alpha1.ConditionType = "VirtualWorkspaceURLsReady"
+	// WorkspaceTypeVirtualWorkspacesReachable is true if all virtual workspace URLs in the status
+	// accept connections.
+	WorkspaceTypeVirtualWorkspacesReachable conditionsv1alpha1.ConditionType = "VirtualWorkspacesReachable"
 
-	ErrorGeneratingURLsReason = "ErrorGeneratingURLs"
+	ErrorGeneratingURLsReason         = "ErrorGeneratingURLs"
+	VirtualWorkspaceUnreachableReason = "VirtualWorkspaceUnreachable"
 )
 
 // WorkspaceTypeStatus defines the observed state of WorkspaceType.
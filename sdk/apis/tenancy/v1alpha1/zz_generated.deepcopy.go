@@ -401,6 +401,13 @@ func (in *WorkspaceTypeSpec) DeepCopyInto(out *WorkspaceTypeSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.AdditionalWorkspaceAnnotations != nil {
+		in, out := &in.AdditionalWorkspaceAnnotations, &out.AdditionalWorkspaceAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.DefaultChildWorkspaceType != nil {
 		in, out := &in.DefaultChildWorkspaceType, &out.DefaultChildWorkspaceType
 		*out = new(WorkspaceTypeReference)
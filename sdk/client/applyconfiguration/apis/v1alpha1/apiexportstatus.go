@@ -28,6 +28,7 @@ type APIExportStatusApplyConfiguration struct {
 	IdentityHash      *string                              `json:"identityHash,omitempty"`
 	Conditions        *v1alpha1.Conditions                 `json:"conditions,omitempty"`
 	VirtualWorkspaces []VirtualWorkspaceApplyConfiguration `json:"virtualWorkspaces,omitempty"`
+	BindingCount      *int32                               `json:"bindingCount,omitempty"`
 }
 
 // APIExportStatusApplyConfiguration constructs a declarative configuration of the APIExportStatus type for use with
@@ -64,3 +65,11 @@ func (b *APIExportStatusApplyConfiguration) WithVirtualWorkspaces(values ...*Vir
 	}
 	return b
 }
+
+// WithBindingCount sets the BindingCount field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the BindingCount field is set to the value of the last call.
+func (b *APIExportStatusApplyConfiguration) WithBindingCount(value int32) *APIExportStatusApplyConfiguration {
+	b.BindingCount = &value
+	return b
+}
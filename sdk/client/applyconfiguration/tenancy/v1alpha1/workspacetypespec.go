@@ -21,13 +21,14 @@ package v1alpha1
 // WorkspaceTypeSpecApplyConfiguration represents a declarative configuration of the WorkspaceTypeSpec type for use
 // with apply.
 type WorkspaceTypeSpecApplyConfiguration struct {
-	Initializer               *bool                                     `json:"initializer,omitempty"`
-	Extend                    *WorkspaceTypeExtensionApplyConfiguration `json:"extend,omitempty"`
-	AdditionalWorkspaceLabels map[string]string                         `json:"additionalWorkspaceLabels,omitempty"`
-	DefaultChildWorkspaceType *WorkspaceTypeReferenceApplyConfiguration `json:"defaultChildWorkspaceType,omitempty"`
-	LimitAllowedChildren      *WorkspaceTypeSelectorApplyConfiguration  `json:"limitAllowedChildren,omitempty"`
-	LimitAllowedParents       *WorkspaceTypeSelectorApplyConfiguration  `json:"limitAllowedParents,omitempty"`
-	DefaultAPIBindings        []APIExportReferenceApplyConfiguration    `json:"defaultAPIBindings,omitempty"`
+	Initializer                    *bool                                     `json:"initializer,omitempty"`
+	Extend                         *WorkspaceTypeExtensionApplyConfiguration `json:"extend,omitempty"`
+	AdditionalWorkspaceLabels      map[string]string                         `json:"additionalWorkspaceLabels,omitempty"`
+	AdditionalWorkspaceAnnotations map[string]string                         `json:"additionalWorkspaceAnnotations,omitempty"`
+	DefaultChildWorkspaceType      *WorkspaceTypeReferenceApplyConfiguration `json:"defaultChildWorkspaceType,omitempty"`
+	LimitAllowedChildren           *WorkspaceTypeSelectorApplyConfiguration  `json:"limitAllowedChildren,omitempty"`
+	LimitAllowedParents            *WorkspaceTypeSelectorApplyConfiguration  `json:"limitAllowedParents,omitempty"`
+	DefaultAPIBindings             []APIExportReferenceApplyConfiguration    `json:"defaultAPIBindings,omitempty"`
 }
 
 // WorkspaceTypeSpecApplyConfiguration constructs a declarative configuration of the WorkspaceTypeSpec type for use with
@@ -66,6 +67,20 @@ func (b *WorkspaceTypeSpecApplyConfiguration) WithAdditionalWorkspaceLabels(entr
 	return b
 }
 
+// WithAdditionalWorkspaceAnnotations puts the entries into the AdditionalWorkspaceAnnotations field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the AdditionalWorkspaceAnnotations field,
+// overwriting an existing map entries in AdditionalWorkspaceAnnotations field with the same key.
+func (b *WorkspaceTypeSpecApplyConfiguration) WithAdditionalWorkspaceAnnotations(entries map[string]string) *WorkspaceTypeSpecApplyConfiguration {
+	if b.AdditionalWorkspaceAnnotations == nil && len(entries) > 0 {
+		b.AdditionalWorkspaceAnnotations = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.AdditionalWorkspaceAnnotations[k] = v
+	}
+	return b
+}
+
 // WithDefaultChildWorkspaceType sets the DefaultChildWorkspaceType field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the DefaultChildWorkspaceType field is set to the value of the last call.
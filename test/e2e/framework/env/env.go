@@ -40,3 +40,8 @@ func RunDelveEnvSet() bool {
 	envSet, _ := strconv.ParseBool(os.Getenv("RUN_DELVE"))
 	return envSet
 }
+
+func JSONArtifactsEnvSet() bool {
+	envSet, _ := strconv.ParseBool(os.Getenv("JSON_ARTIFACTS"))
+	return envSet
+}
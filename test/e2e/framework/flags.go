@@ -40,7 +40,7 @@ type testConfig struct {
 	kcpKubeconfig       string
 	shardKubeconfigs    map[string]string
 	useDefaultKCPServer bool
-	suites              string
+	suites              suitesValue
 }
 
 var TestConfig *testConfig
@@ -66,7 +66,22 @@ func (c *testConfig) ShardKubeconfig() map[string]string {
 }
 
 func (c *testConfig) Suites() []string {
-	return strings.Split(c.suites, ",")
+	return strings.Split(string(c.suites), ",")
+}
+
+// suitesValue is a flag value of comma-delimited suite names, rejecting unknown suites.
+type suitesValue string
+
+func (s *suitesValue) String() string {
+	return string(*s)
+}
+
+func (s *suitesValue) Set(value string) error {
+	if err := validateSuites(strings.Split(value, ",")); err != nil {
+		return err
+	}
+	*s = suitesValue(value)
+	return nil
 }
 
 func init() {
@@ -79,5 +94,6 @@ func registerFlags(c *testConfig) {
 	flag.StringVar(&c.kcpKubeconfig, "kcp-kubeconfig", "", "Path to the kubeconfig for a kcp server.")
 	flag.Var(cliflag.NewMapStringString(&c.shardKubeconfigs), "shard-kubeconfigs", "Paths to the kubeconfigs for a kcp shard server in the format <shard-name>=<kubeconfig-path>. If unset, kcp-kubeconfig is used.")
 	flag.BoolVar(&c.useDefaultKCPServer, "use-default-kcp-server", false, "Whether to use server configuration from .kcp/admin.kubeconfig.")
-	flag.StringVar(&c.suites, "suites", "control-plane", "A comma-delimited list of suites to run.")
+	c.suites = "control-plane"
+	flag.Var(&c.suites, "suites", "A comma-delimited list of suites to run.")
 }
@@ -25,11 +25,30 @@ type Config struct {
 	ArtifactDir string
 	DataDir     string
 	ClientCADir string
+	// RootDirImage is an optional pre-populated root directory, e.g. with etcd data and
+	// certificates of an earlier run. It is copied into the data directory before start.
+	RootDirImage string
+	// LogFilters are regular expressions of known-noise log lines that are dropped when
+	// the logs of a failed kcp are printed. DefaultLogFilters is used if empty.
+	LogFilters []string
+	// ExternalEtcdEndpoints are the URLs of an etcd to use instead of an embedded one.
+	// Every server gets its own random key prefix in it.
+	ExternalEtcdEndpoints []string
+	// ArgsByShard are additional arguments by shard name, appended to Args for the server
+	// running that shard, e.g. to enable a feature gate only on the root shard when the
+	// same configuration is used for several shards.
+	ArgsByShard map[string][]string
 
 	LogToConsole bool
 	RunInProcess bool
 }
 
+// DefaultLogFilters are the log filters used if a configuration does not specify any.
+var DefaultLogFilters = []string{
+	// TODO: some careful thought on context cancellation might fix the following error
+	`clientconn\.go:1326\] \[core\] grpc: addrConn\.createTransport failed to connect to`,
+}
+
 // Option a function that wish to modify a given kcp configuration.
 type Option func(*Config) *Config
 
@@ -49,3 +68,36 @@ func WithCustomArguments(args ...string) Option {
 		return cfg
 	}
 }
+
+// WithShardArguments adds arguments used only if the configuration runs the given shard.
+func WithShardArguments(shard string, args ...string) Option {
+	return func(cfg *Config) *Config {
+		if cfg.ArgsByShard == nil {
+			cfg.ArgsByShard = map[string][]string{}
+		}
+		cfg.ArgsByShard[shard] = append(cfg.ArgsByShard[shard], args...)
+		return cfg
+	}
+}
+
+// WithRootDirectoryImage starts kcp with a copy of the given pre-populated root directory,
+// which typically contains etcd data and certificates of an earlier run. The image itself
+// is not modified, so it can be shared between tests.
+func WithRootDirectoryImage(dir string) Option {
+	return func(cfg *Config) *Config {
+		cfg.RootDirImage = dir
+		return cfg
+	}
+}
+
+// WithLogFilters adds regular expressions of known-noise log lines to drop when the logs
+// of a failed kcp are printed. They extend DefaultLogFilters.
+func WithLogFilters(patterns ...string) Option {
+	return func(cfg *Config) *Config {
+		if len(cfg.LogFilters) == 0 {
+			cfg.LogFilters = append(cfg.LogFilters, DefaultLogFilters...)
+		}
+		cfg.LogFilters = append(cfg.LogFilters, patterns...)
+		return cfg
+	}
+}
@@ -18,6 +18,7 @@ package server
 
 import (
 	"bytes"
+	"context"
 	cryptorand "crypto/rand"
 	"crypto/rsa"
 	"crypto/tls"
@@ -160,6 +161,35 @@ func (s *externalKCPServer) Artifact(t *testing.T, producer func() (runtime.Obje
 	artifact(t, s, producer)
 }
 
+// Restart is not supported for external servers, their lifecycle is not managed by the test.
+func (s *externalKCPServer) Restart(opts ...RunOption) error {
+	return fmt.Errorf("cannot restart external kcp server %s", s.name)
+}
+
+// WaitForExit is not supported for external servers, their lifecycle is not managed by the test.
+func (s *externalKCPServer) WaitForExit(ctx context.Context) (int, error) {
+	return 0, fmt.Errorf("cannot wait for external kcp server %s to exit", s.name)
+}
+
+// WaitForController waits until the named controller got past waiting for its informers to
+// sync on all shards.
+func (s *externalKCPServer) WaitForController(ctx context.Context, name string) error {
+	for _, shard := range s.ShardNames() {
+		raw, err := s.shardCfgs[shard].RawConfig()
+		if err != nil {
+			return err
+		}
+		cfg, err := clientcmd.NewNonInteractiveClientConfig(raw, "shard-base", nil, nil).ClientConfig()
+		if err != nil {
+			return err
+		}
+		if err := waitForController(ctx, cfg, name); err != nil {
+			return fmt.Errorf("shard %s: %w", shard, err)
+		}
+	}
+	return nil
+}
+
 // LoadKubeConfig loads a kubeconfig from disk. This method is
 // intended to be common between fixture for servers whose lifecycle
 // is test-managed and fixture for servers whose lifecycle is managed
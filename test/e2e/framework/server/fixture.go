@@ -20,13 +20,20 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"maps"
+	"net"
+	"net/url"
 	"os"
 	"os/exec"
 	"path"
 	"path/filepath"
+	"regexp"
 	"runtime/debug"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -42,6 +49,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
 	"k8s.io/apimachinery/pkg/util/wait"
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
 	kubernetesscheme "k8s.io/client-go/kubernetes/scheme"
@@ -113,7 +121,7 @@ func NewFixture(t *testing.T, cfgs ...Config) *Fixture {
 			err := s.loadCfg()
 			require.NoError(t, err, "error loading config")
 
-			err = WaitForReady(s.ctx, t, s.RootShardSystemMasterBaseConfig(t), !cfgs[i].RunInProcess)
+			err = WaitForReady(s.ctx, t, s.ShardSystemMasterBaseConfig(t, s.shardName), !cfgs[i].RunInProcess)
 			require.NoError(t, err, "kcp server %s never became ready: %v", s.name, err)
 		}(srv, i)
 	}
@@ -131,9 +139,7 @@ func NewFixture(t *testing.T, cfgs ...Config) *Fixture {
 		ctx, cancel := context.WithTimeout(context.Background(), wait.ForeverTestTimeout)
 		defer cancel()
 
-		for _, s := range servers {
-			gatherMetrics(ctx, t, s, s.artifactDir)
-		}
+		gatherMetricsForServers(ctx, t, servers)
 	})
 
 	t.Logf("Started kcp servers after %s", time.Since(start))
@@ -148,11 +154,21 @@ func NewFixture(t *testing.T, cfgs ...Config) *Fixture {
 //     concurrent execution within a test case and across tests
 type kcpServer struct {
 	name        string
+	shardName   string
 	args        []string
 	ctx         context.Context //nolint:containedctx
 	dataDir     string
 	artifactDir string
 	clientCADir string
+	logFilters  []*regexp.Regexp
+
+	// runOpts are the options of the last run.
+	runOpts []RunOption
+	// stop terminates the running kcp server and waits for it to shut down.
+	stop func()
+	// exited is closed when the kcp server of the last run has exited, after exitCode is set.
+	exited   chan struct{}
+	exitCode int
 
 	lock           *sync.Mutex
 	cfg            clientcmd.ClientConfig
@@ -168,13 +184,30 @@ func newKcpServer(t *testing.T, cfg Config, artifactDir, dataDir, clientCADir st
 	if err != nil {
 		return nil, err
 	}
-	etcdClientPort, err := GetFreePort(t)
-	if err != nil {
-		return nil, err
-	}
-	etcdPeerPort, err := GetFreePort(t)
-	if err != nil {
-		return nil, err
+	var etcdArgs []string
+	if len(cfg.ExternalEtcdEndpoints) > 0 {
+		if err := checkEtcdEndpoints(cfg.ExternalEtcdEndpoints); err != nil {
+			return nil, err
+		}
+		etcdArgs = []string{
+			"--etcd-servers=" + strings.Join(cfg.ExternalEtcdEndpoints, ","),
+			// a random prefix keeps servers and test runs sharing the etcd apart.
+			"--etcd-prefix=" + path.Join("/kcp-e2e", utilrand.String(8), cfg.Name),
+		}
+	} else {
+		etcdClientPort, err := GetFreePort(t)
+		if err != nil {
+			return nil, err
+		}
+		etcdPeerPort, err := GetFreePort(t)
+		if err != nil {
+			return nil, err
+		}
+		etcdArgs = []string{
+			"--embedded-etcd-client-port=" + etcdClientPort,
+			"--embedded-etcd-peer-port=" + etcdPeerPort,
+			"--embedded-etcd-wal-size-bytes=" + strconv.Itoa(5*1000), // 5KB
+		}
 	}
 	artifactDir = filepath.Join(artifactDir, "kcp", cfg.Name)
 	if err := os.MkdirAll(artifactDir, 0755); err != nil {
@@ -184,32 +217,123 @@ func newKcpServer(t *testing.T, cfg Config, artifactDir, dataDir, clientCADir st
 	if err := os.MkdirAll(dataDir, 0755); err != nil {
 		return nil, fmt.Errorf("could not create data dir: %w", err)
 	}
+	if cfg.RootDirImage != "" {
+		if err := copyDir(cfg.RootDirImage, dataDir); err != nil {
+			return nil, fmt.Errorf("could not copy root directory image %q: %w", cfg.RootDirImage, err)
+		}
+	}
+
+	logFilters, err := compileLogFilters(cfg.LogFilters)
+	if err != nil {
+		return nil, err
+	}
+
+	args := []string{
+		"--root-directory",
+		dataDir,
+		"--secure-port=" + kcpListenPort,
+	}
+	args = append(args, etcdArgs...)
+	args = append(args,
+		"--kubeconfig-path="+filepath.Join(dataDir, "admin.kubeconfig"),
+		"--feature-gates="+fmt.Sprintf("%s", utilfeature.DefaultFeatureGate),
+		"--audit-log-path", filepath.Join(artifactDir, "kcp.audit"),
+	)
+	args = append(args, cfg.Args...)
+	shardName := shardNameFromArgs(cfg.Args)
+	args = append(args, cfg.ArgsByShard[shardName]...)
 
 	return &kcpServer{
-		name: cfg.Name,
-		args: append([]string{
-			"--root-directory",
-			dataDir,
-			"--secure-port=" + kcpListenPort,
-			"--embedded-etcd-client-port=" + etcdClientPort,
-			"--embedded-etcd-peer-port=" + etcdPeerPort,
-			"--embedded-etcd-wal-size-bytes=" + strconv.Itoa(5*1000), // 5KB
-			"--kubeconfig-path=" + filepath.Join(dataDir, "admin.kubeconfig"),
-			"--feature-gates=" + fmt.Sprintf("%s", utilfeature.DefaultFeatureGate),
-			"--audit-log-path", filepath.Join(artifactDir, "kcp.audit"),
-		},
-			cfg.Args...),
+		name:        cfg.Name,
+		shardName:   shardName,
+		args:        args,
 		dataDir:     dataDir,
 		artifactDir: artifactDir,
+		logFilters:  logFilters,
 		clientCADir: clientCADir,
 		t:           t,
 		lock:        &sync.Mutex{},
 	}, nil
 }
 
+// checkEtcdEndpoints returns an error if any of the given etcd endpoints does not accept
+// connections.
+func checkEtcdEndpoints(endpoints []string) error {
+	for _, endpoint := range endpoints {
+		u, err := url.Parse(endpoint)
+		if err != nil || u.Host == "" {
+			return fmt.Errorf("invalid etcd endpoint %q, expected a URL like http://127.0.0.1:2379", endpoint)
+		}
+		conn, err := net.DialTimeout("tcp", u.Host, 5*time.Second)
+		if err != nil {
+			return fmt.Errorf("etcd endpoint %q is not reachable: %w", endpoint, err)
+		}
+		conn.Close()
+	}
+	return nil
+}
+
+// shardNameFromArgs returns the value of --shard-name in the given kcp arguments,
+// or the root shard name kcp defaults to.
+func shardNameFromArgs(args []string) string {
+	name := corev1alpha1.RootShard
+	for i, arg := range args {
+		switch {
+		case strings.HasPrefix(arg, "--shard-name="):
+			name = strings.TrimPrefix(arg, "--shard-name=")
+		case arg == "--shard-name" && i+1 < len(args):
+			name = args[i+1]
+		}
+	}
+	return name
+}
+
+// copyDir recursively copies the content of src into dst, preserving file modes.
+func copyDir(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return os.MkdirAll(target, info.Mode().Perm())
+		}
+		if !info.Mode().IsRegular() {
+			return nil // sockets, locks and the like are not part of the state
+		}
+
+		in, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer in.Close()
+		out, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, info.Mode().Perm())
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(out, in); err != nil {
+			out.Close()
+			return err
+		}
+		return out.Close()
+	})
+}
+
 type runOptions struct {
 	runInProcess bool
 	streamLogs   bool
+	expectExit   bool
+	env          map[string]string
+	logWriters   []io.Writer
 }
 
 type RunOption func(o *runOptions)
@@ -222,6 +346,46 @@ func WithLogStreaming(o *runOptions) {
 	o.streamLogs = true
 }
 
+// WithExpectedExit tolerates the kcp server exiting on its own, e.g. in negative tests
+// asserting that it fails to start. Instead of failing the test, the exit code is
+// available via WaitForExit.
+func WithExpectedExit(o *runOptions) {
+	o.expectExit = true
+}
+
+// WithEnv sets additional environment variables for the kcp process, on top of the
+// environment of the test. It is ignored when running in-process.
+func WithEnv(env map[string]string) RunOption {
+	return func(o *runOptions) {
+		if o.env == nil {
+			o.env = make(map[string]string, len(env))
+		}
+		maps.Copy(o.env, env)
+	}
+}
+
+// WithAdditionalLogWriter also writes the log of the kcp process to w, e.g. to a named pipe
+// to tail while debugging a single test. It is ignored when running in-process.
+func WithAdditionalLogWriter(w io.Writer) RunOption {
+	return func(o *runOptions) {
+		o.logWriters = append(o.logWriters, w)
+	}
+}
+
+// kcpCommand returns the command running the given command line with the given
+// environment variables on top of the environment of the test.
+func kcpCommand(commandLine []string, env map[string]string) *exec.Cmd {
+	cmd := exec.Command(commandLine[0], commandLine[1:]...)
+	if len(env) > 0 {
+		cmd.Env = os.Environ()
+		for k, v := range env {
+			// later entries win over the inherited ones
+			cmd.Env = append(cmd.Env, k+"="+v)
+		}
+	}
+	return cmd
+}
+
 // StartKcpCommand returns the string tokens required to start kcp in
 // the currently configured mode (direct or via `go run`).
 func StartKcpCommand(identity string) []string {
@@ -248,6 +412,7 @@ func Command(executableName, identity string) []string {
 // Run runs the kcp server while the parent context is active. This call is not blocking,
 // callers should ensure that the server is Ready() before using it.
 func (c *kcpServer) Run(opts ...RunOption) error {
+	c.runOpts = opts
 	runOpts := runOptions{}
 	for _, opt := range opts {
 		opt(&runOpts)
@@ -255,6 +420,8 @@ func (c *kcpServer) Run(opts ...RunOption) error {
 
 	// We close this channel when the kcp server has stopped
 	shutdownComplete := make(chan struct{})
+	exited := make(chan struct{})
+	c.exited = exited
 
 	ctx, cancel := context.WithCancel(context.Background())
 
@@ -262,6 +429,11 @@ func (c *kcpServer) Run(opts ...RunOption) error {
 		cancel()
 		close(shutdownComplete)
 	}
+	// exit records the exit code of the kcp server. It must be called once the server has exited.
+	exit := func(err error) {
+		c.exitCode = exitCodeOf(err)
+		close(exited)
+	}
 
 	c.t.Cleanup(func() {
 		c.t.Log("cleanup: canceling context")
@@ -276,6 +448,13 @@ func (c *kcpServer) Run(opts ...RunOption) error {
 	})
 	c.ctx = ctx
 
+	terminate := func() {}
+	c.stop = func() {
+		cancel()
+		terminate()
+		<-shutdownComplete
+	}
+
 	commandLine := append(StartKcpCommand("KCP"), c.args...)
 	c.t.Logf("running: %v", strings.Join(commandLine, " "))
 
@@ -334,7 +513,9 @@ func (c *kcpServer) Run(opts ...RunOption) error {
 		go func() {
 			defer cleanup()
 
-			if err := s.Run(ctx); err != nil && ctx.Err() == nil {
+			err := s.Run(ctx)
+			exit(err)
+			if err != nil && ctx.Err() == nil && !runOpts.expectExit {
 				c.t.Errorf("`kcp` failed: %v", err)
 			}
 		}()
@@ -344,7 +525,7 @@ func (c *kcpServer) Run(opts ...RunOption) error {
 
 	// NOTE: do not use exec.CommandContext here. That method issues a SIGKILL when the context is done, and we
 	// want to issue SIGTERM instead, to give the server a chance to shut down cleanly.
-	cmd := exec.Command(commandLine[0], commandLine[1:]...)
+	cmd := kcpCommand(commandLine, runOpts.env)
 
 	// Create a new process group for the child/forked process (which is either 'go run ...' or just 'kcp
 	// ...'). This is necessary so the SIGTERM we send to terminate the kcp server works even with the
@@ -354,7 +535,8 @@ func (c *kcpServer) Run(opts ...RunOption) error {
 	// the idea!
 	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
 
-	logFile, err := os.Create(filepath.Join(c.artifactDir, "kcp.log"))
+	// append to the log of an earlier run when restarting
+	logFile, err := os.OpenFile(filepath.Join(c.artifactDir, "kcp.log"), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
 	if err != nil {
 		cleanup()
 		return fmt.Errorf("could not create log file: %w", err)
@@ -375,6 +557,7 @@ func (c *kcpServer) Run(opts ...RunOption) error {
 		prefix := fmt.Sprintf("%s: ", c.name)
 		writers = append(writers, prefixer.New(os.Stdout, func() string { return prefix }))
 	}
+	writers = append(writers, runOpts.logWriters...)
 
 	mw := io.MultiWriter(writers...)
 	cmd.Stdout = mw
@@ -385,20 +568,25 @@ func (c *kcpServer) Run(opts ...RunOption) error {
 		return err
 	}
 
-	c.t.Cleanup(func() {
-		// Ensure child process is killed on cleanup - send the negative of the pid, which is the process group id.
-		// See https://medium.com/@felixge/killing-a-child-process-and-all-of-its-children-in-go-54079af94773 for details.
-		if err := syscall.Kill(-cmd.Process.Pid, syscall.SIGTERM); err != nil {
-			c.t.Errorf("Saw an error trying to kill `kcp`: %v", err)
-		}
-	})
+	var terminateOnce sync.Once
+	terminate = func() {
+		terminateOnce.Do(func() {
+			// Ensure child process is killed on cleanup - send the negative of the pid, which is the process group id.
+			// See https://medium.com/@felixge/killing-a-child-process-and-all-of-its-children-in-go-54079af94773 for details.
+			if err := syscall.Kill(-cmd.Process.Pid, syscall.SIGTERM); err != nil {
+				c.t.Errorf("Saw an error trying to kill `kcp`: %v", err)
+			}
+		})
+	}
+	c.t.Cleanup(terminate)
 
 	go func() {
 		defer cleanup()
 
 		err := cmd.Wait()
+		exit(err)
 
-		if err != nil && ctx.Err() == nil {
+		if err != nil && ctx.Err() == nil && !runOpts.expectExit {
 			// we care about errors in the process that did not result from the
 			// context expiring and us ending the process
 			data := c.filterKcpLogs(&log)
@@ -410,25 +598,99 @@ func (c *kcpServer) Run(opts ...RunOption) error {
 	return nil
 }
 
-// filterKcpLogs is a silly hack to get rid of the nonsense output that
-// currently plagues kcp. Yes, in the future we want to actually fix these
-// issues but until we do, there's no reason to force awful UX onto users.
+// WaitForExit waits until the kcp server of the last run has exited, and returns its exit
+// code. Run it with WithExpectedExit to not fail the test when it exits on its own.
+func (c *kcpServer) WaitForExit(ctx context.Context) (int, error) {
+	if c.exited == nil {
+		return 0, fmt.Errorf("kcp server %s has not been run", c.name)
+	}
+	select {
+	case <-c.exited:
+		return c.exitCode, nil
+	case <-ctx.Done():
+		return 0, fmt.Errorf("kcp server %s did not exit: %w", c.name, ctx.Err())
+	}
+}
+
+// WaitForController waits until the named controller got past waiting for its informers to
+// sync, e.g. before a test relies on its reconciler being live. A controller that is not
+// installed does not block.
+func (c *kcpServer) WaitForController(ctx context.Context, name string) error {
+	cfg, err := c.config("shard-base")
+	if err != nil {
+		return err
+	}
+	return waitForController(ctx, cfg, name)
+}
+
+// exitCodeOf returns the exit code of a kcp server that exited with the given error. An
+// in-process server failing to run counts as exit code 1, like the kcp binary.
+func exitCodeOf(err error) int {
+	if err == nil {
+		return 0
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode()
+	}
+	return 1
+}
+
+// Restart terminates the kcp server, waits for it to shut down and runs it again with
+// the same arguments and data directory. It returns when the server is ready again.
+// Without options, the options of the last run are used.
+func (c *kcpServer) Restart(opts ...RunOption) error {
+	c.t.Helper()
+
+	if len(opts) == 0 {
+		opts = c.runOpts
+	}
+
+	if c.stop == nil {
+		return fmt.Errorf("kcp server %s is not running", c.name)
+	}
+	c.t.Logf("restarting kcp server %s", c.name)
+	c.stop()
+
+	if err := c.Run(opts...); err != nil {
+		return err
+	}
+	if err := c.loadCfg(); err != nil {
+		return err
+	}
+
+	runOpts := runOptions{}
+	for _, opt := range opts {
+		opt(&runOpts)
+	}
+	return WaitForReady(c.ctx, c.t, c.ShardSystemMasterBaseConfig(c.t, c.shardName), !runOpts.runInProcess)
+}
+
+// compileLogFilters compiles the given log filter patterns, or DefaultLogFilters if empty.
+func compileLogFilters(patterns []string) ([]*regexp.Regexp, error) {
+	if len(patterns) == 0 {
+		patterns = DefaultLogFilters
+	}
+	filters := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid log filter %q: %w", pattern, err)
+		}
+		filters = append(filters, re)
+	}
+	return filters, nil
+}
+
+// filterKcpLogs drops the lines matching the log filters of the server, to get rid of
+// the nonsense output that currently plagues kcp. Yes, in the future we want to actually
+// fix these issues but until we do, there's no reason to force awful UX onto users.
 func (c *kcpServer) filterKcpLogs(logs *bytes.Buffer) string {
 	output := strings.Builder{}
 	scanner := bufio.NewScanner(logs)
 	for scanner.Scan() {
 		line := scanner.Bytes()
-		ignored := false
-		for _, ignore := range [][]byte{
-			// TODO: some careful thought on context cancellation might fix the following error
-			[]byte(`clientconn.go:1326] [core] grpc: addrConn.createTransport failed to connect to`),
-		} {
-			if bytes.Contains(line, ignore) {
-				ignored = true
-				continue
-			}
-		}
-		if ignored {
+		if slices.ContainsFunc(c.logFilters, func(re *regexp.Regexp) bool { return re.Match(line) }) {
 			continue
 		}
 		_, err := output.Write(append(line, []byte("\n")...))
@@ -491,25 +753,27 @@ func (c *kcpServer) BaseConfig(t *testing.T) *rest.Config {
 func (c *kcpServer) RootShardSystemMasterBaseConfig(t *testing.T) *rest.Config {
 	t.Helper()
 
-	cfg, err := c.config("shard-base")
-	require.NoError(t, err)
-	cfg = rest.CopyConfig(cfg)
-
-	return rest.AddUserAgent(cfg, t.Name())
+	return c.ShardSystemMasterBaseConfig(t, corev1alpha1.RootShard)
 }
 
 // ShardSystemMasterBaseConfig returns a rest.Config for the "shard-base" context of a given shard. Client-side throttling is disabled (QPS=-1).
+// A kcpServer runs exactly one shard, named by its --shard-name argument.
 func (c *kcpServer) ShardSystemMasterBaseConfig(t *testing.T, shard string) *rest.Config {
 	t.Helper()
 
-	if shard != corev1alpha1.RootShard {
-		t.Fatalf("only root shard is supported for now")
+	if shard != c.shardName {
+		t.Fatalf("kcp server %q runs shard %q, not %q", c.name, c.shardName, shard)
 	}
-	return c.RootShardSystemMasterBaseConfig(t)
+
+	cfg, err := c.config("shard-base")
+	require.NoError(t, err)
+	cfg = rest.CopyConfig(cfg)
+
+	return rest.AddUserAgent(cfg, t.Name())
 }
 
 func (c *kcpServer) ShardNames() []string {
-	return []string{corev1alpha1.RootShard}
+	return []string{c.shardName}
 }
 
 // RawConfig exposes a copy of the client config for this server.
@@ -562,7 +826,8 @@ func (c *kcpServer) Artifact(t *testing.T, producer func() (runtime.Object, erro
 }
 
 // artifact registers the data-producing function to run and dump the YAML-formatted output
-// to the artifact directory for the test before the kcp process is terminated.
+// to the artifact directory for the test before the kcp process is terminated. With
+// JSON_ARTIFACTS=true, the output is JSON-formatted instead.
 func artifact(t *testing.T, server RunningServer, producer func() (runtime.Object, error)) {
 	t.Helper()
 
@@ -602,10 +867,15 @@ func artifact(t *testing.T, server RunningServer, producer func() (runtime.Objec
 
 		gvkForFilename := fmt.Sprintf("%s_%s", group, gvk.Kind)
 
-		file := path.Join(dir, fmt.Sprintf("%s-%s.yaml", gvkForFilename, accessor.GetName()))
+		ext, marshal := "yaml", yaml.Marshal
+		if env.JSONArtifactsEnvSet() {
+			ext, marshal = "json", func(o interface{}) ([]byte, error) { return json.MarshalIndent(o, "", "  ") }
+		}
+
+		file := path.Join(dir, fmt.Sprintf("%s-%s.%s", gvkForFilename, accessor.GetName(), ext))
 		file = strings.ReplaceAll(file, ":", "_") // github actions don't like colon because NTFS is unhappy with it in path names
 
-		bs, err := yaml.Marshal(data)
+		bs, err := marshal(data)
 		require.NoError(t, err, "error marshalling artifact")
 
 		err = os.WriteFile(file, bs, 0644)
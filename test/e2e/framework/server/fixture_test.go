@@ -0,0 +1,99 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"bytes"
+	"errors"
+	"net"
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+)
+
+func TestWithEnv(t *testing.T) {
+	t.Setenv("KCP_FIXTURE_TEST_INHERITED", "inherited")
+
+	opts := runOptions{}
+	WithEnv(map[string]string{"KCP_FIXTURE_TEST": "foo"})(&opts)
+	WithEnv(map[string]string{"KCP_FIXTURE_TEST_INHERITED": "overridden"})(&opts)
+
+	cmd := kcpCommand([]string{"sh", "-c", `echo "$KCP_FIXTURE_TEST $KCP_FIXTURE_TEST_INHERITED"`}, opts.env)
+	out, err := cmd.Output()
+	require.NoError(t, err)
+	require.Equal(t, "foo overridden\n", string(out))
+}
+
+func TestWithAdditionalLogWriter(t *testing.T) {
+	var first, second bytes.Buffer
+
+	opts := runOptions{}
+	WithAdditionalLogWriter(&first)(&opts)
+	WithAdditionalLogWriter(&second)(&opts)
+	require.Len(t, opts.logWriters, 2)
+	require.Same(t, &first, opts.logWriters[0])
+	require.Same(t, &second, opts.logWriters[1])
+}
+
+func TestCheckEtcdEndpoints(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { l.Close() })
+
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	closedAddr := closed.Addr().String()
+	require.NoError(t, closed.Close())
+
+	require.NoError(t, checkEtcdEndpoints([]string{"http://" + l.Addr().String()}))
+	require.Error(t, checkEtcdEndpoints([]string{"http://" + l.Addr().String(), "http://" + closedAddr}))
+	require.Error(t, checkEtcdEndpoints([]string{l.Addr().String()}))
+}
+
+func TestExitCodeOf(t *testing.T) {
+	require.Equal(t, 0, exitCodeOf(nil))
+	require.Equal(t, 1, exitCodeOf(errors.New("in-process server failed")))
+
+	err := exec.Command("sh", "-c", "exit 3").Run()
+	require.Error(t, err)
+	require.Equal(t, 3, exitCodeOf(err))
+}
+
+func TestPendingControllersFromError(t *testing.T) {
+	err := errors.New(`an error on the server ("internal server error: controllers waiting for sync: kcp-apibinding, kcp-apiexport\n") has prevented the request from succeeding`)
+	pending := pendingControllersFromError(err)
+	require.True(t, pending.Has("kcp-apibinding"))
+	require.True(t, pending.Has("kcp-apiexport"))
+	require.False(t, pending.Has("kcp-apibindingdeletion"))
+
+	require.Empty(t, pendingControllersFromError(errors.New("connection refused")))
+}
+
+func TestNewKcpServerArgsByShard(t *testing.T) {
+	cfg := Config{Name: "shard-1", Args: []string{"--shard-name=shard-1"}}
+	WithShardArguments("shard-1", "--feature-gates=Foo=true")(&cfg)
+	WithShardArguments(corev1alpha1.RootShard, "--feature-gates=Bar=true")(&cfg)
+
+	srv, err := newKcpServer(t, cfg, t.TempDir(), t.TempDir(), "")
+	require.NoError(t, err)
+	require.Equal(t, "shard-1", srv.shardName)
+	require.Contains(t, srv.args, "--feature-gates=Foo=true")
+	require.NotContains(t, srv.args, "--feature-gates=Bar=true")
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package server
 
 import (
+	"context"
 	"testing"
 
 	"k8s.io/apimachinery/pkg/runtime"
@@ -35,4 +36,7 @@ type RunningServer interface {
 	Artifact(t *testing.T, producer func() (runtime.Object, error))
 	ClientCAUserConfig(t *testing.T, config *rest.Config, name string, groups ...string) *rest.Config
 	CADirectory() string
+	Restart(opts ...RunOption) error
+	WaitForExit(ctx context.Context) (int, error)
+	WaitForController(ctx context.Context, name string) error
 }
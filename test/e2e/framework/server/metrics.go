@@ -32,32 +32,52 @@ import (
 	"github.com/stretchr/testify/require"
 	gopkgyaml "gopkg.in/yaml.v3"
 
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/workqueue"
 
 	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned"
 	frameworkhelpers "github.com/kcp-dev/kcp/test/e2e/framework/helpers"
 )
 
-func gatherMetrics(ctx context.Context, t *testing.T, server RunningServer, directory string) {
-	cfg := server.RootShardSystemMasterBaseConfig(t)
+// gatherMetricsWorkers is the number of servers whose metrics are gathered in parallel.
+const gatherMetricsWorkers = 8
+
+// gatherMetrics writes the metrics of the server with the given config to the given directory.
+func gatherMetrics(ctx context.Context, cfg *rest.Config, name, directory string) error {
 	client, err := kcpclientset.NewForConfig(cfg)
 	if err != nil {
-		// Don't fail the test if we couldn't scrape metrics
-		t.Logf("error creating metrics client for server %s: %v", server.Name(), err)
+		return fmt.Errorf("error creating metrics client for server %s: %w", name, err)
 	}
 
 	raw, err := client.RESTClient().Get().RequestURI("/metrics").DoRaw(ctx)
 	if err != nil {
-		// Don't fail the test if we couldn't scrape metrics
-		t.Logf("error getting metrics for server %s: %v", server.Name(), err)
-		return
+		return fmt.Errorf("error getting metrics for server %s: %w", name, err)
 	}
 
-	metricsFile := filepath.Join(directory, fmt.Sprintf("%s-metrics.txt", server.Name()))
+	metricsFile := filepath.Join(directory, fmt.Sprintf("%s-metrics.txt", name))
 	if err := os.WriteFile(metricsFile, raw, 0o644); err != nil {
-		// Don't fail the test if we couldn't scrape metrics
-		t.Logf("error writing metrics file %s: %v", metricsFile, err)
+		return fmt.Errorf("error writing metrics file %s: %w", metricsFile, err)
+	}
+	return nil
+}
+
+// gatherMetricsForServers gathers the metrics of the given servers in parallel. Errors are
+// only logged, we don't fail the test if we couldn't scrape metrics.
+func gatherMetricsForServers(ctx context.Context, t *testing.T, servers []*kcpServer) {
+	// the configs must be created on the test goroutine, they might fail the test.
+	cfgs := make([]*rest.Config, len(servers))
+	for i, s := range servers {
+		cfgs[i] = s.ShardSystemMasterBaseConfig(t, s.shardName)
+	}
+
+	errs := make([]error, len(servers))
+	workqueue.ParallelizeUntil(ctx, gatherMetricsWorkers, len(servers), func(i int) {
+		errs[i] = gatherMetrics(ctx, cfgs[i], servers[i].name, servers[i].artifactDir)
+	})
+	if err := utilerrors.NewAggregate(errs); err != nil {
+		t.Logf("error gathering metrics: %v", err)
 	}
 }
 
@@ -75,7 +95,7 @@ func scrapeMetricsForServer(t *testing.T, srv RunningServer) {
 
 	ctx, cancel := context.WithTimeout(context.Background(), wait.ForeverTestTimeout)
 	defer cancel()
-	require.NoError(t, ScrapeMetrics(ctx, srv.RootShardSystemMasterBaseConfig(t), promUrl, frameworkhelpers.RepositoryDir(), jobName, filepath.Join(srv.CADirectory(), "apiserver.crt"), labels))
+	require.NoError(t, ScrapeMetrics(ctx, srv.ShardSystemMasterBaseConfig(t, srv.ShardNames()[0]), promUrl, frameworkhelpers.RepositoryDir(), jobName, filepath.Join(srv.CADirectory(), "apiserver.crt"), labels))
 }
 
 func ScrapeMetrics(ctx context.Context, cfg *rest.Config, promUrl, promCfgDir, jobName, caFile string, labels map[string]string) error {
@@ -113,6 +113,63 @@ func monitorEndpoint(ctx context.Context, t *testing.T, client *rest.RESTClient,
 	}, 100*time.Millisecond)
 }
 
+// waitForController waits until the named controller of the server at cfg got past waiting for
+// its informers to sync, i.e. until the controllers readyz check does not list it as pending
+// anymore. Controllers only start after the kcp-start-controllers post-start hook ran, and
+// with leader election only on the leader.
+func waitForController(ctx context.Context, cfg *rest.Config, name string) error {
+	cfg = rest.CopyConfig(cfg)
+	if cfg.NegotiatedSerializer == nil {
+		cfg.NegotiatedSerializer = kubernetesscheme.Codecs.WithoutConversion()
+	}
+
+	client, err := rest.UnversionedRESTClientFor(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create unversioned client: %w", err)
+	}
+
+	var lastError error
+	if err := wait.PollUntilContextCancel(ctx, 100*time.Millisecond, true, func(ctx context.Context) (bool, error) {
+		// the controllers are marked pending by the post-start hook, before that the check passes trivially.
+		if _, err := rest.NewRequest(client).RequestURI("/readyz/poststarthook/kcp-start-controllers").Do(ctx).Raw(); err != nil {
+			lastError = fmt.Errorf("controllers have not been started: %w", err)
+			return false, nil
+		}
+		_, err := rest.NewRequest(client).RequestURI("/readyz/controllers").Do(ctx).Raw()
+		if err == nil {
+			return true, nil
+		}
+		if pendingControllersFromError(err).Has(name) {
+			lastError = fmt.Errorf("controller %s is waiting for sync", name)
+			return false, nil
+		}
+		return true, nil
+	}); err != nil {
+		if lastError != nil {
+			return fmt.Errorf("%w: %v", err, lastError)
+		}
+		return err
+	}
+	return nil
+}
+
+// pendingControllersFromError returns the controllers that the failed controllers readyz check
+// reported as waiting for sync.
+func pendingControllersFromError(err error) sets.Set[string] {
+	const prefix = "controllers waiting for sync: "
+	msg := err.Error()
+	i := strings.Index(msg, prefix)
+	if i < 0 {
+		return nil
+	}
+	msg = msg[i+len(prefix):]
+	// the message is quoted in the error of the client, see unreadyComponentsFromError.
+	if j := strings.IndexAny(msg, `"\`); j >= 0 {
+		msg = msg[:j]
+	}
+	return sets.New[string](strings.Split(strings.TrimSpace(msg), ", ")...)
+}
+
 // there doesn't seem to be any simple way to get a metav1.Status from the Go client, so we get
 // the content in a string-formatted error, unfortunately.
 func unreadyComponentsFromError(err error) string {
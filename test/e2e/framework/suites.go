@@ -17,15 +17,31 @@ limitations under the License.
 package framework
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 
 	"k8s.io/apimachinery/pkg/util/sets"
 )
 
+// knownSuites are the suites tests can be part of.
+var knownSuites = sets.New[string]("control-plane")
+
+// validateSuites returns an error listing the valid suites if any of the given suites is unknown.
+func validateSuites(suites []string) error {
+	if unknown := sets.New[string](suites...).Difference(knownSuites); unknown.Len() > 0 {
+		return fmt.Errorf("unknown suites %s, valid suites are: %s", strings.Join(sets.List(unknown), ", "), strings.Join(sets.List(knownSuites), ", "))
+	}
+	return nil
+}
+
 // Suite should be called at the very beginning of a test case, to ensure that a test is only
 // run when the suite containing it is selected by the user running tests.
 func Suite(t *testing.T, suite string) {
 	t.Helper()
+	if !knownSuites.Has(suite) {
+		t.Fatalf("unknown suite %s, add it to the known suites", suite)
+	}
 	if !sets.New[string](TestConfig.Suites()...).Has(suite) {
 		t.Skipf("suite %s disabled", suite)
 	}
@@ -0,0 +1,33 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSuitesValue(t *testing.T) {
+	var suites suitesValue
+	require.NoError(t, suites.Set("control-plane"))
+	require.Equal(t, "control-plane", suites.String())
+
+	err := suites.Set("control-plane,control-pane")
+	require.EqualError(t, err, "unknown suites control-pane, valid suites are: control-plane")
+	require.Equal(t, "control-plane", suites.String(), "expected the value to be unchanged")
+}
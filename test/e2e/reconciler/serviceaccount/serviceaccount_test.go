@@ -0,0 +1,71 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serviceaccount
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+	frameworkserver "github.com/kcp-dev/kcp/test/e2e/framework/server"
+)
+
+func TestServiceAccountsToEnsure(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	args := append(framework.TestServerArgs(), "--service-accounts-to-ensure=default,builder")
+	server := framework.PrivateKcpServer(t, frameworkserver.WithCustomArguments(args...))
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	t.Cleanup(cancelFunc)
+
+	orgPath, _ := framework.NewOrganizationFixture(t, server)
+	wsPath, _ := framework.NewWorkspaceFixture(t, server, orgPath)
+
+	kubeClusterClient, err := kcpkubernetesclientset.NewForConfig(server.BaseConfig(t))
+	require.NoError(t, err)
+
+	t.Log("Creating namespace")
+	namespace, err := kubeClusterClient.Cluster(wsPath).CoreV1().Namespaces().Create(ctx, &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "e2e-sa-",
+		},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err, "failed to create namespace")
+
+	for _, name := range []string{"default", "builder"} {
+		t.Logf("Waiting for service account %q to be created", name)
+		require.Eventually(t, func() bool {
+			_, err := kubeClusterClient.Cluster(wsPath).CoreV1().ServiceAccounts(namespace.Name).Get(ctx, name, metav1.GetOptions{})
+			if apierrors.IsNotFound(err) {
+				return false
+			}
+			require.NoError(t, err)
+			return true
+		}, wait.ForeverTestTimeout, 100*time.Millisecond, "service account %q not created in namespace %s", name, namespace.Name)
+	}
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspace
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kcpclusterclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+func TestWorkspaceSurvivesRestart(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	t.Cleanup(cancelFunc)
+
+	server := framework.PrivateKcpServer(t)
+	orgPath, _ := framework.NewOrganizationFixture(t, server)
+	_, workspace := framework.NewWorkspaceFixture(t, server, orgPath)
+
+	t.Logf("Restarting kcp")
+	require.NoError(t, server.Restart())
+
+	kcpClient, err := kcpclusterclientset.NewForConfig(server.BaseConfig(t))
+	require.NoError(t, err)
+
+	t.Logf("Expect workspace %s to still exist", workspace.Name)
+	got, err := kcpClient.Cluster(orgPath).TenancyV1alpha1().Workspaces().Get(ctx, workspace.Name, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, workspace.UID, got.UID)
+}